@@ -31,6 +31,27 @@ func (app *application) isAuthorized(r *http.Request) bool {
 	return permitted(role, path)
 }
 
+func (app *application) isGM(r *http.Request) bool {
+	return app.sessionManager.GetString(r.Context(), roleKey) == core.RoleGM
+}
+
+// players may access their own characters, gms additionally those taking part in the campaigns they run
+func (app *application) canAccessCharacter(r *http.Request, character core.Character) (bool, error) {
+	userId := app.sessionManager.GetInt(r.Context(), authenticatedUserIdKey)
+	if character.CreatedBy == userId {
+		return true, nil
+	}
+	if character.CampaignID == 0 || !app.isGM(r) {
+		return false, nil
+	}
+
+	campaign, err := app.campaigns.Get(character.CampaignID)
+	if err != nil {
+		return false, err
+	}
+	return campaign.IsRunBy(userId), nil
+}
+
 func permitted(role string, path string) bool {
 	for key, perms := range permissions {
 		exp := regexp.MustCompile(key)
@@ -48,4 +69,5 @@ var permissions = map[string][]string{
 	"/logout":             {core.RolePlayer, core.RoleGM},
 	"/characters/\\d+/.*": {core.RolePlayer, core.RoleGM},
 	"/users/*/.*":         {core.RolePlayer, core.RoleGM},
+	"/campaigns/.*":       {core.RolePlayer, core.RoleGM},
 }
@@ -0,0 +1,149 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/winik100/NoPenNoPaper/internal/core"
+)
+
+const (
+	eventStats      = "stats"
+	eventItems      = "items"
+	eventNotes      = "notes"
+	eventHandouts   = "handouts"
+	eventCombat     = "combat"
+	eventRolls      = "rolls"
+	eventSkills     = "skills"
+	eventSanity     = "sanity"
+	eventAttributes = "attributes"
+	eventWeapons    = "weapons"
+	eventChase      = "chase"
+	eventMythos     = "mythos"
+	eventCash       = "cash"
+	eventBackstory  = "backstory"
+)
+
+// in-process pub/sub hub, every open page subscribes to the topic of the character or campaign it shows
+type eventHub struct {
+	mu          sync.Mutex
+	subscribers map[string]map[chan string]struct{}
+}
+
+func newEventHub() *eventHub {
+	return &eventHub{subscribers: make(map[string]map[chan string]struct{})}
+}
+
+func (h *eventHub) Subscribe(topic string) chan string {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	ch := make(chan string, 16)
+	if h.subscribers[topic] == nil {
+		h.subscribers[topic] = make(map[chan string]struct{})
+	}
+	h.subscribers[topic][ch] = struct{}{}
+	return ch
+}
+
+func (h *eventHub) Unsubscribe(topic string, ch chan string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	delete(h.subscribers[topic], ch)
+	if len(h.subscribers[topic]) == 0 {
+		delete(h.subscribers, topic)
+	}
+}
+
+// never blocks, subscribers that can't keep up miss the event
+func (h *eventHub) Publish(topic, event string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for ch := range h.subscribers[topic] {
+		select {
+		case ch <- event:
+		default:
+		}
+	}
+}
+
+func characterTopic(characterId int) string {
+	return fmt.Sprintf("character:%d", characterId)
+}
+
+func campaignTopic(campaignId int) string {
+	return fmt.Sprintf("campaign:%d", campaignId)
+}
+
+// for events shown on the character's sheet as well as on the pages of the campaign the character plays in
+func (app *application) publishCharacter(character core.Character, events ...string) {
+	for _, event := range events {
+		app.events.Publish(characterTopic(character.ID), event)
+		if character.CampaignID != 0 {
+			app.events.Publish(campaignTopic(character.CampaignID), event)
+		}
+	}
+}
+
+func (app *application) serveEvents(w http.ResponseWriter, r *http.Request, topic string) {
+	rc := http.NewResponseController(w)
+	// the server's write timeout would otherwise end the stream
+	err := rc.SetWriteDeadline(time.Time{})
+	if err != nil && !errors.Is(err, http.ErrNotSupported) {
+		app.serverError(w, r, err)
+		return
+	}
+
+	events := app.events.Subscribe(topic)
+	defer app.events.Unsubscribe(topic, events)
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	err = rc.Flush()
+	if err != nil {
+		return
+	}
+
+	keepAlive := time.NewTicker(30 * time.Second)
+	defer keepAlive.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-keepAlive.C:
+			fmt.Fprint(w, ": keep-alive\n\n")
+		case event := <-events:
+			fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event, event)
+		}
+		err = rc.Flush()
+		if err != nil {
+			return
+		}
+	}
+}
+
+func (app *application) characterEvents(w http.ResponseWriter, r *http.Request) {
+	characterId, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+	app.serveEvents(w, r, characterTopic(characterId))
+}
+
+func (app *application) campaignEvents(w http.ResponseWriter, r *http.Request) {
+	campaignId, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+	app.serveEvents(w, r, campaignTopic(campaignId))
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/winik100/NoPenNoPaper/internal/models/mocks"
+	"github.com/winik100/NoPenNoPaper/internal/testHelpers"
+)
+
+func TestEventHub(t *testing.T) {
+	hub := newEventHub()
+
+	subscribed := hub.Subscribe(characterTopic(1))
+	other := hub.Subscribe(characterTopic(2))
+
+	hub.Publish(characterTopic(1), eventStats)
+	testHelpers.Equal(t, <-subscribed, eventStats)
+	testHelpers.Equal(t, len(other), 0)
+
+	hub.Unsubscribe(characterTopic(1), subscribed)
+	hub.Publish(characterTopic(1), eventItems)
+	testHelpers.Equal(t, len(subscribed), 0)
+
+	// a full subscriber must not block publishing
+	for range cap(other) + 1 {
+		hub.Publish(characterTopic(2), eventNotes)
+	}
+	testHelpers.Equal(t, len(other), cap(other))
+}
+
+func TestCharacterEvents(t *testing.T) {
+	app := newTestApplication(t)
+
+	ts := newTestServer(t, app.sessionManager.LoadAndSave(app.mockSession(noSurf(app.authenticate(app.requireAuthentication(app.routesNoMW()))),
+		map[string]any{
+			authenticatedUserIdKey:   mocks.MockGM.ID,
+			authenticatedUserNameKey: mocks.MockGM.Name,
+		})))
+	defer ts.Close()
+
+	_, _, body := ts.get(t, "/characters/1")
+	validCSRF := extractCSRFToken(t, body)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, ts.URL+"/characters/1/events", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rs, err := ts.Client().Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rs.Body.Close()
+
+	testHelpers.Equal(t, rs.StatusCode, http.StatusOK)
+	testHelpers.Equal(t, rs.Header.Get("Content-Type"), "text/event-stream")
+
+	form := url.Values{}
+	form.Add("Name", "TP")
+	form.Add("Direction", "dec")
+	form.Add("csrf_token", validCSRF)
+	code, _, _ := ts.postForm(t, "/characters/1/editStat", form)
+	testHelpers.Equal(t, code, http.StatusOK)
+
+	line, err := bufio.NewReader(rs.Body).ReadString('\n')
+	if err != nil {
+		t.Fatal(err)
+	}
+	testHelpers.Equal(t, strings.TrimSpace(line), "event: "+eventStats)
+}
@@ -123,7 +123,10 @@ func (app *application) loginPost(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	redirect := fmt.Sprintf("/users/%s", form.Name)
+	redirect := app.sessionManager.PopString(r.Context(), redirectAfterLoginKey)
+	if redirect == "" {
+		redirect = fmt.Sprintf("/users/%s", form.Name)
+	}
 	http.Redirect(w, r, redirect, http.StatusSeeOther)
 }
 
@@ -0,0 +1,127 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/winik100/NoPenNoPaper/internal/core"
+	"github.com/winik100/NoPenNoPaper/internal/models"
+	"github.com/winik100/NoPenNoPaper/internal/validators"
+)
+
+const maxBackstoryEntryLength = 500
+
+type backstoryForm struct {
+	Section core.BackstorySection
+	Text    string
+}
+
+func (app *application) backstoryPost(w http.ResponseWriter, r *http.Request) {
+	character, ok := app.loadCharacter(w, r)
+	if !ok {
+		return
+	}
+
+	var form backstoryForm
+	err := app.decodePostForm(r, &form)
+	if err != nil {
+		app.clientError(w, http.StatusBadRequest)
+		return
+	}
+
+	if !validators.PermittedValue(form.Section, core.BackstorySections...) {
+		app.clientError(w, http.StatusUnprocessableEntity)
+		return
+	}
+
+	redirect := fmt.Sprintf("/characters/%d", character.ID)
+	form.Text = strings.TrimSpace(form.Text)
+	if !validators.NotBlank(form.Text) || !validators.MaxChars(form.Text, maxBackstoryEntryLength) {
+		app.sessionManager.Put(r.Context(), "flash", fmt.Sprintf("Ein Eintrag muss zwischen 1 und %d Zeichen lang sein.", maxBackstoryEntryLength))
+		http.Redirect(w, r, redirect, http.StatusSeeOther)
+		return
+	}
+
+	_, err = app.characters.AddBackstoryEntry(character.ID, form.Section, form.Text)
+	if err != nil {
+		app.serverError(w, r, err)
+		return
+	}
+
+	app.events.Publish(characterTopic(character.ID), eventBackstory)
+	http.Redirect(w, r, redirect, http.StatusSeeOther)
+}
+
+func (app *application) deleteBackstoryEntryPost(w http.ResponseWriter, r *http.Request) {
+	characterId, ok := app.characterIdFromPath(w, r)
+	if !ok {
+		return
+	}
+	entryId, err := strconv.Atoi(r.PathValue("entryId"))
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	err = app.characters.DeleteBackstoryEntry(characterId, entryId)
+	if err != nil {
+		app.serverError(w, r, err)
+		return
+	}
+
+	app.events.Publish(characterTopic(characterId), eventBackstory)
+	http.Redirect(w, r, fmt.Sprintf("/characters/%d", characterId), http.StatusSeeOther)
+}
+
+// only the GM running the character's campaign decides which entry keeps the investigator sane
+func (app *application) keyConnectionPost(w http.ResponseWriter, r *http.Request) {
+	character, ok := app.loadCharacter(w, r)
+	if !ok {
+		return
+	}
+
+	campaign, err := app.characterCampaign(character)
+	if err != nil {
+		app.serverError(w, r, err)
+		return
+	}
+	if !campaign.IsRunBy(app.sessionManager.GetInt(r.Context(), authenticatedUserIdKey)) {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	entryId, err := strconv.Atoi(r.PathValue("entryId"))
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+	entry, ok := character.Backstory.Entry(entryId)
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+
+	redirect := fmt.Sprintf("/characters/%d", character.ID)
+	if !entry.Section.CanBeKeyConnection() {
+		app.sessionManager.Put(r.Context(), "flash", "Nur Weltanschauung, wichtige Personen, bedeutsame Orte und wertvoller Besitz können Schlüsselverbindung sein.")
+		http.Redirect(w, r, redirect, http.StatusSeeOther)
+		return
+	}
+
+	err = app.characters.SetKeyConnection(character.ID, entry.ID)
+	if err != nil {
+		if errors.Is(err, models.ErrNoRecord) {
+			http.NotFound(w, r)
+		} else {
+			app.serverError(w, r, err)
+		}
+		return
+	}
+
+	app.events.Publish(characterTopic(character.ID), eventBackstory)
+	app.sessionManager.Put(r.Context(), "flash", fmt.Sprintf("Neue Schlüsselverbindung von %s: %s", character.Info.Name, entry.Text))
+	http.Redirect(w, r, redirect, http.StatusSeeOther)
+}
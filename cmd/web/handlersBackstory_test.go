@@ -0,0 +1,185 @@
+package main
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/winik100/NoPenNoPaper/internal/models/mocks"
+	"github.com/winik100/NoPenNoPaper/internal/testHelpers"
+)
+
+func TestBackstory(t *testing.T) {
+	app := newTestApplication(t)
+
+	ts := newTestServer(t, app.sessionManager.LoadAndSave(app.mockSession(noSurf(app.authenticate(app.requireAuthentication(app.routesNoMW()))),
+		map[string]any{
+			authenticatedUserIdKey:   mocks.MockPlayer.ID,
+			authenticatedUserNameKey: mocks.MockPlayer.Name,
+		})))
+	defer ts.Close()
+
+	code, _, body := ts.get(t, "/characters/1")
+
+	testHelpers.Equal(t, code, http.StatusOK)
+	testHelpers.StringContains(t, body, "<h3>Weltanschauung &amp; Überzeugungen</h3>")
+	testHelpers.StringContains(t, body, "Das Haus Hightower muss herrschen.")
+	testHelpers.StringContains(t, body, "Alicent, meine Tochter <strong>(Schlüsselverbindung)</strong>")
+	testHelpers.StringContains(t, body, "<h3>Verletzungen &amp; Narben</h3>")
+	if strings.Contains(body, "als Schlüsselverbindung markieren") {
+		t.Errorf("players must not be offered to tag a key connection")
+	}
+}
+
+func TestBackstoryPost(t *testing.T) {
+	app := newTestApplication(t)
+
+	ts := newTestServer(t, app.sessionManager.LoadAndSave(app.mockSession(noSurf(app.authenticate(app.requireAuthentication(app.routesNoMW()))),
+		map[string]any{
+			authenticatedUserIdKey:   mocks.MockPlayer.ID,
+			authenticatedUserNameKey: mocks.MockPlayer.Name,
+		})))
+	defer ts.Close()
+
+	_, _, body := ts.get(t, "/characters/1")
+	validCSRF := extractCSRFToken(t, body)
+
+	tests := []struct {
+		name      string
+		path      string
+		section   string
+		text      string
+		wantCode  int
+		wantFlash string
+	}{
+		{
+			name:     "Valid Entry",
+			path:     "/characters/1/backstory",
+			section:  "locations",
+			text:     "Der Hohe Turm",
+			wantCode: http.StatusSeeOther,
+		},
+		{
+			name:      "Blank Text",
+			path:      "/characters/1/backstory",
+			section:   "locations",
+			text:      " ",
+			wantCode:  http.StatusSeeOther,
+			wantFlash: "Ein Eintrag muss zwischen 1 und 500 Zeichen lang sein.",
+		},
+		{
+			name:      "Text Too Long",
+			path:      "/characters/1/backstory",
+			section:   "traits",
+			text:      strings.Repeat("a", 501),
+			wantCode:  http.StatusSeeOther,
+			wantFlash: "Ein Eintrag muss zwischen 1 und 500 Zeichen lang sein.",
+		},
+		{
+			name:     "Unknown Section",
+			path:     "/characters/1/backstory",
+			section:  "hobbies",
+			text:     "Bücher",
+			wantCode: http.StatusUnprocessableEntity,
+		},
+		{
+			name:     "Nonexistent Character",
+			path:     "/characters/69/backstory",
+			section:  "locations",
+			text:     "Der Hohe Turm",
+			wantCode: http.StatusNotFound,
+		},
+		{
+			name:     "Delete",
+			path:     "/characters/1/backstory/3/delete",
+			wantCode: http.StatusSeeOther,
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			form := url.Values{}
+			form.Add("Section", testCase.section)
+			form.Add("Text", testCase.text)
+			form.Add("csrf_token", validCSRF)
+
+			code, _, _ := ts.postForm(t, testCase.path, form)
+
+			testHelpers.Equal(t, code, testCase.wantCode)
+			if testCase.wantFlash != "" {
+				_, _, body := ts.get(t, "/characters/1")
+				testHelpers.StringContains(t, body, testCase.wantFlash)
+			}
+		})
+	}
+}
+
+func TestKeyConnectionPost(t *testing.T) {
+	tests := []struct {
+		name      string
+		userId    int
+		userName  string
+		path      string
+		wantCode  int
+		wantFlash string
+	}{
+		{
+			name:      "GM Tags Ideology",
+			userId:    mocks.MockGM.ID,
+			userName:  mocks.MockGM.Name,
+			path:      "/characters/1/backstory/1/keyConnection",
+			wantCode:  http.StatusSeeOther,
+			wantFlash: "Neue Schlüsselverbindung von Otto Hightower: Das Haus Hightower muss herrschen.",
+		},
+		{
+			name:      "Injury Is No Key Connection",
+			userId:    mocks.MockGM.ID,
+			userName:  mocks.MockGM.Name,
+			path:      "/characters/1/backstory/3/keyConnection",
+			wantCode:  http.StatusSeeOther,
+			wantFlash: "Nur Weltanschauung, wichtige Personen, bedeutsame Orte und wertvoller Besitz können Schlüsselverbindung sein.",
+		},
+		{
+			name:     "Nonexistent Entry",
+			userId:   mocks.MockGM.ID,
+			userName: mocks.MockGM.Name,
+			path:     "/characters/1/backstory/69/keyConnection",
+			wantCode: http.StatusNotFound,
+		},
+		{
+			name:     "Player",
+			userId:   mocks.MockPlayer.ID,
+			userName: mocks.MockPlayer.Name,
+			path:     "/characters/1/backstory/1/keyConnection",
+			wantCode: http.StatusUnauthorized,
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			app := newTestApplication(t)
+
+			ts := newTestServer(t, app.sessionManager.LoadAndSave(app.mockSession(noSurf(app.authenticate(app.requireAuthentication(app.routesNoMW()))),
+				map[string]any{
+					authenticatedUserIdKey:   testCase.userId,
+					authenticatedUserNameKey: testCase.userName,
+				})))
+			defer ts.Close()
+
+			_, _, body := ts.get(t, "/characters/1")
+			validCSRF := extractCSRFToken(t, body)
+
+			form := url.Values{}
+			form.Add("csrf_token", validCSRF)
+
+			code, _, _ := ts.postForm(t, testCase.path, form)
+
+			testHelpers.Equal(t, code, testCase.wantCode)
+			if testCase.wantFlash != "" {
+				_, _, body := ts.get(t, "/characters/1")
+				testHelpers.StringContains(t, body, testCase.wantFlash)
+			}
+		})
+	}
+}
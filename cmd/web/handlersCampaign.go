@@ -0,0 +1,588 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/winik100/NoPenNoPaper/internal/core"
+	"github.com/winik100/NoPenNoPaper/internal/models"
+	"github.com/winik100/NoPenNoPaper/internal/validators"
+)
+
+type campaignForm struct {
+	Title                    string
+	validators.FormValidator `schema:"-"`
+}
+
+type campaignMemberForm struct {
+	Name   string
+	UserId int
+}
+
+type campaignCharacterForm struct {
+	CharacterId int
+}
+
+type inviteForm struct {
+	ValidDays int
+	SingleUse bool
+	Code      string
+}
+
+type handoutForm struct {
+	MaterialId int
+	UserId     int
+	HandoutId  int
+}
+
+type tableForm struct {
+	CharacterIds []int
+}
+
+type joinForm struct {
+	Code                     string
+	CharacterIds             []int
+	validators.FormValidator `schema:"-"`
+}
+
+func (app *application) createCampaign(w http.ResponseWriter, r *http.Request) {
+	data := app.newTemplateData(r)
+	data.Form = campaignForm{}
+	w.WriteHeader(http.StatusOK)
+	app.render(w, r, "campaignCreate.tmpl.html", data)
+}
+
+func (app *application) createCampaignPost(w http.ResponseWriter, r *http.Request) {
+	var form campaignForm
+	err := app.decodePostForm(r, &form)
+	if err != nil {
+		app.clientError(w, http.StatusBadRequest)
+		return
+	}
+
+	form.CheckField(validators.NotBlank(form.Title), "Title", "Dieses Feld kann nicht leer sein.")
+	form.CheckField(validators.MaxChars(form.Title, 50), "Title", "Maximal 50 Zeichen erlaubt.")
+
+	if !form.Valid() {
+		data := app.newTemplateData(r)
+		data.Form = form
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		app.render(w, r, "campaignCreate.tmpl.html", data)
+		return
+	}
+
+	campaignId, err := app.campaigns.Insert(form.Title, app.sessionManager.GetInt(r.Context(), authenticatedUserIdKey))
+	if err != nil {
+		app.serverError(w, r, err)
+		return
+	}
+
+	redirect := fmt.Sprintf("/campaigns/%d", campaignId)
+	http.Redirect(w, r, redirect, http.StatusSeeOther)
+}
+
+func (app *application) campaign(w http.ResponseWriter, r *http.Request) {
+	campaignId, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	campaign, err := app.campaigns.Get(campaignId)
+	if err != nil {
+		if errors.Is(err, models.ErrNoRecord) {
+			http.NotFound(w, r)
+		} else {
+			app.serverError(w, r, err)
+		}
+		return
+	}
+
+	characters, err := app.characters.GetAllInCampaign(campaignId)
+	if err != nil {
+		app.serverError(w, r, err)
+		return
+	}
+	campaign.Characters = characters
+
+	data := app.newTemplateData(r)
+	if campaign.IsRunBy(data.User.ID) {
+		var addable []core.Character
+		for _, member := range campaign.Members {
+			memberCharacters, err := app.unassignedCharacters(member.ID)
+			if err != nil {
+				app.serverError(w, r, err)
+				return
+			}
+			addable = append(addable, memberCharacters...)
+		}
+		data.Characters = addable
+
+		invites, err := app.campaigns.GetInvites(campaignId)
+		if err != nil {
+			app.serverError(w, r, err)
+			return
+		}
+		campaign.Invites = invites
+
+		handouts, err := app.campaigns.GetHandouts(campaignId)
+		if err != nil {
+			app.serverError(w, r, err)
+			return
+		}
+		campaign.Handouts = handouts
+
+		npcs, err := app.characters.GetNPCsInCampaign(campaignId)
+		if err != nil {
+			app.serverError(w, r, err)
+			return
+		}
+		campaign.NPCs = npcs
+
+		// the GM's materials are needed to reveal new handouts
+		user, err := app.users.Get(data.User.Name)
+		if err != nil {
+			app.serverError(w, r, err)
+			return
+		}
+		data.User = user
+	} else {
+		handouts, err := app.campaigns.GetHandoutsFor(data.User.ID)
+		if err != nil {
+			app.serverError(w, r, err)
+			return
+		}
+		for _, handout := range handouts {
+			if handout.CampaignID == campaignId {
+				campaign.Handouts = append(campaign.Handouts, handout)
+			}
+		}
+	}
+	data.Campaign = campaign
+
+	w.WriteHeader(http.StatusOK)
+	app.render(w, r, "campaign.tmpl.html", data)
+}
+
+func (app *application) deleteCampaignPost(w http.ResponseWriter, r *http.Request) {
+	campaignId, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	err = app.campaigns.Delete(campaignId)
+	if err != nil {
+		app.serverError(w, r, err)
+		return
+	}
+
+	app.sessionManager.Put(r.Context(), "flash", "Kampagne erfolgreich gelöscht!")
+	http.Redirect(w, r, "/", http.StatusSeeOther)
+}
+
+func (app *application) addCampaignMemberPost(w http.ResponseWriter, r *http.Request) {
+	campaignId, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	var form campaignMemberForm
+	err = app.decodePostForm(r, &form)
+	if err != nil {
+		app.clientError(w, http.StatusBadRequest)
+		return
+	}
+
+	redirect := fmt.Sprintf("/campaigns/%d", campaignId)
+	user, err := app.users.Get(form.Name)
+	if err != nil {
+		if errors.Is(err, models.ErrNoRecord) {
+			app.sessionManager.Put(r.Context(), "flash", "Es existiert kein Nutzer mit diesem Namen.")
+			http.Redirect(w, r, redirect, http.StatusSeeOther)
+			return
+		}
+		app.serverError(w, r, err)
+		return
+	}
+
+	err = app.campaigns.AddMember(campaignId, user.ID)
+	if err != nil {
+		if errors.Is(err, models.ErrAlreadyMember) {
+			app.sessionManager.Put(r.Context(), "flash", "Dieser Nutzer nimmt bereits an der Kampagne teil.")
+			http.Redirect(w, r, redirect, http.StatusSeeOther)
+			return
+		}
+		app.serverError(w, r, err)
+		return
+	}
+
+	http.Redirect(w, r, redirect, http.StatusSeeOther)
+}
+
+func (app *application) removeCampaignMemberPost(w http.ResponseWriter, r *http.Request) {
+	campaignId, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	var form campaignMemberForm
+	err = app.decodePostForm(r, &form)
+	if err != nil {
+		app.clientError(w, http.StatusBadRequest)
+		return
+	}
+
+	err = app.campaigns.RemoveMember(campaignId, form.UserId)
+	if err != nil {
+		app.serverError(w, r, err)
+		return
+	}
+
+	redirect := fmt.Sprintf("/campaigns/%d", campaignId)
+	http.Redirect(w, r, redirect, http.StatusSeeOther)
+}
+
+func (app *application) addCampaignCharacterPost(w http.ResponseWriter, r *http.Request) {
+	campaignId, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	var form campaignCharacterForm
+	err = app.decodePostForm(r, &form)
+	if err != nil {
+		app.clientError(w, http.StatusBadRequest)
+		return
+	}
+
+	redirect := fmt.Sprintf("/campaigns/%d", campaignId)
+	err = app.campaigns.AddCharacter(campaignId, form.CharacterId)
+	if err != nil {
+		if errors.Is(err, models.ErrNotAMember) {
+			app.sessionManager.Put(r.Context(), "flash", "Der Charakter gehört keinem Mitspieler dieser Kampagne.")
+			http.Redirect(w, r, redirect, http.StatusSeeOther)
+			return
+		}
+		app.serverError(w, r, err)
+		return
+	}
+
+	http.Redirect(w, r, redirect, http.StatusSeeOther)
+}
+
+func (app *application) removeCampaignCharacterPost(w http.ResponseWriter, r *http.Request) {
+	campaignId, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	var form campaignCharacterForm
+	err = app.decodePostForm(r, &form)
+	if err != nil {
+		app.clientError(w, http.StatusBadRequest)
+		return
+	}
+
+	err = app.campaigns.RemoveCharacter(campaignId, form.CharacterId)
+	if err != nil {
+		app.serverError(w, r, err)
+		return
+	}
+
+	redirect := fmt.Sprintf("/campaigns/%d", campaignId)
+	http.Redirect(w, r, redirect, http.StatusSeeOther)
+}
+
+func (app *application) createInvitePost(w http.ResponseWriter, r *http.Request) {
+	campaignId, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	var form inviteForm
+	err = app.decodePostForm(r, &form)
+	if err != nil {
+		app.clientError(w, http.StatusBadRequest)
+		return
+	}
+
+	redirect := fmt.Sprintf("/campaigns/%d", campaignId)
+	if form.ValidDays < 0 || form.ValidDays > 30 {
+		app.sessionManager.Put(r.Context(), "flash", "Einladungen können höchstens 30 Tage gültig sein.")
+		http.Redirect(w, r, redirect, http.StatusSeeOther)
+		return
+	}
+
+	// 0 days: invite does not expire
+	var expires time.Time
+	if form.ValidDays > 0 {
+		expires = time.Now().AddDate(0, 0, form.ValidDays)
+	}
+
+	_, err = app.campaigns.CreateInvite(campaignId, expires, form.SingleUse)
+	if err != nil {
+		app.serverError(w, r, err)
+		return
+	}
+
+	http.Redirect(w, r, redirect, http.StatusSeeOther)
+}
+
+func (app *application) revokeInvitePost(w http.ResponseWriter, r *http.Request) {
+	campaignId, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	var form inviteForm
+	err = app.decodePostForm(r, &form)
+	if err != nil {
+		app.clientError(w, http.StatusBadRequest)
+		return
+	}
+
+	err = app.campaigns.RevokeInvite(campaignId, form.Code)
+	if err != nil {
+		app.serverError(w, r, err)
+		return
+	}
+
+	redirect := fmt.Sprintf("/campaigns/%d", campaignId)
+	http.Redirect(w, r, redirect, http.StatusSeeOther)
+}
+
+func (app *application) revealHandoutPost(w http.ResponseWriter, r *http.Request) {
+	campaignId, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	var form handoutForm
+	err = app.decodePostForm(r, &form)
+	if err != nil {
+		app.clientError(w, http.StatusBadRequest)
+		return
+	}
+
+	redirect := fmt.Sprintf("/campaigns/%d", campaignId)
+	err = app.campaigns.RevealHandout(campaignId, form.MaterialId, form.UserId)
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrNotAMember):
+			app.sessionManager.Put(r.Context(), "flash", "Dieser Nutzer nimmt nicht an der Kampagne teil.")
+		case errors.Is(err, models.ErrNoRecord):
+			app.sessionManager.Put(r.Context(), "flash", "Dieses Material existiert nicht.")
+		default:
+			app.serverError(w, r, err)
+			return
+		}
+		http.Redirect(w, r, redirect, http.StatusSeeOther)
+		return
+	}
+
+	app.events.Publish(campaignTopic(campaignId), eventHandouts)
+	http.Redirect(w, r, redirect, http.StatusSeeOther)
+}
+
+func (app *application) hideHandoutPost(w http.ResponseWriter, r *http.Request) {
+	campaignId, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	var form handoutForm
+	err = app.decodePostForm(r, &form)
+	if err != nil {
+		app.clientError(w, http.StatusBadRequest)
+		return
+	}
+
+	err = app.campaigns.HideHandout(campaignId, form.HandoutId)
+	if err != nil {
+		app.serverError(w, r, err)
+		return
+	}
+	app.events.Publish(campaignTopic(campaignId), eventHandouts)
+
+	redirect := fmt.Sprintf("/campaigns/%d", campaignId)
+	http.Redirect(w, r, redirect, http.StatusSeeOther)
+}
+
+func (app *application) campaignDashboard(w http.ResponseWriter, r *http.Request) {
+	campaignId, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	campaign, err := app.campaigns.Get(campaignId)
+	if err != nil {
+		if errors.Is(err, models.ErrNoRecord) {
+			http.NotFound(w, r)
+		} else {
+			app.serverError(w, r, err)
+		}
+		return
+	}
+
+	characters, err := app.characters.GetAllInCampaign(campaignId)
+	if err != nil {
+		app.serverError(w, r, err)
+		return
+	}
+
+	campaign.Characters = tableCharacters(campaign, characters)
+
+	pushes, err := app.rolls.GetUnresolvedPushes(campaignId)
+	if err != nil {
+		app.serverError(w, r, err)
+		return
+	}
+
+	data := app.newTemplateData(r)
+	data.Campaign = campaign
+	data.Characters = characters
+	data.PushedRolls = pushes
+	w.WriteHeader(http.StatusOK)
+	app.render(w, r, "dashboard.tmpl.html", data)
+}
+
+func (app *application) setTablePost(w http.ResponseWriter, r *http.Request) {
+	campaignId, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	var form tableForm
+	err = app.decodePostForm(r, &form)
+	if err != nil {
+		app.clientError(w, http.StatusBadRequest)
+		return
+	}
+
+	err = app.campaigns.SetTable(campaignId, form.CharacterIds)
+	if err != nil {
+		app.serverError(w, r, err)
+		return
+	}
+
+	redirect := fmt.Sprintf("/campaigns/%d/dashboard", campaignId)
+	http.Redirect(w, r, redirect, http.StatusSeeOther)
+}
+
+func (app *application) joinCampaign(w http.ResponseWriter, r *http.Request) {
+	form := joinForm{Code: r.URL.Query().Get("code")}
+
+	data := app.newTemplateData(r)
+	if form.Code != "" {
+		invite, err := app.campaigns.GetInvite(form.Code)
+		if err != nil && !errors.Is(err, models.ErrNoRecord) {
+			app.serverError(w, r, err)
+			return
+		}
+		if err != nil || !invite.Usable(time.Now()) {
+			form.AddFieldError("Code", "Dieser Einladungscode ist ungültig oder abgelaufen.")
+		} else {
+			data.Campaign = core.Campaign{ID: invite.CampaignID, Title: invite.CampaignTitle}
+		}
+	}
+
+	characters, err := app.unassignedCharacters(data.User.ID)
+	if err != nil {
+		app.serverError(w, r, err)
+		return
+	}
+	data.Characters = characters
+	data.Form = form
+
+	w.WriteHeader(http.StatusOK)
+	app.render(w, r, "join.tmpl.html", data)
+}
+
+func (app *application) joinCampaignPost(w http.ResponseWriter, r *http.Request) {
+	userId := app.sessionManager.GetInt(r.Context(), authenticatedUserIdKey)
+
+	var form joinForm
+	err := app.decodePostForm(r, &form)
+	if err != nil {
+		app.clientError(w, http.StatusBadRequest)
+		return
+	}
+
+	form.CheckField(validators.NotBlank(form.Code), "Code", "Dieses Feld kann nicht leer sein.")
+
+	var campaignId int
+	if form.Valid() {
+		campaignId, err = app.campaigns.Join(form.Code, userId, form.CharacterIds)
+		if err != nil {
+			if !errors.Is(err, models.ErrInvalidInvite) {
+				app.serverError(w, r, err)
+				return
+			}
+			form.AddFieldError("Code", "Dieser Einladungscode ist ungültig oder abgelaufen.")
+		}
+	}
+
+	if !form.Valid() {
+		characters, err := app.unassignedCharacters(userId)
+		if err != nil {
+			app.serverError(w, r, err)
+			return
+		}
+
+		data := app.newTemplateData(r)
+		data.Characters = characters
+		data.Form = form
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		app.render(w, r, "join.tmpl.html", data)
+		return
+	}
+
+	app.sessionManager.Put(r.Context(), "flash", "Erfolgreich der Kampagne beigetreten!")
+	redirect := fmt.Sprintf("/campaigns/%d", campaignId)
+	http.Redirect(w, r, redirect, http.StatusSeeOther)
+}
+
+// characters of the given user which are not yet part of any campaign
+func (app *application) unassignedCharacters(userId int) ([]core.Character, error) {
+	characters, err := app.characters.GetAllFrom(userId)
+	if err != nil {
+		return nil, err
+	}
+
+	var unassigned []core.Character
+	for _, character := range characters {
+		if character.CampaignID == 0 {
+			unassigned = append(unassigned, character)
+		}
+	}
+	return unassigned, nil
+}
+
+// without a selection for the current table, every character of the campaign is at the table
+func tableCharacters(campaign core.Campaign, characters []core.Character) []core.Character {
+	if len(campaign.Table) == 0 {
+		return characters
+	}
+
+	var atTable []core.Character
+	for _, character := range characters {
+		if campaign.AtTable(character.ID) {
+			atTable = append(atTable, character)
+		}
+	}
+	return atTable
+}
@@ -0,0 +1,323 @@
+package main
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+	"testing"
+
+	"github.com/winik100/NoPenNoPaper/internal/models/mocks"
+	"github.com/winik100/NoPenNoPaper/internal/testHelpers"
+)
+
+func TestCampaign(t *testing.T) {
+	app := newTestApplication(t)
+
+	tests := []struct {
+		name                  string
+		campaignId            string
+		authenticatedUserId   int
+		authenticatedUserName string
+		wantCode              int
+		wantContent           []string
+	}{
+		{
+			name:                  "As GM",
+			campaignId:            "1",
+			authenticatedUserId:   mocks.MockGM.ID,
+			authenticatedUserName: mocks.MockGM.Name,
+			wantCode:              http.StatusOK,
+			wantContent: []string{
+				"<h2>Der Tanz der Drachen</h2>",
+				"<td><a href='/characters/1'>Otto Hightower</a></td>",
+				"<form action='/campaigns/1/addMember' method='POST'>",
+				"<form action='/campaigns/1/hideHandout' method='POST'>",
+				"<option value='1'>Karte von Westeros</option>",
+			},
+		},
+		{
+			name:                  "As Player",
+			campaignId:            "1",
+			authenticatedUserId:   mocks.MockPlayer.ID,
+			authenticatedUserName: mocks.MockPlayer.Name,
+			wantCode:              http.StatusOK,
+			wantContent: []string{
+				"<h2>Der Tanz der Drachen</h2>",
+				"<td>Testnutzer</td>",
+				"<summary>Karte von Westeros</summary>",
+			},
+		},
+		{
+			name:                  "Nonexistent, valid ID",
+			campaignId:            "69",
+			authenticatedUserId:   mocks.MockGM.ID,
+			authenticatedUserName: mocks.MockGM.Name,
+			wantCode:              http.StatusNotFound,
+		},
+		{
+			name:                  "Invalid ID",
+			campaignId:            "test",
+			authenticatedUserId:   mocks.MockGM.ID,
+			authenticatedUserName: mocks.MockGM.Name,
+			wantCode:              http.StatusNotFound,
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			ts := newTestServer(t, app.sessionManager.LoadAndSave(app.mockSession(noSurf(app.authenticate(app.requireAuthentication(app.routesNoMW()))),
+				map[string]any{
+					authenticatedUserIdKey:   testCase.authenticatedUserId,
+					authenticatedUserNameKey: testCase.authenticatedUserName,
+				})))
+			defer ts.Close()
+
+			code, _, body := ts.get(t, "/campaigns/"+testCase.campaignId)
+
+			testHelpers.Equal(t, code, testCase.wantCode)
+			for _, tag := range testCase.wantContent {
+				testHelpers.StringContains(t, body, tag)
+			}
+		})
+	}
+}
+
+func TestCreateCampaignPost(t *testing.T) {
+	app := newTestApplication(t)
+
+	ts := newTestServer(t, app.sessionManager.LoadAndSave(app.mockSession(noSurf(app.authenticate(app.requireAuthentication(app.routesNoMW()))),
+		map[string]any{
+			authenticatedUserIdKey:   mocks.MockGM.ID,
+			authenticatedUserNameKey: mocks.MockGM.Name,
+		})))
+	defer ts.Close()
+
+	_, _, body := ts.get(t, "/campaigns/create")
+	validCSRF := extractCSRFToken(t, body)
+
+	tests := []struct {
+		name         string
+		title        string
+		wantCode     int
+		wantLocation string
+		wantContent  string
+	}{
+		{
+			name:         "Valid Campaign",
+			title:        "Der Tanz der Drachen",
+			wantCode:     http.StatusSeeOther,
+			wantLocation: "/campaigns/1",
+		},
+		{
+			name:        "Empty Title",
+			title:       "",
+			wantCode:    http.StatusUnprocessableEntity,
+			wantContent: "<label class='error'>Dieses Feld kann nicht leer sein.</label>",
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			form := url.Values{}
+			form.Add("Title", testCase.title)
+			form.Add("csrf_token", validCSRF)
+
+			code, header, body := ts.postForm(t, "/campaigns/create", form)
+
+			testHelpers.Equal(t, code, testCase.wantCode)
+			testHelpers.Equal(t, header.Get("Location"), testCase.wantLocation)
+			if testCase.wantContent != "" {
+				testHelpers.StringContains(t, body, testCase.wantContent)
+			}
+		})
+	}
+}
+
+func TestJoinCampaign(t *testing.T) {
+	app := newTestApplication(t)
+
+	ts := newTestServer(t, app.sessionManager.LoadAndSave(app.mockSession(noSurf(app.authenticate(app.requireAuthentication(app.routesNoMW()))),
+		map[string]any{
+			authenticatedUserIdKey:   mocks.MockPlayer.ID,
+			authenticatedUserNameKey: mocks.MockPlayer.Name,
+		})))
+	defer ts.Close()
+
+	_, _, body := ts.get(t, "/campaigns/join?code="+mocks.MockInvite.Code)
+	testHelpers.StringContains(t, body, `<h2>Einladung zur Kampagne "Der Tanz der Drachen"</h2>`)
+	validCSRF := extractCSRFToken(t, body)
+
+	_, _, body = ts.get(t, "/campaigns/join?code="+mocks.MockExpiredInvite.Code)
+	testHelpers.StringContains(t, body, "<label class='error'>Dieser Einladungscode ist ungültig oder abgelaufen.</label>")
+
+	tests := []struct {
+		name         string
+		code         string
+		wantCode     int
+		wantLocation string
+	}{
+		{
+			name:         "Valid Invite",
+			code:         mocks.MockInvite.Code,
+			wantCode:     http.StatusSeeOther,
+			wantLocation: "/campaigns/1",
+		},
+		{
+			name:     "Expired Invite",
+			code:     mocks.MockExpiredInvite.Code,
+			wantCode: http.StatusUnprocessableEntity,
+		},
+		{
+			name:     "Empty Code",
+			code:     "",
+			wantCode: http.StatusUnprocessableEntity,
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			form := url.Values{}
+			form.Add("Code", testCase.code)
+			form.Add("CharacterIds", "1")
+			form.Add("csrf_token", validCSRF)
+
+			code, header, _ := ts.postForm(t, "/campaigns/join", form)
+
+			testHelpers.Equal(t, code, testCase.wantCode)
+			testHelpers.Equal(t, header.Get("Location"), testCase.wantLocation)
+		})
+	}
+}
+
+func TestCampaignDashboard(t *testing.T) {
+	app := newTestApplication(t)
+
+	ts := newTestServer(t, app.sessionManager.LoadAndSave(app.mockSession(noSurf(app.authenticate(app.requireAuthentication(app.routesNoMW()))),
+		map[string]any{
+			authenticatedUserIdKey:   mocks.MockGM.ID,
+			authenticatedUserNameKey: mocks.MockGM.Name,
+		})))
+	defer ts.Close()
+
+	code, _, body := ts.get(t, "/campaigns/1/dashboard")
+
+	testHelpers.Equal(t, code, http.StatusOK)
+	testHelpers.StringContains(t, body, "<td><a href='/characters/1'>Otto Hightower</a></td>")
+	testHelpers.StringContains(t, body, `<form hx-post="/characters/1/editStat" hx-target="find div" hx-swap="outerHTML">`)
+	testHelpers.StringContains(t, body, `<div id="TP-1"`)
+	testHelpers.StringContains(t, body, `<div id="STA-1"`)
+	testHelpers.StringContains(t, body, "<input type='checkbox' name='CharacterIds' value='1' checked> Otto Hightower")
+	testHelpers.StringContains(t, body, "<input type='checkbox' name='CharacterIds' value='2'> Viserys Targaryen")
+
+	code, _, _ = ts.get(t, "/campaigns/69/dashboard")
+	testHelpers.Equal(t, code, http.StatusNotFound)
+}
+
+func TestSetTablePost(t *testing.T) {
+	app := newTestApplication(t)
+
+	ts := newTestServer(t, app.sessionManager.LoadAndSave(app.mockSession(noSurf(app.authenticate(app.requireAuthentication(app.routesNoMW()))),
+		map[string]any{
+			authenticatedUserIdKey:   mocks.MockGM.ID,
+			authenticatedUserNameKey: mocks.MockGM.Name,
+		})))
+	defer ts.Close()
+
+	_, _, body := ts.get(t, "/campaigns/1/dashboard")
+	validCSRF := extractCSRFToken(t, body)
+
+	tests := []struct {
+		name         string
+		path         string
+		characterIds []string
+		wantCode     int
+		wantLocation string
+	}{
+		{
+			name:         "Choose Table",
+			path:         "/campaigns/1/table",
+			characterIds: []string{"1", "2"},
+			wantCode:     http.StatusSeeOther,
+			wantLocation: "/campaigns/1/dashboard",
+		},
+		{
+			name:         "Empty Table",
+			path:         "/campaigns/1/table",
+			wantCode:     http.StatusSeeOther,
+			wantLocation: "/campaigns/1/dashboard",
+		},
+		{
+			name:     "Invalid Campaign",
+			path:     "/campaigns/abc/table",
+			wantCode: http.StatusNotFound,
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			form := url.Values{}
+			for _, characterId := range testCase.characterIds {
+				form.Add("CharacterIds", characterId)
+			}
+			form.Add("csrf_token", validCSRF)
+
+			code, header, _ := ts.postForm(t, testCase.path, form)
+
+			testHelpers.Equal(t, code, testCase.wantCode)
+			testHelpers.Equal(t, header.Get("Location"), testCase.wantLocation)
+		})
+	}
+}
+
+func TestRevealHandoutPost(t *testing.T) {
+	app := newTestApplication(t)
+
+	ts := newTestServer(t, app.sessionManager.LoadAndSave(app.mockSession(noSurf(app.authenticate(app.requireAuthentication(app.routesNoMW()))),
+		map[string]any{
+			authenticatedUserIdKey:   mocks.MockGM.ID,
+			authenticatedUserNameKey: mocks.MockGM.Name,
+		})))
+	defer ts.Close()
+
+	_, _, body := ts.get(t, "/campaigns/1")
+	validCSRF := extractCSRFToken(t, body)
+
+	tests := []struct {
+		name      string
+		userId    string
+		wantFlash string
+	}{
+		{
+			name:   "Reveal to all",
+			userId: "0",
+		},
+		{
+			name:   "Reveal to member",
+			userId: strconv.Itoa(mocks.MockPlayer.ID),
+		},
+		{
+			name:      "Reveal to non-member",
+			userId:    "69",
+			wantFlash: "Dieser Nutzer nimmt nicht an der Kampagne teil.",
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			form := url.Values{}
+			form.Add("MaterialId", "1")
+			form.Add("UserId", testCase.userId)
+			form.Add("csrf_token", validCSRF)
+
+			code, header, _ := ts.postForm(t, "/campaigns/1/revealHandout", form)
+
+			testHelpers.Equal(t, code, http.StatusSeeOther)
+			testHelpers.Equal(t, header.Get("Location"), "/campaigns/1")
+
+			_, _, body := ts.get(t, "/campaigns/1")
+			if testCase.wantFlash != "" {
+				testHelpers.StringContains(t, body, testCase.wantFlash)
+			}
+		})
+	}
+}
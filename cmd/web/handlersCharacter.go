@@ -1,20 +1,29 @@
 package main
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
 	"slices"
 	"strconv"
+	"strings"
 
 	"github.com/winik100/NoPenNoPaper/internal/core"
 	"github.com/winik100/NoPenNoPaper/internal/models"
 	"github.com/winik100/NoPenNoPaper/internal/validators"
 )
 
+// attribute mode of a creation using the server's rolls instead of the fixed array
+const attributesRolled = "rolled"
+
 type characterForm struct {
 	Info                     core.CharacterInfo
+	AttributeMode            string
 	Attributes               core.CharacterAttributes
+	AttributeRolls           []core.AttributeRoll `schema:"-"`
+	AgeDeductions            core.AgeDeductions
+	OccupationID             int
 	Skills                   core.Skills
 	SelectedSkills           []string
 	CustomSkills             core.CustomSkills
@@ -28,15 +37,21 @@ type statEditForm struct {
 	validators.FormValidator `schema:"-"`
 }
 
+type attributeEditForm struct {
+	Name                     string
+	Value                    int
+	validators.FormValidator `schema:"-"`
+}
+
 type skillAddForm struct {
-	CharacterId              int
+	CharacterId              int `schema:"-"`
 	AddableSkill             string
 	Value                    int
 	validators.FormValidator `schema:"-"`
 }
 
 type customSkillAddForm struct {
-	CharacterId              int
+	CharacterId              int `schema:"-"`
 	CustomSkill              string
 	Category                 string
 	Value                    int
@@ -44,14 +59,14 @@ type customSkillAddForm struct {
 }
 
 type skillEditForm struct {
-	CharacterId              int
+	CharacterId              int `schema:"-"`
 	Skill                    string
 	NewValue                 int
 	validators.FormValidator `schema:"-"`
 }
 
 type itemForm struct {
-	CharacterId              int
+	CharacterId              int `schema:"-"`
 	Name                     string
 	Description              string
 	Count                    int
@@ -66,24 +81,95 @@ type itemEditForm struct {
 }
 
 type noteForm struct {
-	CharacterId              int
+	CharacterId              int `schema:"-"`
 	Text                     string
 	validators.FormValidator `schema:"-"`
 }
 
 func (app *application) createCharacter(w http.ResponseWriter, r *http.Request) {
-	data := app.newTemplateData(r)
-	skills, err := app.characters.GetAvailableSkills()
+	var form characterForm
+	rolls, err := app.rolledAttributes(r)
 	if err != nil {
 		app.serverError(w, r, err)
 		return
 	}
-	data.Form = characterForm{Skills: skills}
+	if rolls != nil {
+		form.AttributeMode = attributesRolled
+		form.AttributeRolls = rolls
+	}
 
-	w.WriteHeader(http.StatusOK)
+	app.renderCreateForm(w, r, http.StatusOK, form)
+}
+
+// renders the creation page with every available skill and the occupations to choose from
+func (app *application) renderCreateForm(w http.ResponseWriter, r *http.Request, status int, form characterForm) {
+	availableSkills, err := app.characters.GetAvailableSkills()
+	if err != nil {
+		app.serverError(w, r, err)
+		return
+	}
+	occupations, err := app.occupations.GetAll()
+	if err != nil {
+		app.serverError(w, r, err)
+		return
+	}
+	form.Skills = core.MergeSkills(availableSkills, form.Skills)
+
+	data := app.newTemplateData(r)
+	data.Form = form
+	data.Occupations = occupations
+	w.WriteHeader(status)
 	app.render(w, r, "create.tmpl.html", data)
 }
 
+// rolls the attributes once per creation and records them in the session, so they can't be rerolled or altered
+func (app *application) createRollPost(w http.ResponseWriter, r *http.Request) {
+	var form characterForm
+	err := app.decodePostForm(r, &form)
+	if err != nil {
+		app.clientError(w, http.StatusUnprocessableEntity)
+		return
+	}
+
+	rolls, err := app.rolledAttributes(r)
+	if err != nil {
+		app.serverError(w, r, err)
+		return
+	}
+	if rolls == nil {
+		_, rolls, err = core.RollAttributes()
+		if err != nil {
+			app.serverError(w, r, err)
+			return
+		}
+		encoded, err := json.Marshal(rolls)
+		if err != nil {
+			app.serverError(w, r, err)
+			return
+		}
+		app.sessionManager.Put(r.Context(), rolledAttributesKey, string(encoded))
+	}
+
+	form.AttributeMode = attributesRolled
+	form.AttributeRolls = rolls
+	app.renderCreateForm(w, r, http.StatusOK, form)
+}
+
+// the attributes rolled for the character being created, nil if there are none
+func (app *application) rolledAttributes(r *http.Request) ([]core.AttributeRoll, error) {
+	encoded := app.sessionManager.GetString(r.Context(), rolledAttributesKey)
+	if encoded == "" {
+		return nil, nil
+	}
+
+	var rolls []core.AttributeRoll
+	err := json.Unmarshal([]byte(encoded), &rolls)
+	if err != nil {
+		return nil, err
+	}
+	return rolls, nil
+}
+
 func (app *application) createCharacterPost(w http.ResponseWriter, r *http.Request) {
 	var form characterForm
 
@@ -93,31 +179,69 @@ func (app *application) createCharacterPost(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
-	form.InfoChecks()
-	form.AttributeChecks()
+	rolls, err := app.rolledAttributes(r)
+	if err != nil {
+		app.serverError(w, r, err)
+		return
+	}
 
-	if !form.Valid() {
-		data := app.newTemplateData(r)
-		availableSkills, err := app.characters.GetAvailableSkills()
+	occupation, err := app.occupations.Get(form.OccupationID)
+	if err != nil && !errors.Is(err, models.ErrNoRecord) {
+		app.serverError(w, r, err)
+		return
+	}
+	form.Info.Profession = occupation.Name
+
+	availableSkills, err := app.characters.GetAvailableSkills()
+	if err != nil {
+		app.serverError(w, r, err)
+		return
+	}
+
+	// once rolled, the fixed array is no way out of bad rolls
+	if rolls != nil {
+		form.AttributeMode = attributesRolled
+	}
+
+	form.InfoChecks()
+	if form.AttributeMode == attributesRolled {
+		form.AttributeRolls = rolls
+		form.RolledAttributeChecks()
+	} else {
+		form.AttributeChecks()
+	}
+	form.AgeChecks()
+	if occupation.ID != 0 {
+		err = form.SkillPointChecks(occupation, availableSkills)
 		if err != nil {
 			app.serverError(w, r, err)
 			return
 		}
-		form.Skills = core.MergeSkills(availableSkills, form.Skills)
-		data.Form = form
-		w.WriteHeader(http.StatusUnprocessableEntity)
-		app.render(w, r, "create.tmpl.html", data)
+	}
+
+	if !form.Valid() {
+		app.renderCreateForm(w, r, http.StatusUnprocessableEntity, form)
 		return
 	}
 
-	_, err = app.characters.Insert(core.Character{Info: form.Info, Attributes: form.Attributes, Skills: form.Skills, CustomSkills: form.CustomSkills},
-		app.sessionManager.GetInt(r.Context(), authenticatedUserIdKey))
+	character := core.Character{Info: form.Info, Attributes: form.Attributes, Skills: form.Skills, CustomSkills: form.CustomSkills}
+	character, checks := character.ApplyAge(form.AgeDeductions)
+	_, err = app.characters.Insert(character, app.sessionManager.GetInt(r.Context(), authenticatedUserIdKey))
 
 	if err != nil {
 		app.serverError(w, r, err)
 		return
 	}
+	app.sessionManager.Remove(r.Context(), rolledAttributesKey)
 
+	if len(checks) > 0 {
+		var results []string
+		for _, check := range checks {
+			results = append(results, fmt.Sprintf("%d (+%d)", check.Roll, check.Gain))
+		}
+		app.sessionManager.Put(r.Context(), "flash", fmt.Sprintf("Steigerungswürfe auf BI: %s, BI ist jetzt %d.",
+			strings.Join(results, ", "), character.Attributes.BI))
+	}
 	http.Redirect(w, r, "/", http.StatusSeeOther)
 }
 
@@ -138,20 +262,80 @@ func (app *application) character(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	rolls, err := app.rolls.GetHistory(characterId, rollHistoryLength)
+	if err != nil {
+		app.serverError(w, r, err)
+		return
+	}
+
+	sanityChecks, err := app.sanity.GetHistory(characterId, sanityHistoryLength)
+	if err != nil {
+		app.serverError(w, r, err)
+		return
+	}
+
+	weapons, err := app.weapons.GetAll()
+	if err != nil {
+		app.serverError(w, r, err)
+		return
+	}
+
+	spells, err := app.spells.GetAll()
+	if err != nil {
+		app.serverError(w, r, err)
+		return
+	}
+
+	tomes, err := app.tomes.GetAll()
+	if err != nil {
+		app.serverError(w, r, err)
+		return
+	}
+
+	campaign, err := app.characterCampaign(character)
+	if err != nil {
+		app.serverError(w, r, err)
+		return
+	}
+
+	ledger, err := app.ledger.GetAll(characterId)
+	if err != nil {
+		app.serverError(w, r, err)
+		return
+	}
+	wealth := character.Wealth(campaign.Era)
+	opening, err := app.ledgerOpening(characterId, wealth)
+	if err != nil {
+		app.serverError(w, r, err)
+		return
+	}
+
 	data := app.newTemplateData(r)
 	data.Character = character
+	data.Rolls = rolls
+	data.Weapons = weapons
+	data.Spells = spells
+	data.Tomes = tomes
+	data.Campaign = campaign
+	data.Wealth = wealth
+	data.Opening = opening
+	data.Balance = core.RunningBalance(opening, ledger)
+	data.Ledger = ledger
+	data.SanityChecks = sanityChecks
 	app.sessionManager.Put(r.Context(), characterIdKey, characterId)
 	w.WriteHeader(http.StatusOK)
 	app.render(w, r, "character.tmpl.html", data)
 }
 
 func (app *application) deleteCharacter(w http.ResponseWriter, r *http.Request) {
-	characterId := app.sessionManager.GetInt(r.Context(), characterIdKey)
+	characterId, ok := app.characterIdFromPath(w, r)
+	if !ok {
+		return
+	}
 
 	tmplStr := `<form id="deleteCharacterForm" action="/characters/{{.Form.CharacterId}}/delete" method="POST">
 					<p id="deleteCharacterMessage">Sicher? Kann nicht rückgängig gemacht werden!</p>
 					<input type="hidden" name="csrf_token" value="{{.CSRFToken}}">
-					<input type="hidden" name="CharacterId" Value="{{.Form.CharacterId}}">
 					<button type="submit">OK</button>
 					<button hx-get="/characters/{{.Form.CharacterId}}" hx-target="#deleteCharacterForm" hx-select="#deleteCharacter" hx-swap="outerHTML">Abbrechen</button>
             	</form>`
@@ -165,18 +349,12 @@ func (app *application) deleteCharacter(w http.ResponseWriter, r *http.Request)
 }
 
 func (app *application) deleteCharacterPost(w http.ResponseWriter, r *http.Request) {
-	type deleteForm struct {
-		CharacterId int
-	}
-
-	var form deleteForm
-	err := app.decodePostForm(r, &form)
-	if err != nil {
-		app.clientError(w, http.StatusUnprocessableEntity)
+	characterId, ok := app.characterIdFromPath(w, r)
+	if !ok {
 		return
 	}
 
-	err = app.characters.Delete(form.CharacterId)
+	err := app.characters.Delete(characterId)
 	if err != nil {
 		app.serverError(w, r, err)
 		return
@@ -186,7 +364,10 @@ func (app *application) deleteCharacterPost(w http.ResponseWriter, r *http.Reque
 }
 
 func (app *application) addSkill(w http.ResponseWriter, r *http.Request) {
-	characterId := app.sessionManager.GetInt(r.Context(), characterIdKey)
+	characterId, ok := app.characterIdFromPath(w, r)
+	if !ok {
+		return
+	}
 	character, err := app.characters.Get(characterId)
 	if err != nil {
 		if errors.Is(err, models.ErrNoRecord) {
@@ -213,7 +394,6 @@ func (app *application) addSkill(w http.ResponseWriter, r *http.Request) {
 
 	tmplStr := `<form id="addSkillForm" hx-post="/characters/{{.Form.CharacterId}}/addSkill" hx-target="this" hx-swap="outerHTML">
 				<input type="hidden" name="csrf_token" value="{{.CSRFToken}}">
-				<input type="hidden" name="CharacterId" value="{{.Form.CharacterId}}">
 				<select name='AddableSkill'>
 					{{range .Form.AddableSkills.Name}}
 						<option value='{{.}}'>{{.}}</option>
@@ -235,19 +415,24 @@ func (app *application) addSkill(w http.ResponseWriter, r *http.Request) {
 }
 
 func (app *application) addSkillPost(w http.ResponseWriter, r *http.Request) {
+	characterId, ok := app.characterIdFromPath(w, r)
+	if !ok {
+		return
+	}
+
 	var form skillAddForm
 	err := app.decodePostForm(r, &form)
 	if err != nil {
 		app.clientError(w, http.StatusBadRequest)
 		return
 	}
+	form.CharacterId = characterId
 
 	form.CheckField(form.Value != 0, "Value", "Dieses Feld muss einen positiven Wert enthalten.")
 
 	if !form.Valid() {
 		tmplStr := `<form id="addSkillForm" hx-post="/characters/{{.Form.CharacterId}}/addSkill" hx-target="this" hx-swap="outerHTML">
 						<input type="hidden" name="csrf_token" value="{{.CSRFToken}}">
-						<input type="hidden" name="CharacterId" value="{{.Form.CharacterId}}">
 						<select name='AddableSkill'>
 							{{range .AdditionalData.AddableSkills.Name}}
 								<option value='{{.}}'>{{.}}</option>
@@ -292,12 +477,24 @@ func (app *application) addSkillPost(w http.ResponseWriter, r *http.Request) {
 		app.serverError(w, r, err)
 		return
 	}
+	err = app.capSanity(form.CharacterId, form.AddableSkill, form.Value)
+	if err != nil {
+		app.serverError(w, r, err)
+		return
+	}
 
 	half := half(form.Value)
 	fifth := fifth(form.Value)
 	tmplStr := fmt.Sprintf(`<template>
 							<tr hx-swap-oob="beforeend:#Skills">
 								<th>{{.Form.AddableSkill}}</th>
+								<td>
+									<form hx-post="/characters/{{.Form.CharacterId}}/tickSkill" hx-target="this" hx-swap="outerHTML">
+										<input type="hidden" name="csrf_token" value="{{.CSRFToken}}">
+										<input type="hidden" name="Skill" value="{{.Form.AddableSkill}}">
+										<button type="submit" name="Used" value="true" title="zur Steigerung markieren">&#9744;</button>
+									</form>
+								</td>
 								<td>
 									<div id="Values{{.Form.AddableSkill}}">{{.Form.Value}} | %d | %d</div>
 									<form id="edit{{.Form.AddableSkill}}" hx-get="/characters/{{.Form.CharacterId}}/editSkill" hx-target="this" hx-swap="outerHTML">
@@ -319,7 +516,10 @@ func (app *application) addSkillPost(w http.ResponseWriter, r *http.Request) {
 }
 
 func (app *application) editSkill(w http.ResponseWriter, r *http.Request) {
-	characterId := app.sessionManager.GetInt(r.Context(), characterIdKey)
+	characterId, ok := app.characterIdFromPath(w, r)
+	if !ok {
+		return
+	}
 	params := r.URL.Query()
 	skill := params.Get("skill")
 	value, err := strconv.Atoi(params.Get("value"))
@@ -331,7 +531,6 @@ func (app *application) editSkill(w http.ResponseWriter, r *http.Request) {
 	trimmed := trim(skill)
 	tmplStr := fmt.Sprintf(`<form id="editForm" hx-post="/characters/{{.Form.CharacterId}}/editSkill" hx-target="this" hx-swap="outerHTML">
                 <input type="hidden" name="csrf_token" value="{{.CSRFToken}}">
-				<input type="hidden" name="CharacterId" value="{{.Form.CharacterId}}">
 				<input type="hidden" name="Skill" value="{{.Form.Skill}}">
                 <input type="number" name="NewValue" value="{{.Form.Value}}">
 				<button type="submit">OK</button>
@@ -349,18 +548,29 @@ func (app *application) editSkill(w http.ResponseWriter, r *http.Request) {
 }
 
 func (app *application) editSkillPost(w http.ResponseWriter, r *http.Request) {
+	characterId, ok := app.characterIdFromPath(w, r)
+	if !ok {
+		return
+	}
+
 	var form skillEditForm
 	err := app.decodePostForm(r, &form)
 	if err != nil {
 		app.clientError(w, http.StatusBadRequest)
 		return
 	}
+	form.CharacterId = characterId
 
 	err = app.characters.EditSkill(form.CharacterId, form.Skill, form.NewValue)
 	if err != nil {
 		app.serverError(w, r, err)
 		return
 	}
+	err = app.capSanity(form.CharacterId, form.Skill, form.NewValue)
+	if err != nil {
+		app.serverError(w, r, err)
+		return
+	}
 
 	half := half(form.NewValue)
 	fifth := fifth(form.NewValue)
@@ -379,11 +589,13 @@ func (app *application) editSkillPost(w http.ResponseWriter, r *http.Request) {
 }
 
 func (app *application) addCustomSkill(w http.ResponseWriter, r *http.Request) {
-	characterId := app.sessionManager.GetInt(r.Context(), characterIdKey)
+	characterId, ok := app.characterIdFromPath(w, r)
+	if !ok {
+		return
+	}
 
 	tmplStr := `<form id="addCustomSkillForm" hx-post="/characters/{{.Form.CharacterId}}/addCustomSkill" hx-target="this" hx-swap="outerHTML">
 					<input type="hidden" name="csrf_token" value="{{.CSRFToken}}">
-					<input type="hidden" name="CharacterId" value="{{.Form.CharacterId}}">
 					<select name='Category'>
 								<option value='' disabled selected>Wähle Kategorie</option>
 								<option value='Muttersprache'>Muttersprache</option>
@@ -410,12 +622,18 @@ func (app *application) addCustomSkill(w http.ResponseWriter, r *http.Request) {
 }
 
 func (app *application) addCustomSkillPost(w http.ResponseWriter, r *http.Request) {
+	characterId, ok := app.characterIdFromPath(w, r)
+	if !ok {
+		return
+	}
+
 	var form customSkillAddForm
 	err := app.decodePostForm(r, &form)
 	if err != nil {
 		app.clientError(w, http.StatusBadRequest)
 		return
 	}
+	form.CharacterId = characterId
 
 	form.CheckField(validators.NotBlank(form.CustomSkill), "Name", "Dieses Feld kann nicht leer sein.")
 	form.CheckField(form.Value != 0, "Value", "Dieses Feld muss einen positiven Wert enthalten.")
@@ -424,7 +642,6 @@ func (app *application) addCustomSkillPost(w http.ResponseWriter, r *http.Reques
 	if !form.Valid() {
 		tmplStr := `<form id="addCustomSkillForm" hx-post="/characters/{{.Form.CharacterId}}/addCustomSkill" hx-target="this" hx-swap="outerHTML">
 						<input type="hidden" name="csrf_token" value="{{.CSRFToken}}">
-						<input type="hidden" name="CharacterId" value="{{.Form.CharacterId}}">
 						<select name='Category'>
 							<option value='' disabled selected>Wähle Kategorie</option>
 							<option value='Muttersprache'>Muttersprache</option>
@@ -474,9 +691,16 @@ func (app *application) addCustomSkillPost(w http.ResponseWriter, r *http.Reques
 	tmplStr := fmt.Sprintf(`<template>
 							<tr hx-swap-oob="beforeend:#CustomSkills">
 								<th>{{.Form.CustomSkill}}</th>
+								<td>
+									<form hx-post="/characters/{{.Form.CharacterId}}/tickSkill" hx-target="this" hx-swap="outerHTML">
+										<input type="hidden" name="csrf_token" value="{{.CSRFToken}}">
+										<input type="hidden" name="Skill" value="{{.Form.CustomSkill}}">
+										<button type="submit" name="Used" value="true" title="zur Steigerung markieren">&#9744;</button>
+									</form>
+								</td>
 								<td>
 									<div id="Values{{.Form.CustomSkill}}" value="{{.Form.Value}}">{{.Form.Value}} | %d | %d</div>
-									<form id="edit{{.Form.CustomSkill}}" hx-get="/characters/{{.Form.characterId}}/editCustomSkill" hx-target="this" hx-swap="outerHTML">
+									<form id="edit{{.Form.CustomSkill}}" hx-get="/characters/{{.Form.CharacterId}}/editCustomSkill" hx-target="this" hx-swap="outerHTML">
 										<input type="hidden" name="skill" value="{{.Form.CustomSkill}}">
 										<input type="hidden" name="value" value="{{.Form.Value}}">
 										<button type="submit">Bearbeiten</button>
@@ -495,7 +719,10 @@ func (app *application) addCustomSkillPost(w http.ResponseWriter, r *http.Reques
 }
 
 func (app *application) editCustomSkill(w http.ResponseWriter, r *http.Request) {
-	characterId := app.sessionManager.GetInt(r.Context(), characterIdKey)
+	characterId, ok := app.characterIdFromPath(w, r)
+	if !ok {
+		return
+	}
 	params := r.URL.Query()
 	skill := params.Get("skill")
 	value, err := strconv.Atoi(params.Get("value"))
@@ -506,12 +733,11 @@ func (app *application) editCustomSkill(w http.ResponseWriter, r *http.Request)
 
 	tmplStr := fmt.Sprintf(`<form id="editForm" hx-post="/characters/%d/editCustomSkill" hx-target="this" hx-swap="outerHTML">
                 <input type="hidden" name="csrf_token" value="{{.CSRFToken}}">
-				<input type="hidden" name="CharacterId" value="%d">
 				<input type="hidden" name="Skill" value="%s">
                 <input type="number" name="NewValue" value="%d">
 				<button type="submit">OK</button>
 				<button hx-get="/characters/%d" hx-target="#editForm" hx-swap="outerHTML" hx-select="#edit%s">Abbrechen</button>
-            </form>`, characterId, characterId, skill, value, characterId, skill)
+            </form>`, characterId, skill, value, characterId, skill)
 
 	data := app.newTemplateData(r)
 	w.WriteHeader(http.StatusOK)
@@ -519,12 +745,18 @@ func (app *application) editCustomSkill(w http.ResponseWriter, r *http.Request)
 }
 
 func (app *application) editCustomSkillPost(w http.ResponseWriter, r *http.Request) {
+	characterId, ok := app.characterIdFromPath(w, r)
+	if !ok {
+		return
+	}
+
 	var form skillEditForm
 	err := app.decodePostForm(r, &form)
 	if err != nil {
 		app.clientError(w, http.StatusBadRequest)
 		return
 	}
+	form.CharacterId = characterId
 
 	half := half(form.NewValue)
 	fifth := fifth(form.NewValue)
@@ -553,13 +785,7 @@ func (app *application) customSkillInput(w http.ResponseWriter, r *http.Request)
 						<input type='hidden' name='CustomSkills.Category' value='{{.Form.Category}}'>
 						<label>{{.Form.Category}}</label>
 						<input type="text" name="CustomSkills.Name">
-						<select name="CustomSkills.Value">
-							<option value="{{.Form.Default}}" selected>{{.Form.Default}}</option>
-							<option value="70">70</option>
-							<option value="60">60</option>
-							<option value="50">50</option>
-							<option value="40">40</option>
-						</select>
+						<input type="number" name="CustomSkills.Value" value="{{.Form.Default}}" max="99">
 						<button hx-get="/create" hx-target="#{{.Form.Category}}" hx-swap="delete">Abbrechen</button>
 					</td>
 				</tr>`
@@ -576,17 +802,76 @@ func (app *application) customSkillInput(w http.ResponseWriter, r *http.Request)
 	app.renderHtmx(w, r, "customSkillInput", tmplStr, data)
 }
 
+func (app *application) editAttributePost(w http.ResponseWriter, r *http.Request) {
+	character, ok := app.loadCharacter(w, r)
+	if !ok {
+		return
+	}
+
+	var form attributeEditForm
+	err := app.decodePostForm(r, &form)
+	if err != nil {
+		app.clientError(w, http.StatusBadRequest)
+		return
+	}
+
+	// players roll their investigator's attributes once, only the GM may correct them afterwards
+	if !character.IsNPC() {
+		campaign, err := app.characterCampaign(character)
+		if err != nil {
+			app.serverError(w, r, err)
+			return
+		}
+		if !campaign.IsRunBy(app.sessionManager.GetInt(r.Context(), authenticatedUserIdKey)) {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+	}
+
+	form.CheckField(validators.PermittedValue(form.Name, character.Attributes.OrderedKeys()...), "Name", "Unbekanntes Attribut.")
+	if form.Name == "BW" {
+		form.CheckField(character.IsNPC(), "Name", "BW wird aus ST, GE, GR und Alter berechnet.")
+		form.CheckField(0 <= form.Value && form.Value <= 20, "Value", "Wert muss zwischen 0 und 20 liegen.")
+	} else {
+		form.CheckField(0 <= form.Value && form.Value <= 200, "Value", "Wert muss zwischen 0 und 200 liegen.")
+	}
+
+	redirect := fmt.Sprintf("/characters/%d", character.ID)
+	if !form.Valid() {
+		var messages []string
+		for _, message := range form.FieldErrors {
+			messages = append(messages, message)
+		}
+		app.sessionManager.Put(r.Context(), "flash", strings.Join(messages, " "))
+		http.Redirect(w, r, redirect, http.StatusSeeOther)
+		return
+	}
+
+	_, err = app.characters.EditAttribute(character.ID, form.Name, form.Value)
+	if err != nil {
+		app.serverError(w, r, err)
+		return
+	}
+
+	app.events.Publish(characterTopic(character.ID), eventAttributes)
+	http.Redirect(w, r, redirect, http.StatusSeeOther)
+}
+
 func (app *application) editStat(w http.ResponseWriter, r *http.Request) {
-	characterId := app.sessionManager.GetInt(r.Context(), characterIdKey)
+	characterId, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
 
 	var form statEditForm
-	err := app.decodePostForm(r, &form)
+	err = app.decodePostForm(r, &form)
 	if err != nil {
 		app.clientError(w, http.StatusUnprocessableEntity)
 		return
 	}
 
-	tmplStr := `<div id="{{.Form.Stat}}">
+	tmplStr := `<div id="{{.Form.Stat}}-{{.Form.CharacterId}}"{{if .Form.Critical}} class="critical"{{end}}>
 					{{if gt .Form.NewValue 1}}
 					<button type="submit" name="Direction" value="dec">-</button>
 					{{end}}
@@ -611,16 +896,20 @@ func (app *application) editStat(w http.ResponseWriter, r *http.Request) {
 	case "inc":
 		updated, err = app.characters.IncrementStat(characterId, form.Name)
 		data.Form = map[string]any{
-			"Stat":     form.Name,
-			"NewValue": updated,
-			"Max":      max,
+			"CharacterId": characterId,
+			"Stat":        form.Name,
+			"NewValue":    updated,
+			"Max":         max,
+			"Critical":    core.IsCriticalValue(updated, max),
 		}
 	case "dec":
 		updated, err = app.characters.DecrementStat(characterId, form.Name)
 		data.Form = map[string]any{
-			"Stat":     form.Name,
-			"NewValue": updated,
-			"Max":      max,
+			"CharacterId": characterId,
+			"Stat":        form.Name,
+			"NewValue":    updated,
+			"Max":         max,
+			"Critical":    core.IsCriticalValue(updated, max),
 		}
 	}
 	if err != nil {
@@ -628,12 +917,17 @@ func (app *application) editStat(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	app.publishCharacter(character, eventStats)
+
 	w.WriteHeader(http.StatusOK)
 	app.renderHtmx(w, r, "editStatSuccess", tmplStr, data)
 }
 
 func (app *application) addItem(w http.ResponseWriter, r *http.Request) {
-	characterId := app.sessionManager.GetInt(r.Context(), characterIdKey)
+	characterId, ok := app.characterIdFromPath(w, r)
+	if !ok {
+		return
+	}
 	data := app.newTemplateData(r)
 	data.Form = itemForm{
 		CharacterId: characterId,
@@ -643,12 +937,18 @@ func (app *application) addItem(w http.ResponseWriter, r *http.Request) {
 }
 
 func (app *application) addItemPost(w http.ResponseWriter, r *http.Request) {
+	characterId, ok := app.characterIdFromPath(w, r)
+	if !ok {
+		return
+	}
+
 	var form itemForm
 	err := app.decodePostForm(r, &form)
 	if err != nil {
 		app.clientError(w, http.StatusUnprocessableEntity)
 		return
 	}
+	form.CharacterId = characterId
 
 	form.CheckField(validators.NotBlank(form.Name), "Name", "Dieses Feld kann nicht leer sein.")
 	form.CheckField(validators.MaxChars(form.Name, 50), "Name", "Maximal 50 Zeichen erlaubt.")
@@ -664,19 +964,21 @@ func (app *application) addItemPost(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	fmt.Println(form.CharacterId, form.Name, form.Description, form.Count)
-
 	err = app.characters.AddItem(form.CharacterId, form.Name, form.Description, form.Count)
 	if err != nil {
 		app.serverError(w, r, err)
 		return
 	}
+	app.events.Publish(characterTopic(form.CharacterId), eventItems)
 	redirect := fmt.Sprintf("/characters/%d", form.CharacterId)
 	http.Redirect(w, r, redirect, http.StatusSeeOther)
 }
 
 func (app *application) editItemCount(w http.ResponseWriter, r *http.Request) {
-	characterId := app.sessionManager.GetInt(r.Context(), characterIdKey)
+	characterId, ok := app.characterIdFromPath(w, r)
+	if !ok {
+		return
+	}
 
 	var form itemEditForm
 	err := app.decodePostForm(r, &form)
@@ -714,12 +1016,18 @@ func (app *application) editItemCount(w http.ResponseWriter, r *http.Request) {
 		app.serverError(w, r, err)
 		return
 	}
+	app.events.Publish(characterTopic(characterId), eventItems)
 
 	w.WriteHeader(http.StatusOK)
 	app.renderHtmx(w, r, "editItemCountSuccess", tmplStr, data)
 }
 
 func (app *application) deleteItemPost(w http.ResponseWriter, r *http.Request) {
+	characterId, ok := app.characterIdFromPath(w, r)
+	if !ok {
+		return
+	}
+
 	type deleteForm struct {
 		ItemId int
 	}
@@ -736,17 +1044,20 @@ func (app *application) deleteItemPost(w http.ResponseWriter, r *http.Request) {
 		app.serverError(w, r, err)
 		return
 	}
+	app.events.Publish(characterTopic(characterId), eventItems)
 
 	w.WriteHeader(http.StatusOK)
 	app.renderHtmx(w, r, "empty", "", templateData{})
 }
 
 func (app *application) addNote(w http.ResponseWriter, r *http.Request) {
-	characterId := app.sessionManager.GetInt(r.Context(), characterIdKey)
+	characterId, ok := app.characterIdFromPath(w, r)
+	if !ok {
+		return
+	}
 
 	tmplStr := `<form id="addNoteForm" hx-post="/characters/{{.Form.CharacterId}}/addNote" hx-target="this" hx-swap="outerHTML">
 					<input type="hidden" name="csrf_token" value="{{.CSRFToken}}">
-					<input type="hidden" name="CharacterId" value="{{.Form.CharacterId}}">
 					<div>
 						<label>Notiz:</label>
 						<input type="text" name="Text" textarea>
@@ -764,17 +1075,24 @@ func (app *application) addNote(w http.ResponseWriter, r *http.Request) {
 }
 
 func (app *application) addNotePost(w http.ResponseWriter, r *http.Request) {
+	characterId, ok := app.characterIdFromPath(w, r)
+	if !ok {
+		return
+	}
+
 	var form noteForm
 	err := app.decodePostForm(r, &form)
 	if err != nil {
 		app.clientError(w, http.StatusUnprocessableEntity)
 		return
 	}
+	form.CharacterId = characterId
 	noteId, err := app.characters.AddNote(form.CharacterId, form.Text)
 	if err != nil {
 		app.serverError(w, r, err)
 		return
 	}
+	app.events.Publish(characterTopic(form.CharacterId), eventNotes)
 
 	tmplStr := `<form id="deleteNote" hx-post="/characters/{{.Form.CharacterId}}/deleteNote" hx-target="this" hx-swap="outerHTML">
 								<input type="hidden" name="csrf_token" value="{{.CSRFToken}}">
@@ -793,6 +1111,11 @@ func (app *application) addNotePost(w http.ResponseWriter, r *http.Request) {
 }
 
 func (app *application) deleteNotePost(w http.ResponseWriter, r *http.Request) {
+	characterId, ok := app.characterIdFromPath(w, r)
+	if !ok {
+		return
+	}
+
 	type deleteForm struct {
 		NoteId int
 	}
@@ -809,6 +1132,7 @@ func (app *application) deleteNotePost(w http.ResponseWriter, r *http.Request) {
 		app.serverError(w, r, err)
 		return
 	}
+	app.events.Publish(characterTopic(characterId), eventNotes)
 
 	w.WriteHeader(http.StatusOK)
 	app.renderHtmx(w, r, "empty", "", templateData{})
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"encoding/json"
 	"net/http"
 	"net/url"
 	"strconv"
@@ -20,6 +21,9 @@ func TestCharacter(t *testing.T) {
 		"<div id='skills'>",
 		"<div id='items'>",
 		"<div id='notes'>",
+		"<div id='rolls'>",
+		"<td>Intrige (60)</td>",
+		"<th>Schadensbonus</th>",
 		"Otto Hightower",
 	}
 
@@ -81,6 +85,8 @@ func TestCreateCharacterGet(t *testing.T) {
 		"<div id='info'>",
 		"<div id='attributes'>",
 		"<div id='skills'>",
+		"<select name='OccupationID'>",
+		"Lord (BI×4, Finanzkraft 0-99)",
 	}
 
 	tests := []struct {
@@ -140,6 +146,10 @@ func TestCreateCharacterPost(t *testing.T) {
 		name                  string
 		info                  core.CharacterInfo
 		attributes            core.CharacterAttributes
+		ageDeductions         core.AgeDeductions
+		occupationId          int
+		attributeMode         string
+		rolledAttributes      string
 		skills                core.Skills
 		customSkills          core.CustomSkills
 		authenticatedUserId   int
@@ -150,20 +160,151 @@ func TestCreateCharacterPost(t *testing.T) {
 			name:                  "Valid Creation",
 			info:                  mocks.MockCharacterOtto.Info,
 			attributes:            mocks.MockCharacterOtto.Attributes,
+			ageDeductions:         core.AgeDeductions{ST: 10, KO: 5, GE: 5},
+			occupationId:          mocks.MockOccupation.ID,
 			skills:                mocks.MockCharacterOtto.Skills,
 			customSkills:          mocks.MockCharacterOtto.CustomSkills,
 			authenticatedUserId:   1,
 			authenticatedUserName: "Testnutzer",
 			wantCode:              http.StatusSeeOther,
 		},
+		{
+			name:                  "Missing Age Deductions",
+			info:                  mocks.MockCharacterOtto.Info,
+			attributes:            mocks.MockCharacterOtto.Attributes,
+			ageDeductions:         core.AgeDeductions{ST: 10},
+			occupationId:          mocks.MockOccupation.ID,
+			skills:                mocks.MockCharacterOtto.Skills,
+			customSkills:          mocks.MockCharacterOtto.CustomSkills,
+			authenticatedUserId:   1,
+			authenticatedUserName: "Testnutzer",
+			wantCode:              http.StatusUnprocessableEntity,
+		},
+		{
+			name:                  "Age Deduction From Wrong Attribute",
+			info:                  mocks.MockCharacterOtto.Info,
+			attributes:            mocks.MockCharacterOtto.Attributes,
+			ageDeductions:         core.AgeDeductions{ST: 10, GR: 10},
+			occupationId:          mocks.MockOccupation.ID,
+			skills:                mocks.MockCharacterOtto.Skills,
+			customSkills:          mocks.MockCharacterOtto.CustomSkills,
+			authenticatedUserId:   1,
+			authenticatedUserName: "Testnutzer",
+			wantCode:              http.StatusUnprocessableEntity,
+		},
+		{
+			name:                  "Age Deduction Too High",
+			info:                  mocks.MockCharacterOtto.Info,
+			attributes:            mocks.MockCharacterOtto.Attributes,
+			ageDeductions:         core.AgeDeductions{ST: 40, KO: -20},
+			occupationId:          mocks.MockOccupation.ID,
+			skills:                mocks.MockCharacterOtto.Skills,
+			customSkills:          mocks.MockCharacterOtto.CustomSkills,
+			authenticatedUserId:   1,
+			authenticatedUserName: "Testnutzer",
+			wantCode:              http.StatusUnprocessableEntity,
+		},
+		{
+			name:                  "Valid Rolled Creation",
+			info:                  mocks.MockCharacterOtto.Info,
+			attributes:            mocks.MockCharacterOtto.Attributes,
+			ageDeductions:         core.AgeDeductions{ST: 10, KO: 5, GE: 5},
+			occupationId:          mocks.MockOccupation.ID,
+			attributeMode:         attributesRolled,
+			rolledAttributes:      rolledAttributesJSON(t, mocks.MockCharacterOtto.Attributes),
+			skills:                mocks.MockCharacterOtto.Skills,
+			customSkills:          mocks.MockCharacterOtto.CustomSkills,
+			authenticatedUserId:   1,
+			authenticatedUserName: "Testnutzer",
+			wantCode:              http.StatusSeeOther,
+		},
+		{
+			name:                  "Tampered Rolled Attributes",
+			info:                  mocks.MockCharacterOtto.Info,
+			attributes:            mocks.MockCharacterOtto.Attributes,
+			ageDeductions:         core.AgeDeductions{ST: 10, KO: 5, GE: 5},
+			occupationId:          mocks.MockOccupation.ID,
+			attributeMode:         attributesRolled,
+			rolledAttributes:      rolledAttributesJSON(t, core.CharacterAttributes{ST: 45, GE: 55, MA: 75, KO: 50, ER: 40, BI: 80, GR: 55, IN: 50}),
+			skills:                mocks.MockCharacterOtto.Skills,
+			customSkills:          mocks.MockCharacterOtto.CustomSkills,
+			authenticatedUserId:   1,
+			authenticatedUserName: "Testnutzer",
+			wantCode:              http.StatusUnprocessableEntity,
+		},
+		{
+			name:                  "Rolled Without Rolls",
+			info:                  mocks.MockCharacterOtto.Info,
+			attributes:            mocks.MockCharacterOtto.Attributes,
+			ageDeductions:         core.AgeDeductions{ST: 10, KO: 5, GE: 5},
+			occupationId:          mocks.MockOccupation.ID,
+			attributeMode:         attributesRolled,
+			skills:                mocks.MockCharacterOtto.Skills,
+			customSkills:          mocks.MockCharacterOtto.CustomSkills,
+			authenticatedUserId:   1,
+			authenticatedUserName: "Testnutzer",
+			wantCode:              http.StatusUnprocessableEntity,
+		},
+		{
+			name:                  "Fixed Array After Rolling",
+			info:                  mocks.MockCharacterOtto.Info,
+			attributes:            mocks.MockCharacterOtto.Attributes,
+			ageDeductions:         core.AgeDeductions{ST: 10, KO: 5, GE: 5},
+			occupationId:          mocks.MockOccupation.ID,
+			rolledAttributes:      rolledAttributesJSON(t, core.CharacterAttributes{ST: 45, GE: 55, MA: 75, KO: 50, ER: 40, BI: 80, GR: 55, IN: 50}),
+			skills:                mocks.MockCharacterOtto.Skills,
+			customSkills:          mocks.MockCharacterOtto.CustomSkills,
+			authenticatedUserId:   1,
+			authenticatedUserName: "Testnutzer",
+			wantCode:              http.StatusUnprocessableEntity,
+		},
+		{
+			name:                  "Unknown Occupation",
+			info:                  mocks.MockCharacterOtto.Info,
+			attributes:            mocks.MockCharacterOtto.Attributes,
+			ageDeductions:         core.AgeDeductions{ST: 10, KO: 5, GE: 5},
+			occupationId:          99,
+			skills:                mocks.MockCharacterOtto.Skills,
+			customSkills:          mocks.MockCharacterOtto.CustomSkills,
+			authenticatedUserId:   1,
+			authenticatedUserName: "Testnutzer",
+			wantCode:              http.StatusUnprocessableEntity,
+		},
+		{
+			name:                  "Too Many Skill Points",
+			info:                  mocks.MockCharacterOtto.Info,
+			attributes:            mocks.MockCharacterOtto.Attributes,
+			ageDeductions:         core.AgeDeductions{ST: 10, KO: 5, GE: 5},
+			occupationId:          mocks.MockOccupation.ID,
+			skills:                core.Skills{Name: []string{"Politik", "Intrige", "Schwertkampf", "Singen", "Tanzen"}, Value: []int{99, 99, 99, 99, 99}},
+			customSkills:          mocks.MockCharacterOtto.CustomSkills,
+			authenticatedUserId:   1,
+			authenticatedUserName: "Testnutzer",
+			wantCode:              http.StatusUnprocessableEntity,
+		},
+		{
+			name:                  "Skill Below Base",
+			info:                  mocks.MockCharacterOtto.Info,
+			attributes:            mocks.MockCharacterOtto.Attributes,
+			ageDeductions:         core.AgeDeductions{ST: 10, KO: 5, GE: 5},
+			occupationId:          mocks.MockOccupation.ID,
+			skills:                core.Skills{Name: []string{"Singen"}, Value: []int{5}},
+			authenticatedUserId:   1,
+			authenticatedUserName: "Testnutzer",
+			wantCode:              http.StatusUnprocessableEntity,
+		},
 	}
 
 	for _, testCase := range tests {
 		t.Run(testCase.name, func(t *testing.T) {
-			ts := newTestServer(t, app.sessionManager.LoadAndSave(app.mockSession(app.authenticate(noSurf(app.requireAuthentication(app.routesNoMW()))), map[string]any{
+			session := map[string]any{
 				authenticatedUserIdKey:   testCase.authenticatedUserId,
 				authenticatedUserNameKey: testCase.authenticatedUserName,
-			})))
+			}
+			if testCase.rolledAttributes != "" {
+				session[rolledAttributesKey] = testCase.rolledAttributes
+			}
+			ts := newTestServer(t, app.sessionManager.LoadAndSave(app.mockSession(app.authenticate(noSurf(app.requireAuthentication(app.routesNoMW()))), session)))
 			defer ts.Close()
 			_, _, body := ts.get(t, "/create")
 
@@ -171,7 +312,6 @@ func TestCreateCharacterPost(t *testing.T) {
 
 			form := url.Values{}
 			form.Add("Info.Name", testCase.info.Name)
-			form.Add("Info.Profession", testCase.info.Profession)
 			form.Add("Info.Age", testCase.info.Age)
 			form.Add("Info.Gender", testCase.info.Gender)
 			form.Add("Info.Residence", testCase.info.Residence)
@@ -186,6 +326,12 @@ func TestCreateCharacterPost(t *testing.T) {
 			form.Add("Attributes.GR", strconv.Itoa(testCase.attributes.GR))
 			form.Add("Attributes.IN", strconv.Itoa(testCase.attributes.IN))
 			form.Add("Attributes.BW", strconv.Itoa(testCase.attributes.BW))
+			form.Add("AttributeMode", testCase.attributeMode)
+			form.Add("OccupationID", strconv.Itoa(testCase.occupationId))
+
+			for attr, deduction := range testCase.ageDeductions.AsMap() {
+				form.Add("AgeDeductions."+attr, strconv.Itoa(deduction))
+			}
 
 			for i, skill := range testCase.skills.Name {
 				form.Add("Skills.Name", skill)
@@ -193,6 +339,7 @@ func TestCreateCharacterPost(t *testing.T) {
 			}
 
 			for i, customSkill := range testCase.customSkills.Name {
+				form.Add("CustomSkills.Category", testCase.customSkills.Category[i])
 				form.Add("CustomSkills.Name", customSkill)
 				form.Add("CustomSkills.Value", strconv.Itoa(testCase.customSkills.Value[i]))
 			}
@@ -201,11 +348,105 @@ func TestCreateCharacterPost(t *testing.T) {
 			code, header, _ := ts.postForm(t, "/create", form)
 
 			testHelpers.Equal(t, code, testCase.wantCode)
-			testHelpers.Equal(t, header.Get("Location"), "/")
+			if testCase.wantCode == http.StatusSeeOther {
+				testHelpers.Equal(t, header.Get("Location"), "/")
+			}
+		})
+	}
+}
+
+func TestCreateRollPost(t *testing.T) {
+	app := newTestApplication(t)
+
+	tests := []struct {
+		name             string
+		rolledAttributes string
+		wantCode         int
+		wantContent      []string
+	}{
+		{
+			name:        "First Roll",
+			wantCode:    http.StatusOK,
+			wantContent: []string{"<input type='hidden' name='AttributeMode' value='rolled'>", "<label>ST</label>"},
+		},
+		{
+			name:             "No Reroll",
+			rolledAttributes: rolledAttributesJSON(t, core.CharacterAttributes{ST: 35, GE: 55, MA: 75, KO: 50, ER: 40, BI: 80, GR: 55, IN: 90}),
+			wantCode:         http.StatusOK,
+			wantContent:      []string{"<input type='hidden' name='Attributes.ST' value='35'>", "<input type='hidden' name='Attributes.IN' value='90'>"},
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			session := map[string]any{
+				authenticatedUserIdKey:   1,
+				authenticatedUserNameKey: "Testnutzer",
+			}
+			if testCase.rolledAttributes != "" {
+				session[rolledAttributesKey] = testCase.rolledAttributes
+			}
+			ts := newTestServer(t, app.sessionManager.LoadAndSave(app.mockSession(app.authenticate(noSurf(app.requireAuthentication(app.routesNoMW()))), session)))
+			defer ts.Close()
+			_, _, body := ts.get(t, "/create")
+
+			form := url.Values{}
+			form.Add("Info.Name", mocks.MockCharacterOtto.Info.Name)
+			form.Add("csrf_token", extractCSRFToken(t, body))
+
+			code, _, body := ts.postForm(t, "/create/roll", form)
+
+			testHelpers.Equal(t, code, testCase.wantCode)
+			for _, content := range testCase.wantContent {
+				testHelpers.StringContains(t, body, content)
+			}
 		})
 	}
 }
 
+// the recorded rolls as stored in the session, one per rolled attribute
+func rolledAttributesJSON(t *testing.T, attributes core.CharacterAttributes) string {
+	values := attributes.AsMap()
+	var rolls []core.AttributeRoll
+	for _, attr := range []string{"ST", "KO", "GE", "ER", "MA", "GR", "IN", "BI"} {
+		rolls = append(rolls, core.AttributeRoll{Attribute: attr, Value: values[attr]})
+	}
+
+	encoded, err := json.Marshal(rolls)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(encoded)
+}
+
+func TestDeleteCharacterPost(t *testing.T) {
+	otto, viserys := mocks.MockCharacterOtto, mocks.MockCharacterViserys
+	t.Cleanup(func() {
+		mocks.MockCharacterOtto, mocks.MockCharacterViserys = otto, viserys
+	})
+
+	app := newTestApplication(t)
+
+	ts := newTestServer(t, app.sessionManager.LoadAndSave(app.mockSession(noSurf(app.authenticate(app.requireAuthentication(app.routesNoMW()))),
+		map[string]any{
+			authenticatedUserIdKey:   2,
+			authenticatedUserNameKey: "Testnutzer",
+		})))
+	defer ts.Close()
+	_, _, body := ts.get(t, "/characters/2")
+	validCSRF := extractCSRFToken(t, body)
+
+	form := url.Values{}
+	form.Add("CharacterId", "1")
+	form.Add("csrf_token", validCSRF)
+
+	code, _, _ := ts.postForm(t, "/characters/2/delete", form)
+
+	testHelpers.Equal(t, code, http.StatusSeeOther)
+	testHelpers.Equal(t, mocks.MockCharacterOtto.ID, otto.ID)
+	testHelpers.Equal(t, mocks.MockCharacterViserys.ID, 0)
+}
+
 func TestAddItem(t *testing.T) {
 	app := newTestApplication(t)
 
@@ -391,6 +632,7 @@ func TestAddNote(t *testing.T) {
 
 	tests := []struct {
 		name        string
+		bodyId      string
 		text        string
 		wantCode    int
 		wantContent []string
@@ -401,12 +643,19 @@ func TestAddNote(t *testing.T) {
 			wantCode:    http.StatusOK,
 			wantContent: wantContent,
 		},
+		{
+			name:        "Body Id Is Ignored",
+			bodyId:      "2",
+			text:        "Dies ist eine gültige Notiz.",
+			wantCode:    http.StatusOK,
+			wantContent: wantContent,
+		},
 	}
 
 	for _, testCase := range tests {
 		t.Run(testCase.name, func(t *testing.T) {
 			form := url.Values{}
-			form.Add("CharacterId", "1")
+			form.Add("CharacterId", testCase.bodyId)
 			form.Add("Text", testCase.text)
 			form.Add("csrf_token", validCSRF)
 
@@ -463,3 +712,150 @@ func TestDeleteNote(t *testing.T) {
 		})
 	}
 }
+
+func TestEditAttributePost(t *testing.T) {
+	tests := []struct {
+		name      string
+		userId    int
+		userName  string
+		path      string
+		attribute string
+		value     string
+		wantCode  int
+		wantFlash string
+	}{
+		{
+			name:      "Valid",
+			userId:    mocks.MockGM.ID,
+			userName:  mocks.MockGM.Name,
+			path:      "/characters/1/editAttribute",
+			attribute: "ST",
+			value:     "65",
+			wantCode:  http.StatusSeeOther,
+		},
+		{
+			name:      "Player",
+			userId:    mocks.MockPlayer.ID,
+			userName:  mocks.MockPlayer.Name,
+			path:      "/characters/1/editAttribute",
+			attribute: "ST",
+			value:     "65",
+			wantCode:  http.StatusUnauthorized,
+		},
+		{
+			name:      "NPC Movement Rate",
+			userId:    mocks.MockGM.ID,
+			userName:  mocks.MockGM.Name,
+			path:      "/characters/3/editAttribute",
+			attribute: "BW",
+			value:     "9",
+			wantCode:  http.StatusSeeOther,
+		},
+		{
+			name:      "Derived Movement Rate",
+			userId:    mocks.MockGM.ID,
+			userName:  mocks.MockGM.Name,
+			path:      "/characters/1/editAttribute",
+			attribute: "BW",
+			value:     "9",
+			wantCode:  http.StatusSeeOther,
+			wantFlash: "BW wird aus ST, GE, GR und Alter berechnet.",
+		},
+		{
+			name:      "Unknown Attribute",
+			userId:    mocks.MockGM.ID,
+			userName:  mocks.MockGM.Name,
+			path:      "/characters/1/editAttribute",
+			attribute: "CHA",
+			value:     "50",
+			wantCode:  http.StatusSeeOther,
+			wantFlash: "Unbekanntes Attribut.",
+		},
+		{
+			name:      "Out Of Range",
+			userId:    mocks.MockGM.ID,
+			userName:  mocks.MockGM.Name,
+			path:      "/characters/1/editAttribute",
+			attribute: "GE",
+			value:     "201",
+			wantCode:  http.StatusSeeOther,
+			wantFlash: "Wert muss zwischen 0 und 200 liegen.",
+		},
+		{
+			name:      "Nonexistent Character",
+			userId:    mocks.MockGM.ID,
+			userName:  mocks.MockGM.Name,
+			path:      "/characters/69/editAttribute",
+			attribute: "ST",
+			value:     "65",
+			wantCode:  http.StatusNotFound,
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			app := newTestApplication(t)
+
+			ts := newTestServer(t, app.sessionManager.LoadAndSave(app.mockSession(noSurf(app.authenticate(app.requireAuthentication(app.routesNoMW()))),
+				map[string]any{
+					authenticatedUserIdKey:   testCase.userId,
+					authenticatedUserNameKey: testCase.userName,
+				})))
+			defer ts.Close()
+
+			_, _, body := ts.get(t, "/characters/1")
+			validCSRF := extractCSRFToken(t, body)
+
+			form := url.Values{}
+			form.Add("Name", testCase.attribute)
+			form.Add("Value", testCase.value)
+			form.Add("csrf_token", validCSRF)
+
+			code, _, _ := ts.postForm(t, testCase.path, form)
+
+			testHelpers.Equal(t, code, testCase.wantCode)
+			if testCase.wantFlash != "" {
+				_, _, body := ts.get(t, "/characters/1")
+				testHelpers.StringContains(t, body, testCase.wantFlash)
+			}
+		})
+	}
+}
+
+func TestEditAttributeForm(t *testing.T) {
+	tests := []struct {
+		name     string
+		userId   int
+		userName string
+		want     bool
+	}{
+		{
+			name:     "GM",
+			userId:   mocks.MockGM.ID,
+			userName: mocks.MockGM.Name,
+			want:     true,
+		},
+		{
+			name:     "Player",
+			userId:   mocks.MockPlayer.ID,
+			userName: mocks.MockPlayer.Name,
+			want:     false,
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			app := newTestApplication(t)
+
+			ts := newTestServer(t, app.sessionManager.LoadAndSave(app.mockSession(noSurf(app.authenticate(app.requireAuthentication(app.routesNoMW()))),
+				map[string]any{
+					authenticatedUserIdKey:   testCase.userId,
+					authenticatedUserNameKey: testCase.userName,
+				})))
+			defer ts.Close()
+
+			_, _, body := ts.get(t, "/characters/1")
+			testHelpers.Equal(t, strings.Contains(body, "Attribut ändern"), testCase.want)
+		})
+	}
+}
@@ -0,0 +1,347 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/winik100/NoPenNoPaper/internal/core"
+	"github.com/winik100/NoPenNoPaper/internal/models"
+	"github.com/winik100/NoPenNoPaper/internal/validators"
+)
+
+// most locations the quarry may start ahead of the pursuers
+const maxChaseLead = 10
+
+type startChaseForm struct {
+	Pursuers []int
+	Quarry   []int
+	Lead     int
+}
+
+type chaseLocationForm struct {
+	Position int
+	Name     string
+	Obstacle core.Obstacle
+	Skill    string
+}
+
+type chaserForm struct {
+	CharacterId int
+}
+
+func (app *application) chase(w http.ResponseWriter, r *http.Request) {
+	campaign, ok := app.loadCampaign(w, r)
+	if !ok {
+		return
+	}
+
+	chase, err := app.chases.Get(campaign.ID)
+	if err != nil && !errors.Is(err, models.ErrNoRecord) {
+		app.serverError(w, r, err)
+		return
+	}
+
+	data := app.newTemplateData(r)
+	if campaign.IsRunBy(data.User.ID) && errors.Is(err, models.ErrNoRecord) {
+		// candidates for a new chase
+		data.Characters, err = app.campaignCharacters(campaign.ID)
+		if err != nil {
+			app.serverError(w, r, err)
+			return
+		}
+	}
+
+	data.Campaign = campaign
+	data.Chase = chase
+	w.WriteHeader(http.StatusOK)
+	app.render(w, r, "chase.tmpl.html", data)
+}
+
+// everyone makes their speed roll on KO, the quarry starts the given number of locations ahead
+func (app *application) startChasePost(w http.ResponseWriter, r *http.Request) {
+	campaign, ok := app.loadCampaign(w, r)
+	if !ok {
+		return
+	}
+
+	var form startChaseForm
+	err := app.decodePostForm(r, &form)
+	if err != nil {
+		app.clientError(w, http.StatusBadRequest)
+		return
+	}
+
+	redirect := fmt.Sprintf("/campaigns/%d/chase", campaign.ID)
+	if len(form.Pursuers) == 0 || len(form.Quarry) == 0 {
+		app.sessionManager.Put(r.Context(), "flash", "Bitte mindestens einen Verfolger und einen Flüchtenden auswählen.")
+		http.Redirect(w, r, redirect, http.StatusSeeOther)
+		return
+	}
+	if slices.ContainsFunc(form.Pursuers, func(id int) bool { return slices.Contains(form.Quarry, id) }) {
+		app.sessionManager.Put(r.Context(), "flash", "Niemand kann gleichzeitig verfolgen und flüchten.")
+		http.Redirect(w, r, redirect, http.StatusSeeOther)
+		return
+	}
+	if form.Lead < 1 || form.Lead > maxChaseLead {
+		app.sessionManager.Put(r.Context(), "flash", fmt.Sprintf("Der Vorsprung muss zwischen 1 und %d Orten liegen.", maxChaseLead))
+		http.Redirect(w, r, redirect, http.StatusSeeOther)
+		return
+	}
+
+	candidates, err := app.campaignCharacters(campaign.ID)
+	if err != nil {
+		app.serverError(w, r, err)
+		return
+	}
+
+	chase := core.Chase{CampaignID: campaign.ID}
+	for _, character := range candidates {
+		chaser := core.Chaser{CharacterID: character.ID, Name: character.Info.Name}
+		switch {
+		case slices.Contains(form.Pursuers, character.ID):
+			chaser.Role = core.Pursuer
+		case slices.Contains(form.Quarry, character.ID):
+			chaser.Role = core.Quarry
+			chaser.Position = form.Lead
+		default:
+			continue
+		}
+
+		var roll core.Roll
+		roll, chaser.MOV = core.SpeedRoll(character.ID, character.Attributes.KO, character.Attributes.BW)
+		_, err = app.rolls.Insert(roll)
+		if err != nil {
+			app.serverError(w, r, err)
+			return
+		}
+		app.events.Publish(characterTopic(character.ID), eventRolls)
+		chase.Participants = append(chase.Participants, chaser)
+	}
+	chase.NextRound()
+
+	err = app.chases.Start(chase)
+	if err != nil {
+		app.serverError(w, r, err)
+		return
+	}
+
+	message := "Verfolgungsjagd begonnen."
+	for _, chaser := range chase.Participants {
+		if chase.Escapes(chaser) {
+			message += fmt.Sprintf(" %s ist schneller als alle Verfolger und entkommt.", chaser.Name)
+		}
+	}
+	app.events.Publish(campaignTopic(campaign.ID), eventChase)
+	app.sessionManager.Put(r.Context(), "flash", message)
+	http.Redirect(w, r, redirect, http.StatusSeeOther)
+}
+
+func (app *application) chaseLocationPost(w http.ResponseWriter, r *http.Request) {
+	chase, ok := app.loadChase(w, r)
+	if !ok {
+		return
+	}
+
+	var form chaseLocationForm
+	err := app.decodePostForm(r, &form)
+	if err != nil {
+		app.clientError(w, http.StatusBadRequest)
+		return
+	}
+
+	redirect := fmt.Sprintf("/campaigns/%d/chase", chase.CampaignID)
+	form.Name = strings.TrimSpace(form.Name)
+	form.Skill = strings.TrimSpace(form.Skill)
+	if form.Position < 1 {
+		app.sessionManager.Put(r.Context(), "flash", "Der Ausgangspunkt kann nicht beschrieben werden.")
+		http.Redirect(w, r, redirect, http.StatusSeeOther)
+		return
+	}
+	if form.Position > chase.TrackEnd()+core.MaxLocationsAhead {
+		app.sessionManager.Put(r.Context(), "flash", fmt.Sprintf("Orte können höchstens %d Positionen über das Ende der Strecke hinaus beschrieben werden.", core.MaxLocationsAhead))
+		http.Redirect(w, r, redirect, http.StatusSeeOther)
+		return
+	}
+	if !validators.MaxChars(form.Name, 255) || !validators.MaxChars(form.Skill, 255) {
+		app.sessionManager.Put(r.Context(), "flash", "Maximal 255 Zeichen erlaubt.")
+		http.Redirect(w, r, redirect, http.StatusSeeOther)
+		return
+	}
+	if !validators.PermittedValue(form.Obstacle, core.NoObstacle, core.Hazard, core.Barrier) {
+		app.clientError(w, http.StatusUnprocessableEntity)
+		return
+	}
+	if form.Obstacle != core.NoObstacle && !validators.NotBlank(form.Skill) {
+		app.sessionManager.Put(r.Context(), "flash", "Für ein Hindernis muss eine Fertigkeit angegeben werden.")
+		http.Redirect(w, r, redirect, http.StatusSeeOther)
+		return
+	}
+	if form.Obstacle == core.NoObstacle {
+		form.Skill = ""
+	}
+
+	err = app.chases.SetLocation(chase.CampaignID, core.Location{Position: form.Position, Name: form.Name, Obstacle: form.Obstacle, Skill: form.Skill})
+	if err != nil {
+		app.serverError(w, r, err)
+		return
+	}
+
+	app.events.Publish(campaignTopic(chase.CampaignID), eventChase)
+	http.Redirect(w, r, redirect, http.StatusSeeOther)
+}
+
+// spends a movement action of the participant, obstacles in the way are rolled for right away
+func (app *application) chaseMovePost(w http.ResponseWriter, r *http.Request) {
+	chase, ok := app.loadChase(w, r)
+	if !ok {
+		return
+	}
+
+	var form chaserForm
+	err := app.decodePostForm(r, &form)
+	if err != nil {
+		app.clientError(w, http.StatusBadRequest)
+		return
+	}
+
+	redirect := fmt.Sprintf("/campaigns/%d/chase", chase.CampaignID)
+	location, err := chase.NextLocation(form.CharacterId)
+	if err != nil {
+		switch {
+		case errors.Is(err, core.ErrNotChasing):
+			app.sessionManager.Put(r.Context(), "flash", "Dieser Charakter nimmt nicht an der Verfolgungsjagd teil.")
+		case errors.Is(err, core.ErrNoMovementActions):
+			app.sessionManager.Put(r.Context(), "flash", "Dieser Charakter hat in dieser Runde keine Bewegungsaktionen mehr.")
+		default:
+			app.serverError(w, r, err)
+			return
+		}
+		http.Redirect(w, r, redirect, http.StatusSeeOther)
+		return
+	}
+
+	var roll *core.Roll
+	if location.Obstacle != core.NoObstacle {
+		character, err := app.characters.Get(form.CharacterId)
+		if err != nil {
+			app.serverError(w, r, err)
+			return
+		}
+		value, ok, err := app.skillValue(character, location.Skill)
+		if err != nil {
+			app.serverError(w, r, err)
+			return
+		}
+		if !ok {
+			app.sessionManager.Put(r.Context(), "flash", fmt.Sprintf("Unbekannte Fertigkeit: %s.", location.Skill))
+			http.Redirect(w, r, redirect, http.StatusSeeOther)
+			return
+		}
+
+		obstacleRoll := core.NewRoll(character.ID, location.Skill, value, 0)
+		obstacleRoll.Source = core.SourceChase
+		obstacleRoll.ID, err = app.rolls.Insert(obstacleRoll)
+		if err != nil {
+			app.serverError(w, r, err)
+			return
+		}
+		app.events.Publish(characterTopic(character.ID), eventRolls)
+
+		err = app.tickSkill(character, obstacleRoll)
+		if err != nil {
+			app.serverError(w, r, err)
+			return
+		}
+		roll = &obstacleRoll
+	}
+
+	move, err := chase.Move(form.CharacterId, roll)
+	if err != nil {
+		app.serverError(w, r, err)
+		return
+	}
+	err = app.chases.SaveProgress(chase)
+	if err != nil {
+		app.serverError(w, r, err)
+		return
+	}
+
+	app.events.Publish(campaignTopic(chase.CampaignID), eventChase)
+	app.sessionManager.Put(r.Context(), "flash", chaseMoveMessage(move))
+	http.Redirect(w, r, redirect, http.StatusSeeOther)
+}
+
+func (app *application) nextChaseRoundPost(w http.ResponseWriter, r *http.Request) {
+	chase, ok := app.loadChase(w, r)
+	if !ok {
+		return
+	}
+
+	chase.NextRound()
+	err := app.chases.SaveProgress(chase)
+	if err != nil {
+		app.serverError(w, r, err)
+		return
+	}
+
+	app.events.Publish(campaignTopic(chase.CampaignID), eventChase)
+	http.Redirect(w, r, fmt.Sprintf("/campaigns/%d/chase", chase.CampaignID), http.StatusSeeOther)
+}
+
+func (app *application) endChasePost(w http.ResponseWriter, r *http.Request) {
+	campaign, ok := app.loadCampaign(w, r)
+	if !ok {
+		return
+	}
+
+	err := app.chases.End(campaign.ID)
+	if err != nil {
+		app.serverError(w, r, err)
+		return
+	}
+
+	app.events.Publish(campaignTopic(campaign.ID), eventChase)
+	app.sessionManager.Put(r.Context(), "flash", "Verfolgungsjagd beendet.")
+	http.Redirect(w, r, fmt.Sprintf("/campaigns/%d", campaign.ID), http.StatusSeeOther)
+}
+
+func (app *application) loadChase(w http.ResponseWriter, r *http.Request) (core.Chase, bool) {
+	campaign, ok := app.loadCampaign(w, r)
+	if !ok {
+		return core.Chase{}, false
+	}
+
+	chase, err := app.chases.Get(campaign.ID)
+	if err != nil {
+		if errors.Is(err, models.ErrNoRecord) {
+			http.NotFound(w, r)
+		} else {
+			app.serverError(w, r, err)
+		}
+		return core.Chase{}, false
+	}
+	return chase, true
+}
+
+func chaseMoveMessage(move core.ChaseMove) string {
+	name := move.Chaser.Name
+	place := move.Location.Name
+	if place == "" {
+		place = fmt.Sprintf("Ort %d", move.Location.Position)
+	}
+	if move.Roll == nil {
+		return fmt.Sprintf("%s erreicht %s.", name, place)
+	}
+
+	roll := fmt.Sprintf("%s: %d, %s", move.Roll.Name, move.Roll.Result, move.Roll.Level)
+	switch {
+	case !move.Moved:
+		return fmt.Sprintf("%s scheitert an der Barriere vor %s (%s).", name, place, roll)
+	case move.Lost > 0:
+		return fmt.Sprintf("%s erreicht %s, verliert durch die Gefahr aber %d Bewegungsaktion(en) (%s).", name, place, move.Lost, roll)
+	}
+	return fmt.Sprintf("%s überwindet die %s und erreicht %s (%s).", name, move.Location.Obstacle, place, roll)
+}
@@ -0,0 +1,336 @@
+package main
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/winik100/NoPenNoPaper/internal/models/mocks"
+	"github.com/winik100/NoPenNoPaper/internal/testHelpers"
+)
+
+func TestChase(t *testing.T) {
+	app := newTestApplication(t)
+
+	tests := []struct {
+		name                  string
+		path                  string
+		authenticatedUserId   int
+		authenticatedUserName string
+		wantCode              int
+		wantContent           []string
+	}{
+		{
+			name:                  "As GM",
+			path:                  "/campaigns/1/chase",
+			authenticatedUserId:   mocks.MockGM.ID,
+			authenticatedUserName: mocks.MockGM.Name,
+			wantCode:              http.StatusOK,
+			wantContent: []string{
+				"<h3>Runde 1</h3>",
+				"<td>Fischmarkt</td>",
+				"<td>Gefahr (Ausweichen)</td>",
+				"<td>Larys Strong</td>",
+				"<button type=\"submit\" disabled>weiter</button>",
+				"<button type=\"submit\">nächste Runde</button>",
+			},
+		},
+		{
+			name:                  "As Player",
+			path:                  "/campaigns/1/chase",
+			authenticatedUserId:   mocks.MockPlayer.ID,
+			authenticatedUserName: mocks.MockPlayer.Name,
+			wantCode:              http.StatusOK,
+			wantContent: []string{
+				"<h3>Runde 1</h3>",
+				"Larys Strong (NSC)",
+				"<td>Barriere (Intrige)</td>",
+			},
+		},
+		{
+			name:                  "Nonexistent Campaign",
+			path:                  "/campaigns/69/chase",
+			authenticatedUserId:   mocks.MockGM.ID,
+			authenticatedUserName: mocks.MockGM.Name,
+			wantCode:              http.StatusNotFound,
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			ts := newTestServer(t, app.sessionManager.LoadAndSave(app.mockSession(noSurf(app.authenticate(app.requireAuthentication(app.routesNoMW()))),
+				map[string]any{
+					authenticatedUserIdKey:   testCase.authenticatedUserId,
+					authenticatedUserNameKey: testCase.authenticatedUserName,
+				})))
+			defer ts.Close()
+
+			code, _, body := ts.get(t, testCase.path)
+
+			testHelpers.Equal(t, code, testCase.wantCode)
+			for _, tag := range testCase.wantContent {
+				testHelpers.StringContains(t, body, tag)
+			}
+		})
+	}
+}
+
+func TestStartChasePost(t *testing.T) {
+	app := newTestApplication(t)
+
+	ts := newTestServer(t, app.sessionManager.LoadAndSave(app.mockSession(noSurf(app.authenticate(app.requireAuthentication(app.routesNoMW()))),
+		map[string]any{
+			authenticatedUserIdKey:   mocks.MockGM.ID,
+			authenticatedUserNameKey: mocks.MockGM.Name,
+		})))
+	defer ts.Close()
+
+	_, _, body := ts.get(t, "/campaigns/1/chase")
+	validCSRF := extractCSRFToken(t, body)
+
+	tests := []struct {
+		name      string
+		pursuers  []int
+		quarry    []int
+		lead      string
+		wantCode  int
+		wantFlash string
+	}{
+		{
+			name:      "Valid Chase",
+			pursuers:  []int{1, 2},
+			quarry:    []int{3},
+			lead:      "2",
+			wantCode:  http.StatusSeeOther,
+			wantFlash: "Verfolgungsjagd begonnen.",
+		},
+		{
+			name:      "No Quarry",
+			pursuers:  []int{1},
+			lead:      "2",
+			wantCode:  http.StatusSeeOther,
+			wantFlash: "Bitte mindestens einen Verfolger und einen Flüchtenden auswählen.",
+		},
+		{
+			name:      "Both Roles",
+			pursuers:  []int{1, 3},
+			quarry:    []int{3},
+			lead:      "2",
+			wantCode:  http.StatusSeeOther,
+			wantFlash: "Niemand kann gleichzeitig verfolgen und flüchten.",
+		},
+		{
+			name:      "No Lead",
+			pursuers:  []int{1},
+			quarry:    []int{3},
+			lead:      "0",
+			wantCode:  http.StatusSeeOther,
+			wantFlash: "Der Vorsprung muss zwischen 1 und 10 Orten liegen.",
+		},
+		{
+			name:     "Invalid Lead",
+			pursuers: []int{1},
+			quarry:   []int{3},
+			lead:     "weit",
+			wantCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			form := url.Values{}
+			for _, id := range testCase.pursuers {
+				form.Add("Pursuers", strconv.Itoa(id))
+			}
+			for _, id := range testCase.quarry {
+				form.Add("Quarry", strconv.Itoa(id))
+			}
+			form.Add("Lead", testCase.lead)
+			form.Add("csrf_token", validCSRF)
+
+			code, header, _ := ts.postForm(t, "/campaigns/1/chase/start", form)
+
+			testHelpers.Equal(t, code, testCase.wantCode)
+			if testCase.wantFlash != "" {
+				testHelpers.Equal(t, header.Get("Location"), "/campaigns/1/chase")
+				_, _, body := ts.get(t, "/campaigns/1/chase")
+				testHelpers.StringContains(t, body, testCase.wantFlash)
+			}
+		})
+	}
+}
+
+func TestChaseLocationPost(t *testing.T) {
+	app := newTestApplication(t)
+
+	ts := newTestServer(t, app.sessionManager.LoadAndSave(app.mockSession(noSurf(app.authenticate(app.requireAuthentication(app.routesNoMW()))),
+		map[string]any{
+			authenticatedUserIdKey:   mocks.MockGM.ID,
+			authenticatedUserNameKey: mocks.MockGM.Name,
+		})))
+	defer ts.Close()
+
+	_, _, body := ts.get(t, "/campaigns/1/chase")
+	validCSRF := extractCSRFToken(t, body)
+
+	tests := []struct {
+		name      string
+		position  string
+		locName   string
+		obstacle  string
+		skill     string
+		wantCode  int
+		wantFlash string
+	}{
+		{
+			name:     "Valid Hazard",
+			position: "4",
+			obstacle: "hazard",
+			skill:    "Klettern",
+			wantCode: http.StatusSeeOther,
+		},
+		{
+			name:      "Obstacle Without Skill",
+			position:  "4",
+			obstacle:  "barrier",
+			wantCode:  http.StatusSeeOther,
+			wantFlash: "Für ein Hindernis muss eine Fertigkeit angegeben werden.",
+		},
+		{
+			name:      "Starting Point",
+			position:  "0",
+			wantCode:  http.StatusSeeOther,
+			wantFlash: "Der Ausgangspunkt kann nicht beschrieben werden.",
+		},
+		{
+			name:     "Furthest Position",
+			position: "13",
+			wantCode: http.StatusSeeOther,
+		},
+		{
+			name:      "Too Far Ahead",
+			position:  "14",
+			wantCode:  http.StatusSeeOther,
+			wantFlash: "Orte können höchstens 10 Positionen über das Ende der Strecke hinaus beschrieben werden.",
+		},
+		{
+			name:      "Name Too Long",
+			position:  "4",
+			locName:   strings.Repeat("a", 256),
+			wantCode:  http.StatusSeeOther,
+			wantFlash: "Maximal 255 Zeichen erlaubt.",
+		},
+		{
+			name:      "Skill Too Long",
+			position:  "4",
+			obstacle:  "hazard",
+			skill:     strings.Repeat("a", 256),
+			wantCode:  http.StatusSeeOther,
+			wantFlash: "Maximal 255 Zeichen erlaubt.",
+		},
+		{
+			name:     "Invalid Obstacle",
+			position: "4",
+			obstacle: "lava",
+			skill:    "Springen",
+			wantCode: http.StatusUnprocessableEntity,
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			locName := testCase.locName
+			if locName == "" {
+				locName = "Gasse"
+			}
+
+			form := url.Values{}
+			form.Add("Position", testCase.position)
+			form.Add("Name", locName)
+			form.Add("Obstacle", testCase.obstacle)
+			form.Add("Skill", testCase.skill)
+			form.Add("csrf_token", validCSRF)
+
+			code, header, _ := ts.postForm(t, "/campaigns/1/chase/location", form)
+
+			testHelpers.Equal(t, code, testCase.wantCode)
+			if testCase.wantFlash != "" {
+				testHelpers.Equal(t, header.Get("Location"), "/campaigns/1/chase")
+				_, _, body := ts.get(t, "/campaigns/1/chase")
+				testHelpers.StringContains(t, body, testCase.wantFlash)
+			}
+		})
+	}
+}
+
+func TestChaseMovePost(t *testing.T) {
+	app := newTestApplication(t)
+
+	ts := newTestServer(t, app.sessionManager.LoadAndSave(app.mockSession(noSurf(app.authenticate(app.requireAuthentication(app.routesNoMW()))),
+		map[string]any{
+			authenticatedUserIdKey:   mocks.MockGM.ID,
+			authenticatedUserNameKey: mocks.MockGM.Name,
+		})))
+	defer ts.Close()
+
+	_, _, body := ts.get(t, "/campaigns/1/chase")
+	validCSRF := extractCSRFToken(t, body)
+
+	tests := []struct {
+		name        string
+		characterId string
+		wantCode    int
+		wantFlash   []string
+	}{
+		{
+			name:        "Into Hazard",
+			characterId: "1",
+			wantCode:    http.StatusSeeOther,
+			wantFlash:   []string{"Otto Hightower", "Fischmarkt", "Ausweichen: "},
+		},
+		{
+			name:        "Against Barrier",
+			characterId: "3",
+			wantCode:    http.StatusSeeOther,
+			wantFlash:   []string{"Larys Strong", "Stadttor", "Intrige: "},
+		},
+		{
+			name:        "No Actions Left",
+			characterId: "2",
+			wantCode:    http.StatusSeeOther,
+			wantFlash:   []string{"Dieser Charakter hat in dieser Runde keine Bewegungsaktionen mehr."},
+		},
+		{
+			name:        "Not Chasing",
+			characterId: "69",
+			wantCode:    http.StatusSeeOther,
+			wantFlash:   []string{"Dieser Charakter nimmt nicht an der Verfolgungsjagd teil."},
+		},
+		{
+			name:        "Invalid Character",
+			characterId: "Otto",
+			wantCode:    http.StatusBadRequest,
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			form := url.Values{}
+			form.Add("CharacterId", testCase.characterId)
+			form.Add("csrf_token", validCSRF)
+
+			code, header, _ := ts.postForm(t, "/campaigns/1/chase/move", form)
+
+			testHelpers.Equal(t, code, testCase.wantCode)
+			if len(testCase.wantFlash) > 0 {
+				testHelpers.Equal(t, header.Get("Location"), "/campaigns/1/chase")
+				_, _, body := ts.get(t, "/campaigns/1/chase")
+				for _, flash := range testCase.wantFlash {
+					testHelpers.StringContains(t, body, flash)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,288 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/winik100/NoPenNoPaper/internal/core"
+	"github.com/winik100/NoPenNoPaper/internal/models"
+	"github.com/winik100/NoPenNoPaper/internal/validators"
+)
+
+const chronicleDateLayout = "2006-01-02"
+
+type chronicleForm struct {
+	Date                     string
+	Title                    string
+	Body                     string
+	CharacterIds             []int
+	HandoutIds               []int
+	NoteIds                  []int
+	validators.FormValidator `schema:"-"`
+}
+
+func (f chronicleForm) HasCharacter(characterId int) bool {
+	return slices.Contains(f.CharacterIds, characterId)
+}
+
+func (f chronicleForm) HasHandout(handoutId int) bool {
+	return slices.Contains(f.HandoutIds, handoutId)
+}
+
+func (f chronicleForm) HasNote(noteId int) bool {
+	return slices.Contains(f.NoteIds, noteId)
+}
+
+func (app *application) campaignChronicle(w http.ResponseWriter, r *http.Request) {
+	campaign, ok := app.loadCampaign(w, r)
+	if !ok {
+		return
+	}
+
+	entries, err := app.chronicle.GetAll(campaign.ID)
+	if err != nil {
+		app.serverError(w, r, err)
+		return
+	}
+
+	data := app.newTemplateData(r)
+	data.Campaign = campaign
+	data.Chronicle = entries
+	w.WriteHeader(http.StatusOK)
+	app.render(w, r, "chronicle.tmpl.html", data)
+}
+
+func (app *application) chronicleEntry(w http.ResponseWriter, r *http.Request) {
+	campaign, ok := app.loadCampaign(w, r)
+	if !ok {
+		return
+	}
+	entry, ok := app.loadChronicleEntry(w, r, campaign.ID)
+	if !ok {
+		return
+	}
+
+	data := app.newTemplateData(r)
+	if !campaign.IsRunBy(data.User.ID) {
+		// players only get to see handouts that were revealed to them
+		var visible []core.Handout
+		for _, handout := range entry.Handouts {
+			if handout.RevealedToAll() || handout.RevealedTo == data.User.ID {
+				visible = append(visible, handout)
+			}
+		}
+		entry.Handouts = visible
+	}
+
+	data.Campaign = campaign
+	data.ChronicleEntry = entry
+	w.WriteHeader(http.StatusOK)
+	app.render(w, r, "chronicleEntry.tmpl.html", data)
+}
+
+func (app *application) createChronicleEntry(w http.ResponseWriter, r *http.Request) {
+	campaign, ok := app.loadCampaign(w, r)
+	if !ok {
+		return
+	}
+
+	app.renderChronicleForm(w, r, http.StatusOK, campaign, core.ChronicleEntry{},
+		chronicleForm{Date: time.Now().Format(chronicleDateLayout)})
+}
+
+func (app *application) createChronicleEntryPost(w http.ResponseWriter, r *http.Request) {
+	campaign, ok := app.loadCampaign(w, r)
+	if !ok {
+		return
+	}
+
+	var form chronicleForm
+	err := app.decodePostForm(r, &form)
+	if err != nil {
+		app.clientError(w, http.StatusBadRequest)
+		return
+	}
+
+	entry := core.ChronicleEntry{CampaignID: campaign.ID}
+	if !app.validChronicleForm(&form, &entry) {
+		app.renderChronicleForm(w, r, http.StatusUnprocessableEntity, campaign, entry, form)
+		return
+	}
+
+	entryId, err := app.chronicle.Insert(entry, form.CharacterIds, form.HandoutIds, form.NoteIds)
+	if err != nil {
+		app.serverError(w, r, err)
+		return
+	}
+
+	redirect := fmt.Sprintf("/campaigns/%d/chronicle/%d", campaign.ID, entryId)
+	http.Redirect(w, r, redirect, http.StatusSeeOther)
+}
+
+func (app *application) editChronicleEntry(w http.ResponseWriter, r *http.Request) {
+	campaign, ok := app.loadCampaign(w, r)
+	if !ok {
+		return
+	}
+	entry, ok := app.loadChronicleEntry(w, r, campaign.ID)
+	if !ok {
+		return
+	}
+
+	form := chronicleForm{
+		Date:  entry.Date.Format(chronicleDateLayout),
+		Title: entry.Title,
+		Body:  entry.Body,
+	}
+	for _, character := range entry.Characters {
+		form.CharacterIds = append(form.CharacterIds, character.ID)
+	}
+	for _, handout := range entry.Handouts {
+		form.HandoutIds = append(form.HandoutIds, handout.ID)
+	}
+	form.NoteIds = entry.Notes.ID
+
+	app.renderChronicleForm(w, r, http.StatusOK, campaign, entry, form)
+}
+
+func (app *application) editChronicleEntryPost(w http.ResponseWriter, r *http.Request) {
+	campaign, ok := app.loadCampaign(w, r)
+	if !ok {
+		return
+	}
+	entry, ok := app.loadChronicleEntry(w, r, campaign.ID)
+	if !ok {
+		return
+	}
+
+	var form chronicleForm
+	err := app.decodePostForm(r, &form)
+	if err != nil {
+		app.clientError(w, http.StatusBadRequest)
+		return
+	}
+
+	if !app.validChronicleForm(&form, &entry) {
+		app.renderChronicleForm(w, r, http.StatusUnprocessableEntity, campaign, entry, form)
+		return
+	}
+
+	err = app.chronicle.Update(entry, form.CharacterIds, form.HandoutIds, form.NoteIds)
+	if err != nil {
+		if errors.Is(err, models.ErrNoRecord) {
+			http.NotFound(w, r)
+			return
+		}
+		app.serverError(w, r, err)
+		return
+	}
+
+	redirect := fmt.Sprintf("/campaigns/%d/chronicle/%d", campaign.ID, entry.ID)
+	http.Redirect(w, r, redirect, http.StatusSeeOther)
+}
+
+func (app *application) deleteChronicleEntryPost(w http.ResponseWriter, r *http.Request) {
+	campaignId, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+	entryId, err := strconv.Atoi(r.PathValue("entryId"))
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	err = app.chronicle.Delete(campaignId, entryId)
+	if err != nil {
+		app.serverError(w, r, err)
+		return
+	}
+
+	app.sessionManager.Put(r.Context(), "flash", "Eintrag erfolgreich gelöscht!")
+	redirect := fmt.Sprintf("/campaigns/%d/chronicle", campaignId)
+	http.Redirect(w, r, redirect, http.StatusSeeOther)
+}
+
+// checks the form and copies its contents into the entry
+func (app *application) validChronicleForm(form *chronicleForm, entry *core.ChronicleEntry) bool {
+	date, err := time.Parse(chronicleDateLayout, form.Date)
+	form.CheckField(err == nil, "Date", "Bitte ein gültiges Datum angeben.")
+	form.CheckField(validators.NotBlank(form.Title), "Title", "Dieses Feld kann nicht leer sein.")
+	form.CheckField(validators.MaxChars(form.Title, 100), "Title", "Maximal 100 Zeichen erlaubt.")
+	form.CheckField(validators.NotBlank(form.Body), "Body", "Dieses Feld kann nicht leer sein.")
+
+	entry.Date = date
+	entry.Title = form.Title
+	entry.Body = form.Body
+	return form.Valid()
+}
+
+func (app *application) renderChronicleForm(w http.ResponseWriter, r *http.Request, status int, campaign core.Campaign, entry core.ChronicleEntry, form chronicleForm) {
+	characters, err := app.characters.GetAllInCampaign(campaign.ID)
+	if err != nil {
+		app.serverError(w, r, err)
+		return
+	}
+	handouts, err := app.campaigns.GetHandouts(campaign.ID)
+	if err != nil {
+		app.serverError(w, r, err)
+		return
+	}
+	campaign.Handouts = handouts
+
+	data := app.newTemplateData(r)
+	data.Campaign = campaign
+	data.Characters = characters
+	data.ChronicleEntry = entry
+	data.Form = form
+	w.WriteHeader(status)
+	app.render(w, r, "chronicleForm.tmpl.html", data)
+}
+
+func (app *application) loadCampaign(w http.ResponseWriter, r *http.Request) (core.Campaign, bool) {
+	campaignId, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		http.NotFound(w, r)
+		return core.Campaign{}, false
+	}
+
+	campaign, err := app.campaigns.Get(campaignId)
+	if err != nil {
+		if errors.Is(err, models.ErrNoRecord) {
+			http.NotFound(w, r)
+		} else {
+			app.serverError(w, r, err)
+		}
+		return core.Campaign{}, false
+	}
+	return campaign, true
+}
+
+// entries of other campaigns are treated as nonexistent
+func (app *application) loadChronicleEntry(w http.ResponseWriter, r *http.Request, campaignId int) (core.ChronicleEntry, bool) {
+	entryId, err := strconv.Atoi(r.PathValue("entryId"))
+	if err != nil {
+		http.NotFound(w, r)
+		return core.ChronicleEntry{}, false
+	}
+
+	entry, err := app.chronicle.Get(entryId)
+	if err != nil {
+		if errors.Is(err, models.ErrNoRecord) {
+			http.NotFound(w, r)
+		} else {
+			app.serverError(w, r, err)
+		}
+		return core.ChronicleEntry{}, false
+	}
+	if entry.CampaignID != campaignId {
+		http.NotFound(w, r)
+		return core.ChronicleEntry{}, false
+	}
+	return entry, true
+}
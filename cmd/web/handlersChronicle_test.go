@@ -0,0 +1,162 @@
+package main
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/winik100/NoPenNoPaper/internal/models/mocks"
+	"github.com/winik100/NoPenNoPaper/internal/testHelpers"
+)
+
+func TestChronicleEntry(t *testing.T) {
+	app := newTestApplication(t)
+
+	tests := []struct {
+		name                  string
+		path                  string
+		authenticatedUserId   int
+		authenticatedUserName string
+		wantCode              int
+		wantContent           []string
+	}{
+		{
+			name:                  "As GM",
+			path:                  "/campaigns/1/chronicle/1",
+			authenticatedUserId:   mocks.MockGM.ID,
+			authenticatedUserName: mocks.MockGM.Name,
+			wantCode:              http.StatusOK,
+			wantContent: []string{
+				"<h2>Die Schlacht am Gottesauge</h2>",
+				"<p>Die Drachen <strong>kämpfen</strong> über dem See.</p>",
+				"<a href='/characters/1'>Otto Hightower</a>",
+				"<summary>Karte von Westeros</summary>",
+				"<a href='/campaigns/1/chronicle/1/edit'>bearbeiten</a>",
+			},
+		},
+		{
+			name:                  "As Player",
+			path:                  "/campaigns/1/chronicle/1",
+			authenticatedUserId:   mocks.MockPlayer.ID,
+			authenticatedUserName: mocks.MockPlayer.Name,
+			wantCode:              http.StatusOK,
+			wantContent: []string{
+				"<h2>Die Schlacht am Gottesauge</h2>",
+				"<summary>Karte von Westeros</summary>",
+			},
+		},
+		{
+			name:                  "Entry of another campaign",
+			path:                  "/campaigns/2/chronicle/1",
+			authenticatedUserId:   mocks.MockGM.ID,
+			authenticatedUserName: mocks.MockGM.Name,
+			wantCode:              http.StatusNotFound,
+		},
+		{
+			name:                  "Nonexistent Entry",
+			path:                  "/campaigns/1/chronicle/69",
+			authenticatedUserId:   mocks.MockGM.ID,
+			authenticatedUserName: mocks.MockGM.Name,
+			wantCode:              http.StatusNotFound,
+		},
+		{
+			name:                  "Chronicle",
+			path:                  "/campaigns/1/chronicle",
+			authenticatedUserId:   mocks.MockPlayer.ID,
+			authenticatedUserName: mocks.MockPlayer.Name,
+			wantCode:              http.StatusOK,
+			wantContent: []string{
+				"<td>12.07.2024</td>",
+				"<td><a href='/campaigns/1/chronicle/1'>Die Schlacht am Gottesauge</a></td>",
+			},
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			ts := newTestServer(t, app.sessionManager.LoadAndSave(app.mockSession(noSurf(app.authenticate(app.requireAuthentication(app.routesNoMW()))),
+				map[string]any{
+					authenticatedUserIdKey:   testCase.authenticatedUserId,
+					authenticatedUserNameKey: testCase.authenticatedUserName,
+				})))
+			defer ts.Close()
+
+			code, _, body := ts.get(t, testCase.path)
+
+			testHelpers.Equal(t, code, testCase.wantCode)
+			for _, tag := range testCase.wantContent {
+				testHelpers.StringContains(t, body, tag)
+			}
+		})
+	}
+}
+
+func TestCreateChronicleEntryPost(t *testing.T) {
+	app := newTestApplication(t)
+
+	ts := newTestServer(t, app.sessionManager.LoadAndSave(app.mockSession(noSurf(app.authenticate(app.requireAuthentication(app.routesNoMW()))),
+		map[string]any{
+			authenticatedUserIdKey:   mocks.MockGM.ID,
+			authenticatedUserNameKey: mocks.MockGM.Name,
+		})))
+	defer ts.Close()
+
+	_, _, body := ts.get(t, "/campaigns/1/chronicle/create")
+	testHelpers.StringContains(t, body, "<input type='checkbox' name='CharacterIds' value='1'> Otto Hightower")
+	validCSRF := extractCSRFToken(t, body)
+
+	tests := []struct {
+		name         string
+		date         string
+		title        string
+		body         string
+		wantCode     int
+		wantLocation string
+		wantContent  string
+	}{
+		{
+			name:         "Valid Entry",
+			date:         "2024-07-12",
+			title:        "Die Schlacht am Gottesauge",
+			body:         "Die Drachen kämpfen.",
+			wantCode:     http.StatusSeeOther,
+			wantLocation: "/campaigns/1/chronicle/2",
+		},
+		{
+			name:        "Invalid Date",
+			date:        "12.07.2024",
+			title:       "Die Schlacht am Gottesauge",
+			body:        "Die Drachen kämpfen.",
+			wantCode:    http.StatusUnprocessableEntity,
+			wantContent: "<label class='error'>Bitte ein gültiges Datum angeben.</label>",
+		},
+		{
+			name:        "Empty Body",
+			date:        "2024-07-12",
+			title:       "Die Schlacht am Gottesauge",
+			body:        "",
+			wantCode:    http.StatusUnprocessableEntity,
+			wantContent: "<label class='error'>Dieses Feld kann nicht leer sein.</label>",
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			form := url.Values{}
+			form.Add("Date", testCase.date)
+			form.Add("Title", testCase.title)
+			form.Add("Body", testCase.body)
+			form.Add("CharacterIds", "1")
+			form.Add("csrf_token", validCSRF)
+
+			code, header, body := ts.postForm(t, "/campaigns/1/chronicle/create", form)
+
+			testHelpers.Equal(t, code, testCase.wantCode)
+			testHelpers.Equal(t, header.Get("Location"), testCase.wantLocation)
+			if testCase.wantContent != "" {
+				testHelpers.StringContains(t, body, testCase.wantContent)
+				testHelpers.StringContains(t, body, "<input type='checkbox' name='CharacterIds' value='1' checked> Otto Hightower")
+			}
+		})
+	}
+}
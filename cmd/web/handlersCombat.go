@@ -0,0 +1,236 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+
+	"github.com/winik100/NoPenNoPaper/internal/core"
+	"github.com/winik100/NoPenNoPaper/internal/models"
+)
+
+type startCombatForm struct {
+	CharacterIds []int
+	Readied      []int
+}
+
+type combatantForm struct {
+	CharacterId int
+	Readied     bool
+}
+
+type damageForm struct {
+	Damage int
+}
+
+func (app *application) combat(w http.ResponseWriter, r *http.Request) {
+	campaign, ok := app.loadCampaign(w, r)
+	if !ok {
+		return
+	}
+
+	combat, err := app.combats.Get(campaign.ID)
+	if err != nil && !errors.Is(err, models.ErrNoRecord) {
+		app.serverError(w, r, err)
+		return
+	}
+
+	data := app.newTemplateData(r)
+	if campaign.IsRunBy(data.User.ID) && errors.Is(err, models.ErrNoRecord) {
+		// candidates for a new combat
+		data.Characters, err = app.campaignCharacters(campaign.ID)
+		if err != nil {
+			app.serverError(w, r, err)
+			return
+		}
+	}
+
+	data.Campaign = campaign
+	data.Combat = combat
+	w.WriteHeader(http.StatusOK)
+	app.render(w, r, "combat.tmpl.html", data)
+}
+
+func (app *application) startCombatPost(w http.ResponseWriter, r *http.Request) {
+	campaign, ok := app.loadCampaign(w, r)
+	if !ok {
+		return
+	}
+
+	var form startCombatForm
+	err := app.decodePostForm(r, &form)
+	if err != nil {
+		app.clientError(w, http.StatusBadRequest)
+		return
+	}
+
+	redirect := fmt.Sprintf("/campaigns/%d/combat", campaign.ID)
+	if len(form.CharacterIds) == 0 {
+		app.sessionManager.Put(r.Context(), "flash", "Bitte mindestens einen Teilnehmer auswählen.")
+		http.Redirect(w, r, redirect, http.StatusSeeOther)
+		return
+	}
+
+	var combatants []core.Combatant
+	for _, characterId := range form.CharacterIds {
+		combatants = append(combatants, core.Combatant{
+			CharacterID:    characterId,
+			ReadiedFirearm: slices.Contains(form.Readied, characterId),
+			TieBreak:       core.RollTieBreak(),
+		})
+	}
+
+	err = app.combats.Start(campaign.ID, combatants)
+	if err != nil {
+		app.serverError(w, r, err)
+		return
+	}
+
+	app.events.Publish(campaignTopic(campaign.ID), eventCombat)
+	http.Redirect(w, r, redirect, http.StatusSeeOther)
+}
+
+func (app *application) readyFirearmPost(w http.ResponseWriter, r *http.Request) {
+	combat, ok := app.loadCombat(w, r)
+	if !ok {
+		return
+	}
+
+	var form combatantForm
+	err := app.decodePostForm(r, &form)
+	if err != nil {
+		app.clientError(w, http.StatusBadRequest)
+		return
+	}
+
+	err = app.combats.SetReadied(combat.CampaignID, form.CharacterId, form.Readied)
+	if err != nil {
+		app.serverError(w, r, err)
+		return
+	}
+
+	app.events.Publish(campaignTopic(combat.CampaignID), eventCombat)
+	http.Redirect(w, r, fmt.Sprintf("/campaigns/%d/combat", combat.CampaignID), http.StatusSeeOther)
+}
+
+func (app *application) nextTurnPost(w http.ResponseWriter, r *http.Request) {
+	combat, ok := app.loadCombat(w, r)
+	if !ok {
+		return
+	}
+
+	combat.Advance()
+	err := app.combats.SaveProgress(combat)
+	if err != nil {
+		app.serverError(w, r, err)
+		return
+	}
+
+	app.events.Publish(campaignTopic(combat.CampaignID), eventCombat)
+	http.Redirect(w, r, fmt.Sprintf("/campaigns/%d/combat", combat.CampaignID), http.StatusSeeOther)
+}
+
+func (app *application) combatTargetPost(w http.ResponseWriter, r *http.Request) {
+	combat, ok := app.loadCombat(w, r)
+	if !ok {
+		return
+	}
+
+	var form combatantForm
+	err := app.decodePostForm(r, &form)
+	if err != nil {
+		app.clientError(w, http.StatusBadRequest)
+		return
+	}
+
+	redirect := fmt.Sprintf("/campaigns/%d/combat", combat.CampaignID)
+	if !combat.HasParticipant(form.CharacterId) {
+		app.sessionManager.Put(r.Context(), "flash", "Dieser Charakter nimmt nicht am Kampf teil.")
+		http.Redirect(w, r, redirect, http.StatusSeeOther)
+		return
+	}
+
+	combat.TargetID = form.CharacterId
+	err = app.combats.SaveProgress(combat)
+	if err != nil {
+		app.serverError(w, r, err)
+		return
+	}
+
+	app.events.Publish(campaignTopic(combat.CampaignID), eventCombat)
+	http.Redirect(w, r, redirect, http.StatusSeeOther)
+}
+
+func (app *application) combatDamagePost(w http.ResponseWriter, r *http.Request) {
+	combat, ok := app.loadCombat(w, r)
+	if !ok {
+		return
+	}
+
+	var form damageForm
+	err := app.decodePostForm(r, &form)
+	if err != nil {
+		app.clientError(w, http.StatusBadRequest)
+		return
+	}
+
+	redirect := fmt.Sprintf("/campaigns/%d/combat", combat.CampaignID)
+	target, ok := combat.Target()
+	if !ok {
+		app.sessionManager.Put(r.Context(), "flash", "Bitte zuerst ein Ziel auswählen.")
+		http.Redirect(w, r, redirect, http.StatusSeeOther)
+		return
+	}
+	if form.Damage < 1 {
+		app.sessionManager.Put(r.Context(), "flash", "Schaden muss mindestens 1 betragen.")
+		http.Redirect(w, r, redirect, http.StatusSeeOther)
+		return
+	}
+
+	damage, err := app.characters.ApplyDamage(target.CharacterID, form.Damage)
+	if err != nil {
+		app.serverError(w, r, err)
+		return
+	}
+
+	app.events.Publish(characterTopic(target.CharacterID), eventStats)
+	app.events.Publish(campaignTopic(combat.CampaignID), eventStats)
+	app.sessionManager.Put(r.Context(), "flash", damageMessage(target.Name, damage))
+	http.Redirect(w, r, redirect, http.StatusSeeOther)
+}
+
+func (app *application) endCombatPost(w http.ResponseWriter, r *http.Request) {
+	campaign, ok := app.loadCampaign(w, r)
+	if !ok {
+		return
+	}
+
+	err := app.combats.End(campaign.ID)
+	if err != nil {
+		app.serverError(w, r, err)
+		return
+	}
+
+	app.events.Publish(campaignTopic(campaign.ID), eventCombat)
+	app.sessionManager.Put(r.Context(), "flash", "Kampf beendet.")
+	http.Redirect(w, r, fmt.Sprintf("/campaigns/%d", campaign.ID), http.StatusSeeOther)
+}
+
+func (app *application) loadCombat(w http.ResponseWriter, r *http.Request) (core.Combat, bool) {
+	campaign, ok := app.loadCampaign(w, r)
+	if !ok {
+		return core.Combat{}, false
+	}
+
+	combat, err := app.combats.Get(campaign.ID)
+	if err != nil {
+		if errors.Is(err, models.ErrNoRecord) {
+			http.NotFound(w, r)
+		} else {
+			app.serverError(w, r, err)
+		}
+		return core.Combat{}, false
+	}
+	return combat, true
+}
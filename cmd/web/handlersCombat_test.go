@@ -0,0 +1,131 @@
+package main
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/winik100/NoPenNoPaper/internal/models/mocks"
+	"github.com/winik100/NoPenNoPaper/internal/testHelpers"
+)
+
+func TestCombat(t *testing.T) {
+	app := newTestApplication(t)
+
+	tests := []struct {
+		name                  string
+		path                  string
+		authenticatedUserId   int
+		authenticatedUserName string
+		wantCode              int
+		wantContent           []string
+	}{
+		{
+			name:                  "As GM",
+			path:                  "/campaigns/1/combat",
+			authenticatedUserId:   mocks.MockGM.ID,
+			authenticatedUserName: mocks.MockGM.Name,
+			wantCode:              http.StatusOK,
+			wantContent: []string{
+				"<h3>Runde 2</h3>",
+				"<tr class='current'>",
+				"<label>Schaden an Otto Hightower:</label>",
+				"(hat sich verteidigt)",
+				"<button type=\"submit\">nächster Zug</button>",
+			},
+		},
+		{
+			name:                  "As Player",
+			path:                  "/campaigns/1/combat",
+			authenticatedUserId:   mocks.MockPlayer.ID,
+			authenticatedUserName: mocks.MockPlayer.Name,
+			wantCode:              http.StatusOK,
+			wantContent: []string{
+				"<h3>Runde 2</h3>",
+				"Larys Strong (NSC)",
+				"<a href='/characters/1'>Otto Hightower</a>",
+			},
+		},
+		{
+			name:                  "Nonexistent Campaign",
+			path:                  "/campaigns/69/combat",
+			authenticatedUserId:   mocks.MockGM.ID,
+			authenticatedUserName: mocks.MockGM.Name,
+			wantCode:              http.StatusNotFound,
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			ts := newTestServer(t, app.sessionManager.LoadAndSave(app.mockSession(noSurf(app.authenticate(app.requireAuthentication(app.routesNoMW()))),
+				map[string]any{
+					authenticatedUserIdKey:   testCase.authenticatedUserId,
+					authenticatedUserNameKey: testCase.authenticatedUserName,
+				})))
+			defer ts.Close()
+
+			code, _, body := ts.get(t, testCase.path)
+
+			testHelpers.Equal(t, code, testCase.wantCode)
+			for _, tag := range testCase.wantContent {
+				testHelpers.StringContains(t, body, tag)
+			}
+		})
+	}
+}
+
+func TestCombatDamagePost(t *testing.T) {
+	app := newTestApplication(t)
+
+	ts := newTestServer(t, app.sessionManager.LoadAndSave(app.mockSession(noSurf(app.authenticate(app.requireAuthentication(app.routesNoMW()))),
+		map[string]any{
+			authenticatedUserIdKey:   mocks.MockGM.ID,
+			authenticatedUserNameKey: mocks.MockGM.Name,
+		})))
+	defer ts.Close()
+
+	_, _, body := ts.get(t, "/campaigns/1/combat")
+	validCSRF := extractCSRFToken(t, body)
+
+	tests := []struct {
+		name      string
+		damage    string
+		wantCode  int
+		wantFlash string
+	}{
+		{
+			name:      "Valid Damage",
+			damage:    "4",
+			wantCode:  http.StatusSeeOther,
+			wantFlash: "Otto Hightower erleidet 4 Schaden",
+		},
+		{
+			name:      "No Damage",
+			damage:    "0",
+			wantCode:  http.StatusSeeOther,
+			wantFlash: "Schaden muss mindestens 1 betragen.",
+		},
+		{
+			name:     "Invalid Damage",
+			damage:   "viel",
+			wantCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			form := url.Values{}
+			form.Add("Damage", testCase.damage)
+			form.Add("csrf_token", validCSRF)
+
+			code, header, _ := ts.postForm(t, "/campaigns/1/combat/damage", form)
+
+			testHelpers.Equal(t, code, testCase.wantCode)
+			if testCase.wantFlash != "" {
+				testHelpers.Equal(t, header.Get("Location"), "/campaigns/1/combat")
+				_, _, body := ts.get(t, "/campaigns/1/combat")
+				testHelpers.StringContains(t, body, testCase.wantFlash)
+			}
+		})
+	}
+}
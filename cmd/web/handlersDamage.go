@@ -0,0 +1,64 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/winik100/NoPenNoPaper/internal/core"
+)
+
+func (app *application) damagePost(w http.ResponseWriter, r *http.Request) {
+	character, ok := app.loadCharacter(w, r)
+	if !ok {
+		return
+	}
+
+	var form damageForm
+	err := app.decodePostForm(r, &form)
+	if err != nil {
+		app.clientError(w, http.StatusBadRequest)
+		return
+	}
+
+	redirect := fmt.Sprintf("/characters/%d", character.ID)
+	if form.Damage < 1 {
+		app.sessionManager.Put(r.Context(), "flash", "Schaden muss mindestens 1 betragen.")
+		http.Redirect(w, r, redirect, http.StatusSeeOther)
+		return
+	}
+
+	damage, err := app.characters.ApplyDamage(character.ID, form.Damage)
+	if err != nil {
+		app.serverError(w, r, err)
+		return
+	}
+
+	app.publishCharacter(character, eventStats)
+	app.sessionManager.Put(r.Context(), "flash", damageMessage(character.Info.Name, damage))
+	http.Redirect(w, r, redirect, http.StatusSeeOther)
+}
+
+func (app *application) treatWoundsPost(w http.ResponseWriter, r *http.Request) {
+	character, ok := app.loadCharacter(w, r)
+	if !ok {
+		return
+	}
+
+	err := app.characters.TreatWounds(character.ID)
+	if err != nil {
+		app.serverError(w, r, err)
+		return
+	}
+
+	app.publishCharacter(character, eventStats)
+	app.sessionManager.Put(r.Context(), "flash", fmt.Sprintf("Die Wunden von %s wurden versorgt.", character.Info.Name))
+	http.Redirect(w, r, fmt.Sprintf("/characters/%d", character.ID), http.StatusSeeOther)
+}
+
+func damageMessage(name string, damage core.Damage) string {
+	message := fmt.Sprintf("%s erleidet %d Schaden und hat noch %d TP.", name, damage.Amount, damage.TP)
+	if consequences := damage.Consequences(); consequences != "" {
+		message += " " + consequences + "!"
+	}
+	return message
+}
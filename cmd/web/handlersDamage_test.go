@@ -0,0 +1,115 @@
+package main
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/winik100/NoPenNoPaper/internal/models/mocks"
+	"github.com/winik100/NoPenNoPaper/internal/testHelpers"
+)
+
+func TestDamagePost(t *testing.T) {
+	app := newTestApplication(t)
+
+	ts := newTestServer(t, app.sessionManager.LoadAndSave(app.mockSession(noSurf(app.authenticate(app.requireAuthentication(app.routesNoMW()))),
+		map[string]any{
+			authenticatedUserIdKey:   mocks.MockPlayer.ID,
+			authenticatedUserNameKey: mocks.MockPlayer.Name,
+		})))
+	defer ts.Close()
+
+	_, _, body := ts.get(t, "/characters/1")
+	testHelpers.StringContains(t, body, "Schaden erleiden")
+	validCSRF := extractCSRFToken(t, body)
+
+	tests := []struct {
+		name      string
+		path      string
+		damage    string
+		wantCode  int
+		wantFlash string
+	}{
+		{
+			name:      "Minor Wound",
+			path:      "/characters/1/damage",
+			damage:    "3",
+			wantCode:  http.StatusSeeOther,
+			wantFlash: "Otto Hightower erleidet 3 Schaden und hat noch 8 TP.",
+		},
+		{
+			name:      "Major Wound",
+			path:      "/characters/1/damage",
+			damage:    "6",
+			wantCode:  http.StatusSeeOther,
+			wantFlash: "hat noch 5 TP. Schwere Wunde!",
+		},
+		{
+			name:      "Dying",
+			path:      "/characters/1/damage",
+			damage:    "11",
+			wantCode:  http.StatusSeeOther,
+			wantFlash: "hat noch 0 TP. Schwere Wunde, Sterbend!",
+		},
+		{
+			name:      "Instant Death",
+			path:      "/characters/1/damage",
+			damage:    "12",
+			wantCode:  http.StatusSeeOther,
+			wantFlash: "Schwere Wunde, Tot!",
+		},
+		{
+			name:      "No Damage",
+			path:      "/characters/1/damage",
+			damage:    "0",
+			wantCode:  http.StatusSeeOther,
+			wantFlash: "Schaden muss mindestens 1 betragen.",
+		},
+		{
+			name:     "Nonexistent Character",
+			path:     "/characters/69/damage",
+			damage:   "3",
+			wantCode: http.StatusNotFound,
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			form := url.Values{}
+			form.Add("Damage", testCase.damage)
+			form.Add("csrf_token", validCSRF)
+
+			code, _, _ := ts.postForm(t, testCase.path, form)
+
+			testHelpers.Equal(t, code, testCase.wantCode)
+			if testCase.wantFlash != "" {
+				_, _, body := ts.get(t, "/characters/1")
+				testHelpers.StringContains(t, body, testCase.wantFlash)
+			}
+		})
+	}
+}
+
+func TestTreatWoundsPost(t *testing.T) {
+	app := newTestApplication(t)
+
+	ts := newTestServer(t, app.sessionManager.LoadAndSave(app.mockSession(noSurf(app.authenticate(app.requireAuthentication(app.routesNoMW()))),
+		map[string]any{
+			authenticatedUserIdKey:   mocks.MockPlayer.ID,
+			authenticatedUserNameKey: mocks.MockPlayer.Name,
+		})))
+	defer ts.Close()
+
+	_, _, body := ts.get(t, "/characters/1")
+	validCSRF := extractCSRFToken(t, body)
+
+	form := url.Values{}
+	form.Add("csrf_token", validCSRF)
+	code, header, _ := ts.postForm(t, "/characters/1/treatWounds", form)
+
+	testHelpers.Equal(t, code, http.StatusSeeOther)
+	testHelpers.Equal(t, header.Get("Location"), "/characters/1")
+
+	_, _, body = ts.get(t, "/characters/1")
+	testHelpers.StringContains(t, body, "Die Wunden von Otto Hightower wurden versorgt.")
+}
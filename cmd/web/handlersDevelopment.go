@@ -0,0 +1,85 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/winik100/NoPenNoPaper/internal/models"
+)
+
+type skillTickForm struct {
+	Skill string
+	Used  bool
+}
+
+func (app *application) tickSkillPost(w http.ResponseWriter, r *http.Request) {
+	characterId, ok := app.characterIdFromPath(w, r)
+	if !ok {
+		return
+	}
+
+	var form skillTickForm
+	err := app.decodePostForm(r, &form)
+	if err != nil {
+		app.clientError(w, http.StatusBadRequest)
+		return
+	}
+
+	err = app.characters.SetSkillTick(characterId, form.Skill, form.Used)
+	if err != nil {
+		if errors.Is(err, models.ErrNoRecord) {
+			app.clientError(w, http.StatusUnprocessableEntity)
+			return
+		}
+		app.serverError(w, r, err)
+		return
+	}
+
+	app.events.Publish(characterTopic(characterId), eventSkills)
+
+	tmplStr := `<form hx-post="/characters/{{.Form.CharacterId}}/tickSkill" hx-target="this" hx-swap="outerHTML">
+					<input type="hidden" name="csrf_token" value="{{.CSRFToken}}">
+					<input type="hidden" name="Skill" value="{{.Form.Skill}}">
+					<button type="submit" name="Used" value="{{not .Form.Used}}" title="zur Steigerung markieren">{{if .Form.Used}}&#9745;{{else}}&#9744;{{end}}</button>
+				</form>`
+
+	data := app.newTemplateData(r)
+	data.Form = map[string]any{
+		"CharacterId": characterId,
+		"Skill":       form.Skill,
+		"Used":        form.Used,
+	}
+	w.WriteHeader(http.StatusOK)
+	app.renderHtmx(w, r, "tickSkill", tmplStr, data)
+}
+
+// development phase: every ticked skill gets its improvement roll, afterwards all ticks are cleared
+func (app *application) developPost(w http.ResponseWriter, r *http.Request) {
+	character, ok := app.loadCharacter(w, r)
+	if !ok {
+		return
+	}
+
+	improvements := character.Develop()
+	if len(improvements) == 0 {
+		app.sessionManager.Put(r.Context(), "flash", "Es sind keine Fertigkeiten zur Steigerung markiert.")
+		http.Redirect(w, r, fmt.Sprintf("/characters/%d", character.ID), http.StatusSeeOther)
+		return
+	}
+
+	err := app.characters.Develop(character.ID, improvements)
+	if err != nil {
+		app.serverError(w, r, err)
+		return
+	}
+
+	app.events.Publish(characterTopic(character.ID), eventSkills)
+	app.publishCharacter(character, eventStats)
+
+	data := app.newTemplateData(r)
+	data.Character = character
+	data.AdditionalData = improvements
+	w.WriteHeader(http.StatusOK)
+	app.render(w, r, "development.tmpl.html", data)
+}
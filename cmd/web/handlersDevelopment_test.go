@@ -0,0 +1,128 @@
+package main
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/winik100/NoPenNoPaper/internal/models/mocks"
+	"github.com/winik100/NoPenNoPaper/internal/testHelpers"
+)
+
+func TestTickSkillPost(t *testing.T) {
+	app := newTestApplication(t)
+
+	ts := newTestServer(t, app.sessionManager.LoadAndSave(app.mockSession(noSurf(app.authenticate(app.requireAuthentication(app.routesNoMW()))),
+		map[string]any{
+			authenticatedUserIdKey:   mocks.MockPlayer.ID,
+			authenticatedUserNameKey: mocks.MockPlayer.Name,
+		})))
+	defer ts.Close()
+
+	_, _, body := ts.get(t, "/characters/1")
+	validCSRF := extractCSRFToken(t, body)
+
+	tests := []struct {
+		name        string
+		skill       string
+		used        string
+		wantCode    int
+		wantContent string
+	}{
+		{
+			name:        "Tick",
+			skill:       "Politik",
+			used:        "true",
+			wantCode:    http.StatusOK,
+			wantContent: "&#9745;",
+		},
+		{
+			name:        "Untick",
+			skill:       "Intrige",
+			used:        "false",
+			wantCode:    http.StatusOK,
+			wantContent: "&#9744;",
+		},
+		{
+			name:        "Custom Skill",
+			skill:       "Westerosi",
+			used:        "true",
+			wantCode:    http.StatusOK,
+			wantContent: "&#9745;",
+		},
+		{
+			name:     "Unknown Skill",
+			skill:    "Drachenreiten",
+			used:     "true",
+			wantCode: http.StatusUnprocessableEntity,
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			form := url.Values{}
+			form.Add("Skill", testCase.skill)
+			form.Add("Used", testCase.used)
+			form.Add("csrf_token", validCSRF)
+
+			code, _, body := ts.postForm(t, "/characters/1/tickSkill", form)
+
+			testHelpers.Equal(t, code, testCase.wantCode)
+			if testCase.wantContent != "" {
+				testHelpers.StringContains(t, body, testCase.wantContent)
+			}
+		})
+	}
+}
+
+func TestDevelopPost(t *testing.T) {
+	app := newTestApplication(t)
+
+	ts := newTestServer(t, app.sessionManager.LoadAndSave(app.mockSession(noSurf(app.authenticate(app.requireAuthentication(app.routesNoMW()))),
+		map[string]any{
+			authenticatedUserIdKey:   mocks.MockPlayer.ID,
+			authenticatedUserNameKey: mocks.MockPlayer.Name,
+		})))
+	defer ts.Close()
+
+	_, _, body := ts.get(t, "/characters/1")
+	validCSRF := extractCSRFToken(t, body)
+
+	tests := []struct {
+		name        string
+		path        string
+		wantCode    int
+		wantContent string
+	}{
+		{
+			name:        "Ticked Skills",
+			path:        "/characters/1/develop",
+			wantCode:    http.StatusOK,
+			wantContent: "Intrige",
+		},
+		{
+			name:     "No Ticked Skills",
+			path:     "/characters/2/develop",
+			wantCode: http.StatusSeeOther,
+		},
+		{
+			name:     "Nonexistent Character",
+			path:     "/characters/69/develop",
+			wantCode: http.StatusNotFound,
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			form := url.Values{}
+			form.Add("csrf_token", validCSRF)
+
+			code, _, body := ts.postForm(t, testCase.path, form)
+
+			testHelpers.Equal(t, code, testCase.wantCode)
+			if testCase.wantContent != "" {
+				testHelpers.StringContains(t, body, testCase.wantContent)
+			}
+		})
+	}
+}
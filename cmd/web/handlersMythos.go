@@ -0,0 +1,197 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/winik100/NoPenNoPaper/internal/core"
+	"github.com/winik100/NoPenNoPaper/internal/models"
+)
+
+type spellForm struct {
+	SpellId int
+}
+
+type tomeForm struct {
+	TomeId int
+}
+
+func (app *application) learnSpellPost(w http.ResponseWriter, r *http.Request) {
+	character, ok := app.loadCharacter(w, r)
+	if !ok {
+		return
+	}
+
+	var form spellForm
+	err := app.decodePostForm(r, &form)
+	if err != nil {
+		app.clientError(w, http.StatusBadRequest)
+		return
+	}
+
+	redirect := fmt.Sprintf("/characters/%d", character.ID)
+	spell, err := app.spells.Get(form.SpellId)
+	if err != nil {
+		if errors.Is(err, models.ErrNoRecord) {
+			app.sessionManager.Put(r.Context(), "flash", "Unbekannter Zauber.")
+			http.Redirect(w, r, redirect, http.StatusSeeOther)
+		} else {
+			app.serverError(w, r, err)
+		}
+		return
+	}
+
+	err = app.spells.Learn(character.ID, spell.ID)
+	if err != nil {
+		if errors.Is(err, models.ErrAlreadyKnown) {
+			app.sessionManager.Put(r.Context(), "flash", fmt.Sprintf("%s kennt %s bereits.", character.Info.Name, spell.Name))
+			http.Redirect(w, r, redirect, http.StatusSeeOther)
+		} else {
+			app.serverError(w, r, err)
+		}
+		return
+	}
+
+	app.events.Publish(characterTopic(character.ID), eventMythos)
+	app.sessionManager.Put(r.Context(), "flash", fmt.Sprintf("%s lernt %s.", character.Info.Name, spell.Name))
+	http.Redirect(w, r, redirect, http.StatusSeeOther)
+}
+
+// deducts the magic points and the rolled sanity cost of a known spell
+func (app *application) castSpellPost(w http.ResponseWriter, r *http.Request) {
+	character, ok := app.loadCharacter(w, r)
+	if !ok {
+		return
+	}
+
+	var form spellForm
+	err := app.decodePostForm(r, &form)
+	if err != nil {
+		app.clientError(w, http.StatusBadRequest)
+		return
+	}
+
+	spell, ok := character.KnownSpell(form.SpellId)
+	if !ok {
+		app.clientError(w, http.StatusUnprocessableEntity)
+		return
+	}
+
+	redirect := fmt.Sprintf("/characters/%d", character.ID)
+	casting := character.CastSpell(spell)
+	err = app.spells.Cast(casting)
+	if err != nil {
+		if errors.Is(err, models.ErrNotEnoughMagic) {
+			app.sessionManager.Put(r.Context(), "flash", fmt.Sprintf("%s hat nicht genug Magiepunkte für %s (%d benötigt).",
+				character.Info.Name, spell.Name, spell.MPCost))
+			http.Redirect(w, r, redirect, http.StatusSeeOther)
+		} else {
+			app.serverError(w, r, err)
+		}
+		return
+	}
+
+	app.publishCharacter(character, eventStats)
+	message := fmt.Sprintf("%s wirkt %s: %d Magiepunkte und %d Stabilität (%s) verloren.",
+		character.Info.Name, spell.Name, spell.MPCost, casting.Sanity.Lost, spell.SANCost)
+	app.sessionManager.Put(r.Context(), "flash", message+insanityMessage(casting.Sanity))
+	http.Redirect(w, r, redirect, http.StatusSeeOther)
+}
+
+func (app *application) acquireTomePost(w http.ResponseWriter, r *http.Request) {
+	character, ok := app.loadCharacter(w, r)
+	if !ok {
+		return
+	}
+
+	var form tomeForm
+	err := app.decodePostForm(r, &form)
+	if err != nil {
+		app.clientError(w, http.StatusBadRequest)
+		return
+	}
+
+	redirect := fmt.Sprintf("/characters/%d", character.ID)
+	tome, err := app.tomes.Get(form.TomeId)
+	if err != nil {
+		if errors.Is(err, models.ErrNoRecord) {
+			app.sessionManager.Put(r.Context(), "flash", "Unbekanntes Buch.")
+			http.Redirect(w, r, redirect, http.StatusSeeOther)
+		} else {
+			app.serverError(w, r, err)
+		}
+		return
+	}
+
+	err = app.tomes.Acquire(character.ID, tome.ID)
+	if err != nil {
+		if errors.Is(err, models.ErrAlreadyOwned) {
+			app.sessionManager.Put(r.Context(), "flash", fmt.Sprintf("%s besitzt %s bereits.", character.Info.Name, tome.Name))
+			http.Redirect(w, r, redirect, http.StatusSeeOther)
+		} else {
+			app.serverError(w, r, err)
+		}
+		return
+	}
+
+	app.events.Publish(characterTopic(character.ID), eventMythos)
+	app.sessionManager.Put(r.Context(), "flash", fmt.Sprintf("%s besitzt jetzt %s.", character.Info.Name, tome.Name))
+	http.Redirect(w, r, redirect, http.StatusSeeOther)
+}
+
+// a full study of an owned tome raises Cthulhu-Mythos and costs sanity, maximum sanity drops accordingly
+func (app *application) studyTomePost(w http.ResponseWriter, r *http.Request) {
+	character, ok := app.loadCharacter(w, r)
+	if !ok {
+		return
+	}
+
+	var form tomeForm
+	err := app.decodePostForm(r, &form)
+	if err != nil {
+		app.clientError(w, http.StatusBadRequest)
+		return
+	}
+
+	tome, ok := character.OwnedTome(form.TomeId)
+	if !ok {
+		app.clientError(w, http.StatusUnprocessableEntity)
+		return
+	}
+
+	redirect := fmt.Sprintf("/characters/%d", character.ID)
+	study := character.StudyTome(tome)
+	study.Mythos, err = app.tomes.Study(study)
+	if err != nil {
+		if errors.Is(err, models.ErrAlreadyStudied) {
+			app.sessionManager.Put(r.Context(), "flash", fmt.Sprintf("%s hat %s bereits studiert.", character.Info.Name, tome.Name))
+			http.Redirect(w, r, redirect, http.StatusSeeOther)
+		} else {
+			app.serverError(w, r, err)
+		}
+		return
+	}
+
+	app.events.Publish(characterTopic(character.ID), eventSkills)
+	app.events.Publish(characterTopic(character.ID), eventMythos)
+	app.publishCharacter(character, eventStats)
+	message := fmt.Sprintf("%s studiert %s (%d Wochen): Cthulhu-Mythos steigt auf %d, %d Stabilität (%s) verloren, maximale Stabilität %d.",
+		character.Info.Name, tome.Name, tome.StudyWeeks, study.Mythos, study.Sanity.Lost, tome.SANLoss, max(core.SanityLimit-study.Mythos, 0))
+	app.sessionManager.Put(r.Context(), "flash", message+insanityMessage(study.Sanity))
+	http.Redirect(w, r, redirect, http.StatusSeeOther)
+}
+
+func insanityMessage(cost core.SanityCost) string {
+	var message string
+	if cost.Temporary {
+		message += " Zeitweiliger Wahnsinn!"
+	}
+	if cost.Indefinite {
+		message += " Unbestimmter Wahnsinn!"
+	}
+	if cost.Bout != "" {
+		message += " Anfall: " + cost.Bout
+	}
+	return message
+}
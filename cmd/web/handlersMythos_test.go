@@ -0,0 +1,237 @@
+package main
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+	"testing"
+
+	"github.com/winik100/NoPenNoPaper/internal/models/mocks"
+	"github.com/winik100/NoPenNoPaper/internal/testHelpers"
+)
+
+func TestSpellsPost(t *testing.T) {
+	app := newTestApplication(t)
+
+	ts := newTestServer(t, app.sessionManager.LoadAndSave(app.mockSession(noSurf(app.authenticate(app.requireAuthentication(app.routesNoMW()))),
+		map[string]any{
+			authenticatedUserIdKey:   mocks.MockPlayer.ID,
+			authenticatedUserNameKey: mocks.MockPlayer.Name,
+		})))
+	defer ts.Close()
+
+	_, _, body := ts.get(t, "/characters/1")
+	validCSRF := extractCSRFToken(t, body)
+	testHelpers.StringContains(t, body, "<td>Sternenvampir herbeirufen</td>")
+
+	tests := []struct {
+		name      string
+		path      string
+		spellId   int
+		wantCode  int
+		wantFlash string
+	}{
+		{
+			name:      "Learn",
+			path:      "/characters/1/learnSpell",
+			spellId:   mocks.MockSpellElderSign.ID,
+			wantCode:  http.StatusSeeOther,
+			wantFlash: "Otto Hightower lernt Älteres Zeichen erschaffen.",
+		},
+		{
+			name:      "Already Known",
+			path:      "/characters/1/learnSpell",
+			spellId:   mocks.MockSpellDominate.ID,
+			wantCode:  http.StatusSeeOther,
+			wantFlash: "Otto Hightower kennt Dominieren bereits.",
+		},
+		{
+			name:      "Unknown Spell",
+			path:      "/characters/1/learnSpell",
+			spellId:   99,
+			wantCode:  http.StatusSeeOther,
+			wantFlash: "Unbekannter Zauber.",
+		},
+		{
+			name:      "Cast",
+			path:      "/characters/1/castSpell",
+			spellId:   mocks.MockSpellDominate.ID,
+			wantCode:  http.StatusSeeOther,
+			wantFlash: "Otto Hightower wirkt Dominieren: 1 Magiepunkte und",
+		},
+		{
+			name:      "Not Enough Magic Points",
+			path:      "/characters/1/castSpell",
+			spellId:   mocks.MockSpellStarVampire.ID,
+			wantCode:  http.StatusSeeOther,
+			wantFlash: "Otto Hightower hat nicht genug Magiepunkte für Sternenvampir herbeirufen (12 benötigt).",
+		},
+		{
+			name:     "Cast Spell Not Known",
+			path:     "/characters/1/castSpell",
+			spellId:  mocks.MockSpellElderSign.ID,
+			wantCode: http.StatusUnprocessableEntity,
+		},
+		{
+			name:     "Nonexistent Character",
+			path:     "/characters/69/castSpell",
+			spellId:  mocks.MockSpellDominate.ID,
+			wantCode: http.StatusNotFound,
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			form := url.Values{}
+			form.Add("SpellId", strconv.Itoa(testCase.spellId))
+			form.Add("csrf_token", validCSRF)
+
+			code, _, _ := ts.postForm(t, testCase.path, form)
+
+			testHelpers.Equal(t, code, testCase.wantCode)
+			if testCase.wantFlash != "" {
+				_, _, body := ts.get(t, "/characters/1")
+				testHelpers.StringContains(t, body, testCase.wantFlash)
+			}
+		})
+	}
+}
+
+func TestTomesPost(t *testing.T) {
+	app := newTestApplication(t)
+
+	ts := newTestServer(t, app.sessionManager.LoadAndSave(app.mockSession(noSurf(app.authenticate(app.requireAuthentication(app.routesNoMW()))),
+		map[string]any{
+			authenticatedUserIdKey:   mocks.MockPlayer.ID,
+			authenticatedUserNameKey: mocks.MockPlayer.Name,
+		})))
+	defer ts.Close()
+
+	_, _, body := ts.get(t, "/characters/1")
+	validCSRF := extractCSRFToken(t, body)
+	testHelpers.StringContains(t, body, "<td>Necronomicon (lateinisch)</td>")
+
+	tests := []struct {
+		name      string
+		path      string
+		tomeId    int
+		wantCode  int
+		wantFlash string
+	}{
+		{
+			name:      "Acquire",
+			path:      "/characters/1/acquireTome",
+			tomeId:    mocks.MockTomeVermis.ID,
+			wantCode:  http.StatusSeeOther,
+			wantFlash: "Otto Hightower besitzt jetzt De Vermis Mysteriis.",
+		},
+		{
+			name:      "Already Owned",
+			path:      "/characters/1/acquireTome",
+			tomeId:    mocks.MockTomeNecronomicon.ID,
+			wantCode:  http.StatusSeeOther,
+			wantFlash: "Otto Hightower besitzt Necronomicon (lateinisch) bereits.",
+		},
+		{
+			name:      "Unknown Tome",
+			path:      "/characters/1/acquireTome",
+			tomeId:    99,
+			wantCode:  http.StatusSeeOther,
+			wantFlash: "Unbekanntes Buch.",
+		},
+		{
+			name:      "Study",
+			path:      "/characters/1/studyTome",
+			tomeId:    mocks.MockTomeNecronomicon.ID,
+			wantCode:  http.StatusSeeOther,
+			wantFlash: "Otto Hightower studiert Necronomicon (lateinisch) (66 Wochen): Cthulhu-Mythos steigt auf 12,",
+		},
+		{
+			name:      "Already Studied",
+			path:      "/characters/1/studyTome",
+			tomeId:    mocks.MockTomePnakotic.ID,
+			wantCode:  http.StatusSeeOther,
+			wantFlash: "Otto Hightower hat Pnakotische Manuskripte bereits studiert.",
+		},
+		{
+			name:     "Study Tome Not Owned",
+			path:     "/characters/1/studyTome",
+			tomeId:   mocks.MockTomeVermis.ID,
+			wantCode: http.StatusUnprocessableEntity,
+		},
+		{
+			name:     "Nonexistent Character",
+			path:     "/characters/69/acquireTome",
+			tomeId:   mocks.MockTomeVermis.ID,
+			wantCode: http.StatusNotFound,
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			form := url.Values{}
+			form.Add("TomeId", strconv.Itoa(testCase.tomeId))
+			form.Add("csrf_token", validCSRF)
+
+			code, _, _ := ts.postForm(t, testCase.path, form)
+
+			testHelpers.Equal(t, code, testCase.wantCode)
+			if testCase.wantFlash != "" {
+				_, _, body := ts.get(t, "/characters/1")
+				testHelpers.StringContains(t, body, testCase.wantFlash)
+			}
+		})
+	}
+}
+
+// casting and studying change sanity, which the GM dashboard shows as well
+func TestMythosCampaignEvents(t *testing.T) {
+	app := newTestApplication(t)
+
+	ts := newTestServer(t, app.sessionManager.LoadAndSave(app.mockSession(noSurf(app.authenticate(app.requireAuthentication(app.routesNoMW()))),
+		map[string]any{
+			authenticatedUserIdKey:   mocks.MockPlayer.ID,
+			authenticatedUserNameKey: mocks.MockPlayer.Name,
+		})))
+	defer ts.Close()
+
+	_, _, body := ts.get(t, "/characters/1")
+	validCSRF := extractCSRFToken(t, body)
+
+	dashboard := app.events.Subscribe(campaignTopic(mocks.MockCharacterOtto.CampaignID))
+	defer app.events.Unsubscribe(campaignTopic(mocks.MockCharacterOtto.CampaignID), dashboard)
+
+	tests := []struct {
+		name  string
+		path  string
+		field string
+		id    int
+	}{
+		{
+			name:  "Cast",
+			path:  "/characters/1/castSpell",
+			field: "SpellId",
+			id:    mocks.MockSpellDominate.ID,
+		},
+		{
+			name:  "Study",
+			path:  "/characters/1/studyTome",
+			field: "TomeId",
+			id:    mocks.MockTomeNecronomicon.ID,
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			form := url.Values{}
+			form.Add(testCase.field, strconv.Itoa(testCase.id))
+			form.Add("csrf_token", validCSRF)
+
+			code, _, _ := ts.postForm(t, testCase.path, form)
+
+			testHelpers.Equal(t, code, http.StatusSeeOther)
+			testHelpers.Equal(t, len(dashboard), 1)
+			testHelpers.Equal(t, <-dashboard, eventStats)
+		})
+	}
+}
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/winik100/NoPenNoPaper/internal/core"
+	"github.com/winik100/NoPenNoPaper/internal/models"
+	"github.com/winik100/NoPenNoPaper/internal/validators"
+)
+
+type npcForm struct {
+	Info                     core.CharacterInfo
+	Attributes               core.CharacterAttributes
+	TrackTP                  bool
+	TrackSTA                 bool
+	CampaignId               int
+	validators.FormValidator `schema:"-"`
+}
+
+func (app *application) npcs(w http.ResponseWriter, r *http.Request) {
+	data := app.newTemplateData(r)
+	npcs, err := app.characters.GetNPCsFrom(data.User.ID)
+	if err != nil {
+		app.serverError(w, r, err)
+		return
+	}
+	campaigns, err := app.campaigns.GetAllFrom(data.User.ID)
+	if err != nil {
+		app.serverError(w, r, err)
+		return
+	}
+
+	data.Characters = npcs
+	data.Campaigns = campaigns
+	w.WriteHeader(http.StatusOK)
+	app.render(w, r, "npcs.tmpl.html", data)
+}
+
+func (app *application) createNPC(w http.ResponseWriter, r *http.Request) {
+	app.renderNPCForm(w, r, http.StatusOK, npcForm{TrackTP: true, TrackSTA: true})
+}
+
+func (app *application) createNPCPost(w http.ResponseWriter, r *http.Request) {
+	userId := app.sessionManager.GetInt(r.Context(), authenticatedUserIdKey)
+
+	var form npcForm
+	err := app.decodePostForm(r, &form)
+	if err != nil {
+		app.clientError(w, http.StatusUnprocessableEntity)
+		return
+	}
+
+	form.NPCChecks()
+	if form.CampaignId != 0 {
+		campaign, err := app.campaigns.Get(form.CampaignId)
+		if err != nil && !errors.Is(err, models.ErrNoRecord) {
+			app.serverError(w, r, err)
+			return
+		}
+		form.CheckField(err == nil && campaign.IsRunBy(userId), "CampaignId", "Ungültige Kampagne.")
+	}
+
+	if !form.Valid() {
+		app.renderNPCForm(w, r, http.StatusUnprocessableEntity, form)
+		return
+	}
+
+	characterId, err := app.characters.Insert(core.Character{
+		CampaignID: form.CampaignId,
+		Kind:       core.KindNPC,
+		TrackTP:    form.TrackTP,
+		TrackSTA:   form.TrackSTA,
+		Info:       form.Info,
+		Attributes: form.Attributes,
+	}, userId)
+	if err != nil {
+		app.serverError(w, r, err)
+		return
+	}
+
+	redirect := fmt.Sprintf("/characters/%d", characterId)
+	http.Redirect(w, r, redirect, http.StatusSeeOther)
+}
+
+func (app *application) renderNPCForm(w http.ResponseWriter, r *http.Request, status int, form npcForm) {
+	data := app.newTemplateData(r)
+	campaigns, err := app.campaigns.GetAllFrom(data.User.ID)
+	if err != nil {
+		app.serverError(w, r, err)
+		return
+	}
+
+	data.Campaigns = campaigns
+	data.Form = form
+	w.WriteHeader(status)
+	app.render(w, r, "npcCreate.tmpl.html", data)
+}
+
+// NPCs only need a name, everything else may be entered freely
+func (form *npcForm) NPCChecks() {
+	form.CheckField(validators.NotBlank(form.Info.Name), "Name", "Dieses Feld kann nicht leer sein.")
+	for key, info := range form.Info.AsMap() {
+		form.CheckField(validators.MaxChars(info, 50), key, "Maximal 50 Zeichen erlaubt.")
+	}
+	form.CheckField(validators.MaxChars(form.Info.Gender, 10), "Geschlecht", "Maximal 10 Zeichen erlaubt.")
+
+	if strings.TrimSpace(form.Info.Age) == "" {
+		form.Info.Age = "0"
+	}
+	form.CheckField(validators.IsInteger(form.Info.Age), "Alter", "Dieses Feld muss eine Zahl enthalten.")
+	form.CheckField(validators.InBetween(form.Info.Age, 0, 999), "Alter", "Alter muss zwischen 0 und 999 liegen.")
+
+	for key, attr := range form.Attributes.AsMap() {
+		if key == "BW" {
+			form.CheckField(0 <= attr && attr <= 20, key, "Wert muss zwischen 0 und 20 liegen.")
+		} else {
+			form.CheckField(0 <= attr && attr <= 200, key, "Wert muss zwischen 0 und 200 liegen.")
+		}
+	}
+}
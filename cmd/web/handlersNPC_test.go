@@ -0,0 +1,157 @@
+package main
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/winik100/NoPenNoPaper/internal/models/mocks"
+	"github.com/winik100/NoPenNoPaper/internal/testHelpers"
+)
+
+func TestNPCs(t *testing.T) {
+	app := newTestApplication(t)
+
+	tests := []struct {
+		name           string
+		path           string
+		wantCode       int
+		wantContent    []string
+		notWantContent []string
+	}{
+		{
+			name:     "NPC List",
+			path:     "/npcs",
+			wantCode: http.StatusOK,
+			wantContent: []string{
+				"<td><a href='/characters/3'>Larys Strong</a></td>",
+				"<a href='/campaigns/1'>Der Tanz der Drachen</a>",
+			},
+		},
+		{
+			name:     "NPC Sheet",
+			path:     "/characters/3",
+			wantCode: http.StatusOK,
+			wantContent: []string{
+				"<p>Nichtspielercharakter</p>",
+				"<th>Trefferpunkte",
+			},
+			notWantContent: []string{
+				"<th>Stabilität",
+			},
+		},
+		{
+			name:     "Campaign",
+			path:     "/campaigns/1",
+			wantCode: http.StatusOK,
+			wantContent: []string{
+				"<td><a href='/characters/3'>Larys Strong</a></td>",
+			},
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			ts := newTestServer(t, app.sessionManager.LoadAndSave(app.mockSession(noSurf(app.authenticate(app.requireAuthentication(app.routesNoMW()))),
+				map[string]any{
+					authenticatedUserIdKey:   mocks.MockGM.ID,
+					authenticatedUserNameKey: mocks.MockGM.Name,
+				})))
+			defer ts.Close()
+
+			code, _, body := ts.get(t, testCase.path)
+
+			testHelpers.Equal(t, code, testCase.wantCode)
+			for _, tag := range testCase.wantContent {
+				testHelpers.StringContains(t, body, tag)
+			}
+			for _, tag := range testCase.notWantContent {
+				testHelpers.Equal(t, strings.Contains(body, tag), false)
+			}
+		})
+	}
+}
+
+func TestCreateNPCPost(t *testing.T) {
+	app := newTestApplication(t)
+
+	ts := newTestServer(t, app.sessionManager.LoadAndSave(app.mockSession(noSurf(app.authenticate(app.requireAuthentication(app.routesNoMW()))),
+		map[string]any{
+			authenticatedUserIdKey:   mocks.MockGM.ID,
+			authenticatedUserNameKey: mocks.MockGM.Name,
+		})))
+	defer ts.Close()
+
+	_, _, body := ts.get(t, "/npcs/create")
+	testHelpers.StringContains(t, body, "<option value='1'>Der Tanz der Drachen</option>")
+	validCSRF := extractCSRFToken(t, body)
+
+	tests := []struct {
+		name         string
+		npcName      string
+		age          string
+		st           string
+		campaignId   string
+		wantCode     int
+		wantLocation string
+		wantContent  string
+	}{
+		{
+			name:         "Valid NPC",
+			npcName:      "Larys Strong",
+			age:          "",
+			st:           "120",
+			campaignId:   "1",
+			wantCode:     http.StatusSeeOther,
+			wantLocation: "/characters/1",
+		},
+		{
+			name:        "Empty Name",
+			npcName:     "",
+			age:         "40",
+			st:          "60",
+			campaignId:  "0",
+			wantCode:    http.StatusUnprocessableEntity,
+			wantContent: "<label class='error'>Dieses Feld kann nicht leer sein.</label>",
+		},
+		{
+			name:        "Attribute Out Of Range",
+			npcName:     "Larys Strong",
+			age:         "40",
+			st:          "250",
+			campaignId:  "0",
+			wantCode:    http.StatusUnprocessableEntity,
+			wantContent: "<label class='error'>Wert muss zwischen 0 und 200 liegen.</label>",
+		},
+		{
+			name:        "Foreign Campaign",
+			npcName:     "Larys Strong",
+			age:         "40",
+			st:          "60",
+			campaignId:  "2",
+			wantCode:    http.StatusUnprocessableEntity,
+			wantContent: "<label class='error'>Ungültige Kampagne.</label>",
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			form := url.Values{}
+			form.Add("Info.Name", testCase.npcName)
+			form.Add("Info.Age", testCase.age)
+			form.Add("Attributes.ST", testCase.st)
+			form.Add("CampaignId", testCase.campaignId)
+			form.Add("TrackTP", "true")
+			form.Add("csrf_token", validCSRF)
+
+			code, header, body := ts.postForm(t, "/npcs/create", form)
+
+			testHelpers.Equal(t, code, testCase.wantCode)
+			testHelpers.Equal(t, header.Get("Location"), testCase.wantLocation)
+			if testCase.wantContent != "" {
+				testHelpers.StringContains(t, body, testCase.wantContent)
+			}
+		})
+	}
+}
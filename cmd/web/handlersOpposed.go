@@ -0,0 +1,195 @@
+package main
+
+import (
+	"errors"
+	"net/http"
+	"slices"
+
+	"github.com/winik100/NoPenNoPaper/internal/core"
+	"github.com/winik100/NoPenNoPaper/internal/models"
+	"github.com/winik100/NoPenNoPaper/internal/validators"
+)
+
+type opposedForm struct {
+	AttackerId               int
+	AttackerSkill            string
+	AttackerBonus            int
+	DefenderId               int
+	DefenderSkill            string
+	DefenderBonus            int
+	Defense                  core.Defense
+	Maneuver                 bool
+	Result                   *opposedResult `schema:"-"`
+	validators.FormValidator `schema:"-"`
+}
+
+type opposedResult struct {
+	core.OpposedRoll
+	AttackerName string
+	DefenderName string
+}
+
+func (app *application) opposedRoll(w http.ResponseWriter, r *http.Request) {
+	campaign, ok := app.loadCampaign(w, r)
+	if !ok {
+		return
+	}
+
+	candidates, err := app.campaignCharacters(campaign.ID)
+	if err != nil {
+		app.serverError(w, r, err)
+		return
+	}
+
+	data := app.newTemplateData(r)
+	data.Campaign = campaign
+	data.Characters = candidates
+	data.AdditionalData = skillNames(candidates)
+	data.Form = opposedForm{}
+	w.WriteHeader(http.StatusOK)
+	app.render(w, r, "opposed.tmpl.html", data)
+}
+
+// rolls two characters' skills against each other. during a combat, attacking someone who already
+// dodged or fought back this round grants a bonus die.
+func (app *application) opposedRollPost(w http.ResponseWriter, r *http.Request) {
+	campaign, ok := app.loadCampaign(w, r)
+	if !ok {
+		return
+	}
+
+	var form opposedForm
+	err := app.decodePostForm(r, &form)
+	if err != nil {
+		app.clientError(w, http.StatusBadRequest)
+		return
+	}
+
+	candidates, err := app.campaignCharacters(campaign.ID)
+	if err != nil {
+		app.serverError(w, r, err)
+		return
+	}
+
+	if form.Defense == core.DefenseDodge {
+		form.DefenderSkill = core.Dodge
+	}
+	attacker, attackerFound := findCharacter(candidates, form.AttackerId)
+	defender, defenderFound := findCharacter(candidates, form.DefenderId)
+	form.CheckField(attackerFound, "AttackerId", "Bitte einen Charakter der Kampagne wählen.")
+	form.CheckField(defenderFound, "DefenderId", "Bitte einen Charakter der Kampagne wählen.")
+	form.CheckField(form.AttackerId != form.DefenderId, "DefenderId", "Ein Charakter kann nicht gegen sich selbst würfeln.")
+	form.CheckField(validators.NotBlank(form.AttackerSkill), "AttackerSkill", "Dieses Feld kann nicht leer sein.")
+	form.CheckField(validators.NotBlank(form.DefenderSkill), "DefenderSkill", "Dieses Feld kann nicht leer sein.")
+	form.CheckField(form.AttackerBonus >= -maxBonusDice && form.AttackerBonus <= maxBonusDice, "AttackerBonus", "Ungültige Anzahl an Bonus-/Strafwürfeln.")
+	form.CheckField(form.DefenderBonus >= -maxBonusDice && form.DefenderBonus <= maxBonusDice, "DefenderBonus", "Ungültige Anzahl an Bonus-/Strafwürfeln.")
+	form.CheckField(validators.PermittedValue(form.Defense, core.NoDefense, core.DefenseDodge, core.DefenseFightBack), "Defense", "Ungültige Verteidigung.")
+
+	var attackerValue, defenderValue int
+	if form.Valid() {
+		var known bool
+		attackerValue, known, err = app.skillValue(attacker, form.AttackerSkill)
+		if err != nil {
+			app.serverError(w, r, err)
+			return
+		}
+		form.CheckField(known, "AttackerSkill", "Unbekannte Fertigkeit.")
+
+		defenderValue, known, err = app.skillValue(defender, form.DefenderSkill)
+		if err != nil {
+			app.serverError(w, r, err)
+			return
+		}
+		form.CheckField(known, "DefenderSkill", "Unbekannte Fertigkeit.")
+	}
+
+	combat, err := app.combats.Get(campaign.ID)
+	if err != nil && !errors.Is(err, models.ErrNoRecord) {
+		app.serverError(w, r, err)
+		return
+	}
+	inCombat := form.Defense != core.NoDefense && combat.HasParticipant(attacker.ID) && combat.HasParticipant(defender.ID)
+
+	var opposed core.OpposedRoll
+	if form.Valid() {
+		opposed, err = core.RollOpposed(
+			core.Contestant{CharacterID: attacker.ID, Skill: form.AttackerSkill, Value: attackerValue, Bonus: form.AttackerBonus, Build: attacker.Attributes.Build},
+			core.Contestant{CharacterID: defender.ID, Skill: form.DefenderSkill, Value: defenderValue, Bonus: form.DefenderBonus, Build: defender.Attributes.Build},
+			form.Defense, form.Maneuver, inCombat && combat.HasDefended(defender.ID))
+		if errors.Is(err, core.ErrManeuverImpossible) {
+			form.AddGenericError(defender.Info.Name + " ist zu groß für ein Kampfmanöver.")
+		} else if err != nil {
+			app.serverError(w, r, err)
+			return
+		}
+	}
+
+	if !form.Valid() {
+		data := app.newTemplateData(r)
+		data.Campaign = campaign
+		data.Characters = candidates
+		data.AdditionalData = skillNames(candidates)
+		data.Form = form
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		app.render(w, r, "opposed.tmpl.html", data)
+		return
+	}
+
+	sides := []struct {
+		character core.Character
+		roll      *core.Roll
+	}{{attacker, &opposed.Attacker}, {defender, &opposed.Defender}}
+	for _, side := range sides {
+		side.roll.ID, err = app.rolls.Insert(*side.roll)
+		if err != nil {
+			app.serverError(w, r, err)
+			return
+		}
+		app.events.Publish(characterTopic(side.character.ID), eventRolls)
+
+		err = app.tickSkill(side.character, *side.roll)
+		if err != nil {
+			app.serverError(w, r, err)
+			return
+		}
+	}
+
+	if inCombat {
+		err = app.combats.RecordDefense(campaign.ID, defender.ID, combat.Round)
+		if err != nil {
+			app.serverError(w, r, err)
+			return
+		}
+		app.events.Publish(campaignTopic(campaign.ID), eventCombat)
+	}
+
+	form.Result = &opposedResult{OpposedRoll: opposed, AttackerName: attacker.Info.Name, DefenderName: defender.Info.Name}
+	data := app.newTemplateData(r)
+	data.Campaign = campaign
+	data.Characters = candidates
+	data.AdditionalData = skillNames(candidates)
+	data.Form = form
+	w.WriteHeader(http.StatusOK)
+	app.render(w, r, "opposed.tmpl.html", data)
+}
+
+func findCharacter(characters []core.Character, characterId int) (core.Character, bool) {
+	i := slices.IndexFunc(characters, func(c core.Character) bool {
+		return c.ID == characterId
+	})
+	if i < 0 {
+		return core.Character{}, false
+	}
+	return characters[i], true
+}
+
+// every skill any of the characters has, to suggest when choosing what to roll
+func skillNames(characters []core.Character) []string {
+	names := []string{core.Dodge}
+	for _, character := range characters {
+		names = append(names, character.Skills.Name...)
+		names = append(names, character.CustomSkills.Name...)
+	}
+	slices.Sort(names)
+	return slices.Compact(names)
+}
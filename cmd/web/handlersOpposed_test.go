@@ -0,0 +1,119 @@
+package main
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/winik100/NoPenNoPaper/internal/models/mocks"
+	"github.com/winik100/NoPenNoPaper/internal/testHelpers"
+)
+
+func TestOpposedRollPost(t *testing.T) {
+	app := newTestApplication(t)
+
+	ts := newTestServer(t, app.sessionManager.LoadAndSave(app.mockSession(noSurf(app.authenticate(app.requireAuthentication(app.routesNoMW()))),
+		map[string]any{
+			authenticatedUserIdKey:   mocks.MockGM.ID,
+			authenticatedUserNameKey: mocks.MockGM.Name,
+		})))
+	defer ts.Close()
+
+	code, _, body := ts.get(t, "/campaigns/1/opposed")
+	testHelpers.Equal(t, code, http.StatusOK)
+	testHelpers.StringContains(t, body, "<option value='3'>Larys Strong (NSC)</option>")
+	testHelpers.StringContains(t, body, "<option value='Ausweichen'>")
+	validCSRF := extractCSRFToken(t, body)
+
+	tests := []struct {
+		name          string
+		attackerId    string
+		attackerSkill string
+		defenderId    string
+		defenderSkill string
+		defense       string
+		wantCode      int
+		wantContent   []string
+	}{
+		{
+			name:          "Dodge Outnumbered",
+			attackerId:    "3",
+			attackerSkill: "Intrige",
+			defenderId:    "1",
+			defense:       "dodge",
+			wantCode:      http.StatusOK,
+			wantContent: []string{
+				"<td>Intrige (5)</td>",
+				"<td>Ausweichen (25)</td>",
+				"Otto Hightower hat sich in dieser Runde bereits verteidigt",
+			},
+		},
+		{
+			name:          "Plain Opposed Roll",
+			attackerId:    "1",
+			attackerSkill: "Politik",
+			defenderId:    "2",
+			defenderSkill: "Politik",
+			wantCode:      http.StatusOK,
+			wantContent:   []string{"<h3>Vergleichender Wurf</h3>", "<td>Politik (70)</td>"},
+		},
+		{
+			name:          "Against Oneself",
+			attackerId:    "1",
+			attackerSkill: "Politik",
+			defenderId:    "1",
+			defenderSkill: "Intrige",
+			wantCode:      http.StatusUnprocessableEntity,
+			wantContent:   []string{"Ein Charakter kann nicht gegen sich selbst würfeln."},
+		},
+		{
+			name:          "Unknown Skill",
+			attackerId:    "1",
+			attackerSkill: "Fliegen",
+			defenderId:    "3",
+			defenderSkill: "Politik",
+			wantCode:      http.StatusUnprocessableEntity,
+			wantContent:   []string{"Unbekannte Fertigkeit."},
+		},
+		{
+			name:          "Character Outside Campaign",
+			attackerId:    "69",
+			attackerSkill: "Politik",
+			defenderId:    "1",
+			defenderSkill: "Politik",
+			wantCode:      http.StatusUnprocessableEntity,
+			wantContent:   []string{"Bitte einen Charakter der Kampagne wählen."},
+		},
+		{
+			name:          "Invalid Defense",
+			attackerId:    "1",
+			attackerSkill: "Politik",
+			defenderId:    "3",
+			defenderSkill: "Politik",
+			defense:       "parry",
+			wantCode:      http.StatusUnprocessableEntity,
+			wantContent:   []string{"Ungültige Verteidigung."},
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			form := url.Values{}
+			form.Add("AttackerId", testCase.attackerId)
+			form.Add("AttackerSkill", testCase.attackerSkill)
+			form.Add("AttackerBonus", "0")
+			form.Add("DefenderId", testCase.defenderId)
+			form.Add("DefenderSkill", testCase.defenderSkill)
+			form.Add("DefenderBonus", "0")
+			form.Add("Defense", testCase.defense)
+			form.Add("csrf_token", validCSRF)
+
+			code, _, body := ts.postForm(t, "/campaigns/1/opposed", form)
+
+			testHelpers.Equal(t, code, testCase.wantCode)
+			for _, tag := range testCase.wantContent {
+				testHelpers.StringContains(t, body, tag)
+			}
+		})
+	}
+}
@@ -0,0 +1,356 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/winik100/NoPenNoPaper/internal/core"
+	"github.com/winik100/NoPenNoPaper/internal/models"
+	"github.com/winik100/NoPenNoPaper/internal/validators"
+)
+
+// number of rolls shown in a character's history
+const rollHistoryLength = 20
+
+// most bonus or penalty dice a single roll can get
+const maxBonusDice = 2
+
+// longest justification a push can be given and longest consequence the GM can write for it
+const (
+	maxJustificationLength = 500
+	maxConsequenceLength   = 1000
+)
+
+type rollForm struct {
+	Name  string
+	Bonus int
+}
+
+type pushForm struct {
+	Justification string
+}
+
+type consequenceForm struct {
+	Consequence string
+}
+
+func (app *application) rollPost(w http.ResponseWriter, r *http.Request) {
+	character, ok := app.loadCharacter(w, r)
+	if !ok {
+		return
+	}
+
+	var form rollForm
+	err := app.decodePostForm(r, &form)
+	if err != nil {
+		app.clientError(w, http.StatusBadRequest)
+		return
+	}
+
+	value, ok := character.RollTarget(form.Name)
+	if !ok || form.Bonus < -maxBonusDice || form.Bonus > maxBonusDice {
+		app.clientError(w, http.StatusUnprocessableEntity)
+		return
+	}
+
+	roll := core.NewRoll(character.ID, form.Name, value, form.Bonus)
+	roll.Source = core.SourceSheet
+	roll.ID, err = app.rolls.Insert(roll)
+	if err != nil {
+		app.serverError(w, r, err)
+		return
+	}
+
+	app.events.Publish(characterTopic(character.ID), eventRolls)
+
+	err = app.tickSkill(character, roll)
+	if err != nil {
+		app.serverError(w, r, err)
+		return
+	}
+
+	data := app.newTemplateData(r)
+	data.Character = character
+	data.Form = roll
+	w.WriteHeader(http.StatusOK)
+	app.renderHtmx(w, r, "rollResult", rollResultTmpl, data)
+}
+
+func (app *application) spendLuckPost(w http.ResponseWriter, r *http.Request) {
+	characterId, ok := app.characterIdFromPath(w, r)
+	if !ok {
+		return
+	}
+	rollId, err := strconv.Atoi(r.PathValue("rollId"))
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	roll, err := app.rolls.SpendLuck(characterId, rollId)
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrNoRecord):
+			http.NotFound(w, r)
+		case errors.Is(err, models.ErrNotEnoughLuck), errors.Is(err, models.ErrLuckNotAllowed):
+			tmplStr := `<div id="lastRoll" class="failed">{{.Form}}</div>`
+			data := app.newTemplateData(r)
+			data.Form = "Für diesen Wurf kann kein Glück eingesetzt werden."
+			if errors.Is(err, models.ErrNotEnoughLuck) {
+				data.Form = "Nicht genug Glück übrig."
+			}
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			app.renderHtmx(w, r, "spendLuckFail", tmplStr, data)
+		default:
+			app.serverError(w, r, err)
+		}
+		return
+	}
+
+	character, err := app.characters.Get(characterId)
+	if err != nil {
+		app.serverError(w, r, err)
+		return
+	}
+
+	app.events.Publish(characterTopic(characterId), eventRolls)
+	app.publishCharacter(character, eventStats)
+
+	data := app.newTemplateData(r)
+	data.Character = character
+	data.Form = roll
+	w.WriteHeader(http.StatusOK)
+	app.renderHtmx(w, r, "rollResult", rollResultTmpl, data)
+}
+
+// rerolls a failed roll once, the player has to tell how their character pushes it
+func (app *application) pushRollPost(w http.ResponseWriter, r *http.Request) {
+	characterId, ok := app.characterIdFromPath(w, r)
+	if !ok {
+		return
+	}
+	rollId, err := strconv.Atoi(r.PathValue("rollId"))
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	var form pushForm
+	err = app.decodePostForm(r, &form)
+	if err != nil {
+		app.clientError(w, http.StatusBadRequest)
+		return
+	}
+
+	tmplStr := `<div id="lastRoll" class="failed">{{.Form}}</div>`
+	form.Justification = strings.TrimSpace(form.Justification)
+	if !validators.NotBlank(form.Justification) || !validators.MaxChars(form.Justification, maxJustificationLength) {
+		data := app.newTemplateData(r)
+		data.Form = fmt.Sprintf("Bitte in höchstens %d Zeichen begründen, wie der Wurf forciert wird.", maxJustificationLength)
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		app.renderHtmx(w, r, "pushFail", tmplStr, data)
+		return
+	}
+
+	original, err := app.rolls.Get(characterId, rollId)
+	if err != nil {
+		if errors.Is(err, models.ErrNoRecord) {
+			http.NotFound(w, r)
+		} else {
+			app.serverError(w, r, err)
+		}
+		return
+	}
+
+	push := original.Push(form.Justification)
+	push.ID, err = app.rolls.Push(push)
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrNoRecord):
+			http.NotFound(w, r)
+		case errors.Is(err, models.ErrPushNotAllowed):
+			data := app.newTemplateData(r)
+			data.Form = "Dieser Wurf kann nicht forciert werden."
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			app.renderHtmx(w, r, "pushFail", tmplStr, data)
+		default:
+			app.serverError(w, r, err)
+		}
+		return
+	}
+
+	character, err := app.characters.Get(characterId)
+	if err != nil {
+		app.serverError(w, r, err)
+		return
+	}
+
+	app.events.Publish(characterTopic(characterId), eventRolls)
+	if push.AwaitsConsequence() && character.CampaignID != 0 {
+		app.events.Publish(campaignTopic(character.CampaignID), eventRolls)
+	}
+
+	err = app.tickSkill(character, push)
+	if err != nil {
+		app.serverError(w, r, err)
+		return
+	}
+
+	data := app.newTemplateData(r)
+	data.Character = character
+	data.Form = push
+	w.WriteHeader(http.StatusOK)
+	app.renderHtmx(w, r, "rollResult", rollResultTmpl, data)
+}
+
+// rolls for luck recovery at the end of a session, for every investigator at the table
+func (app *application) recoverLuckPost(w http.ResponseWriter, r *http.Request) {
+	campaign, ok := app.loadCampaign(w, r)
+	if !ok {
+		return
+	}
+
+	characters, err := app.characters.GetAllInCampaign(campaign.ID)
+	if err != nil {
+		app.serverError(w, r, err)
+		return
+	}
+
+	var summary []string
+	for _, character := range tableCharacters(campaign, characters) {
+		result, gain := core.RecoverLuck(character.Stats.LUCK, character.Stats.MaxLUCK)
+		if gain == 0 {
+			summary = append(summary, fmt.Sprintf("%s: %d, kein Glück erholt", character.Info.Name, result))
+			continue
+		}
+
+		luck, err := app.characters.GainLuck(character.ID, gain)
+		if err != nil {
+			app.serverError(w, r, err)
+			return
+		}
+		app.events.Publish(characterTopic(character.ID), eventStats)
+		summary = append(summary, fmt.Sprintf("%s: %d, +%d Glück (jetzt %d)", character.Info.Name, result, gain, luck))
+	}
+	app.events.Publish(campaignTopic(campaign.ID), eventStats)
+
+	if len(summary) == 0 {
+		summary = append(summary, "Keine Charaktere am Spieltisch.")
+	}
+	app.sessionManager.Put(r.Context(), "flash", "Glückserholung - "+strings.Join(summary, "; "))
+	http.Redirect(w, r, fmt.Sprintf("/campaigns/%d/dashboard", campaign.ID), http.StatusSeeOther)
+}
+
+// the GM decides what goes wrong after a failed push
+func (app *application) consequencePost(w http.ResponseWriter, r *http.Request) {
+	campaign, ok := app.loadCampaign(w, r)
+	if !ok {
+		return
+	}
+	rollId, err := strconv.Atoi(r.PathValue("rollId"))
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	var form consequenceForm
+	err = app.decodePostForm(r, &form)
+	if err != nil {
+		app.clientError(w, http.StatusBadRequest)
+		return
+	}
+
+	redirect := fmt.Sprintf("/campaigns/%d/dashboard", campaign.ID)
+	form.Consequence = strings.TrimSpace(form.Consequence)
+	if !validators.NotBlank(form.Consequence) || !validators.MaxChars(form.Consequence, maxConsequenceLength) {
+		app.sessionManager.Put(r.Context(), "flash", fmt.Sprintf("Bitte die Folgen in höchstens %d Zeichen beschreiben.", maxConsequenceLength))
+		http.Redirect(w, r, redirect, http.StatusSeeOther)
+		return
+	}
+
+	pushes, err := app.rolls.GetUnresolvedPushes(campaign.ID)
+	if err != nil {
+		app.serverError(w, r, err)
+		return
+	}
+	i := slices.IndexFunc(pushes, func(p core.PushedRoll) bool {
+		return p.Push.ID == rollId
+	})
+	if i < 0 {
+		app.sessionManager.Put(r.Context(), "flash", "Für diesen Wurf stehen keine Folgen aus.")
+		http.Redirect(w, r, redirect, http.StatusSeeOther)
+		return
+	}
+
+	err = app.rolls.SetConsequence(campaign.ID, rollId, form.Consequence)
+	if err != nil {
+		app.serverError(w, r, err)
+		return
+	}
+
+	app.events.Publish(characterTopic(pushes[i].Push.CharacterID), eventRolls)
+	app.events.Publish(campaignTopic(campaign.ID), eventRolls)
+	app.sessionManager.Put(r.Context(), "flash", fmt.Sprintf("Folgen für %s festgehalten.", pushes[i].CharacterName))
+	http.Redirect(w, r, redirect, http.StatusSeeOther)
+}
+
+// successful skill rolls earn an improvement check
+func (app *application) tickSkill(character core.Character, roll core.Roll) error {
+	if !roll.Succeeded() || !character.HasSkill(roll.Name) || character.IsTicked(roll.Name) {
+		return nil
+	}
+
+	err := app.characters.SetSkillTick(character.ID, roll.Name, true)
+	if err != nil {
+		return err
+	}
+	app.events.Publish(characterTopic(character.ID), eventSkills)
+	return nil
+}
+
+// shows the result of a roll and offers to spend luck on it, if possible
+const rollResultTmpl = `<div id="lastRoll"{{if not .Form.Succeeded}} class="failed"{{end}}>
+					{{.Form.Name}} ({{.Form.Value}}): {{.Form.Result}} - {{.Form.Level}}
+					{{with .Form.BonusLabel}}({{.}}, Zehner: {{range $i, $ten := $.Form.Tens}}{{if $i}}, {{end}}{{$ten}}{{end}}){{end}}
+					{{with .Form.LuckSpent}}({{.}} Glück eingesetzt){{end}}
+					{{if .Form.IsPush}}(forciert: {{.Form.Justification}}){{end}}
+					{{if .Form.AwaitsConsequence}}<br>Die Spielleitung entscheidet über die Folgen.{{end}}
+					{{if and .Form.CanSpendLuck (le .Form.LuckNeeded .Character.Stats.LUCK)}}
+					<form hx-post="/characters/{{.Form.CharacterID}}/rolls/{{.Form.ID}}/spendLuck" hx-target="#lastRoll" hx-swap="outerHTML">
+						<input type="hidden" name="csrf_token" value="{{.CSRFToken}}">
+						<button type="submit">{{.Form.LuckNeeded}} Glück einsetzen</button>
+					</form>
+					{{end}}
+					{{if .Form.CanPush}}
+					<form hx-post="/characters/{{.Form.CharacterID}}/rolls/{{.Form.ID}}/push" hx-target="#lastRoll" hx-swap="outerHTML">
+						<input type="hidden" name="csrf_token" value="{{.CSRFToken}}">
+						<input type="text" name="Justification" placeholder="Begründung">
+						<button type="submit">forcieren</button>
+					</form>
+					{{end}}
+				</div>`
+
+// the value a character rolls the skill against. skills they never learned are rolled against
+// their base value, dodging against half of GE.
+func (app *application) skillValue(character core.Character, name string) (int, bool, error) {
+	if value, ok := character.RollTarget(name); ok {
+		return value, true, nil
+	}
+	if name == core.Dodge {
+		return character.DodgeValue(), true, nil
+	}
+
+	availableSkills, err := app.characters.GetAvailableSkills()
+	if err != nil {
+		return 0, false, err
+	}
+	i := slices.Index(availableSkills.Name, name)
+	if i < 0 {
+		return 0, false, nil
+	}
+	return availableSkills.Value[i], true, nil
+}
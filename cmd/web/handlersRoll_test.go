@@ -0,0 +1,358 @@
+package main
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/winik100/NoPenNoPaper/internal/models/mocks"
+	"github.com/winik100/NoPenNoPaper/internal/testHelpers"
+)
+
+func TestRollPost(t *testing.T) {
+	app := newTestApplication(t)
+
+	ts := newTestServer(t, app.sessionManager.LoadAndSave(app.mockSession(noSurf(app.authenticate(app.requireAuthentication(app.routesNoMW()))),
+		map[string]any{
+			authenticatedUserIdKey:   mocks.MockPlayer.ID,
+			authenticatedUserNameKey: mocks.MockPlayer.Name,
+		})))
+	defer ts.Close()
+
+	_, _, body := ts.get(t, "/characters/1")
+	validCSRF := extractCSRFToken(t, body)
+
+	tests := []struct {
+		name        string
+		path        string
+		rollName    string
+		bonus       string
+		wantCode    int
+		wantContent string
+	}{
+		{
+			name:        "Skill",
+			path:        "/characters/1/roll",
+			rollName:    "Intrige",
+			wantCode:    http.StatusOK,
+			wantContent: "Intrige (60): ",
+		},
+		{
+			name:        "Custom Skill",
+			path:        "/characters/1/roll",
+			rollName:    "Westerosi",
+			wantCode:    http.StatusOK,
+			wantContent: "Westerosi (50): ",
+		},
+		{
+			name:        "Attribute",
+			path:        "/characters/1/roll",
+			rollName:    "ST",
+			wantCode:    http.StatusOK,
+			wantContent: "ST (40): ",
+		},
+		{
+			name:        "Bonus Dice",
+			path:        "/characters/1/roll",
+			rollName:    "Intrige",
+			bonus:       "2",
+			wantCode:    http.StatusOK,
+			wantContent: "(2 Bonuswürfel, Zehner: ",
+		},
+		{
+			name:        "Penalty Die",
+			path:        "/characters/1/roll",
+			rollName:    "Intrige",
+			bonus:       "-1",
+			wantCode:    http.StatusOK,
+			wantContent: "(1 Strafwürfel, Zehner: ",
+		},
+		{
+			name:     "Too Many Bonus Dice",
+			path:     "/characters/1/roll",
+			rollName: "Intrige",
+			bonus:    "3",
+			wantCode: http.StatusUnprocessableEntity,
+		},
+		{
+			name:     "Movement Rate",
+			path:     "/characters/1/roll",
+			rollName: "BW",
+			wantCode: http.StatusUnprocessableEntity,
+		},
+		{
+			name:     "Unknown Skill",
+			path:     "/characters/1/roll",
+			rollName: "Drachenreiten",
+			wantCode: http.StatusUnprocessableEntity,
+		},
+		{
+			name:     "Nonexistent Character",
+			path:     "/characters/69/roll",
+			rollName: "Intrige",
+			wantCode: http.StatusNotFound,
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			form := url.Values{}
+			form.Add("Name", testCase.rollName)
+			form.Add("Bonus", testCase.bonus)
+			form.Add("csrf_token", validCSRF)
+
+			code, _, body := ts.postForm(t, testCase.path, form)
+
+			testHelpers.Equal(t, code, testCase.wantCode)
+			if testCase.wantContent != "" {
+				testHelpers.StringContains(t, body, testCase.wantContent)
+			}
+		})
+	}
+}
+
+func TestSpendLuckPost(t *testing.T) {
+	app := newTestApplication(t)
+
+	ts := newTestServer(t, app.sessionManager.LoadAndSave(app.mockSession(noSurf(app.authenticate(app.requireAuthentication(app.routesNoMW()))),
+		map[string]any{
+			authenticatedUserIdKey:   mocks.MockPlayer.ID,
+			authenticatedUserNameKey: mocks.MockPlayer.Name,
+		})))
+	defer ts.Close()
+
+	_, _, body := ts.get(t, "/characters/1")
+	testHelpers.StringContains(t, body, "<button type=\"submit\">8 Glück einsetzen</button>")
+	if strings.Contains(body, "<button type=\"submit\">15 Glück einsetzen</button>") {
+		t.Errorf("luck offered on a pushed roll")
+	}
+	validCSRF := extractCSRFToken(t, body)
+
+	tests := []struct {
+		name        string
+		path        string
+		wantCode    int
+		wantContent string
+	}{
+		{
+			name:        "Failed Roll",
+			path:        "/characters/1/rolls/2/spendLuck",
+			wantCode:    http.StatusOK,
+			wantContent: "(8 Glück eingesetzt)",
+		},
+		{
+			name:        "Successful Roll",
+			path:        "/characters/1/rolls/1/spendLuck",
+			wantCode:    http.StatusUnprocessableEntity,
+			wantContent: "Für diesen Wurf kann kein Glück eingesetzt werden.",
+		},
+		{
+			name:        "Pushed Roll",
+			path:        "/characters/1/rolls/5/spendLuck",
+			wantCode:    http.StatusUnprocessableEntity,
+			wantContent: "Für diesen Wurf kann kein Glück eingesetzt werden.",
+		},
+		{
+			name:     "Roll Of Another Character",
+			path:     "/characters/2/rolls/2/spendLuck",
+			wantCode: http.StatusNotFound,
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			form := url.Values{}
+			form.Add("csrf_token", validCSRF)
+
+			code, _, body := ts.postForm(t, testCase.path, form)
+
+			testHelpers.Equal(t, code, testCase.wantCode)
+			if testCase.wantContent != "" {
+				testHelpers.StringContains(t, body, testCase.wantContent)
+			}
+		})
+	}
+}
+
+func TestRecoverLuckPost(t *testing.T) {
+	app := newTestApplication(t)
+
+	ts := newTestServer(t, app.sessionManager.LoadAndSave(app.mockSession(noSurf(app.authenticate(app.requireAuthentication(app.routesNoMW()))),
+		map[string]any{
+			authenticatedUserIdKey:   mocks.MockGM.ID,
+			authenticatedUserNameKey: mocks.MockGM.Name,
+		})))
+	defer ts.Close()
+
+	_, _, body := ts.get(t, "/campaigns/1/dashboard")
+	testHelpers.StringContains(t, body, "Glückserholung (Sitzungsende)")
+	validCSRF := extractCSRFToken(t, body)
+
+	form := url.Values{}
+	form.Add("csrf_token", validCSRF)
+	code, header, _ := ts.postForm(t, "/campaigns/1/recoverLuck", form)
+
+	testHelpers.Equal(t, code, http.StatusSeeOther)
+	testHelpers.Equal(t, header.Get("Location"), "/campaigns/1/dashboard")
+
+	_, _, body = ts.get(t, "/campaigns/1/dashboard")
+	testHelpers.StringContains(t, body, "Glückserholung - Otto Hightower: ")
+}
+
+func TestPushRollPost(t *testing.T) {
+	app := newTestApplication(t)
+
+	ts := newTestServer(t, app.sessionManager.LoadAndSave(app.mockSession(noSurf(app.authenticate(app.requireAuthentication(app.routesNoMW()))),
+		map[string]any{
+			authenticatedUserIdKey:   mocks.MockPlayer.ID,
+			authenticatedUserNameKey: mocks.MockPlayer.Name,
+		})))
+	defer ts.Close()
+
+	_, _, body := ts.get(t, "/characters/1")
+	testHelpers.StringContains(t, body, "<form hx-post=\"/characters/1/rolls/2/push\"")
+	testHelpers.StringContains(t, body, "<a href='#roll-5'>forciert</a>: Otto droht mit dem Zorn des Königs.")
+	testHelpers.StringContains(t, body, "Folgen stehen noch aus")
+	validCSRF := extractCSRFToken(t, body)
+
+	tests := []struct {
+		name          string
+		path          string
+		justification string
+		wantCode      int
+		wantContent   string
+	}{
+		{
+			name:          "Failed Roll",
+			path:          "/characters/1/rolls/2/push",
+			justification: "Otto erinnert an seine Verdienste als Hand des Königs.",
+			wantCode:      http.StatusOK,
+			wantContent:   "(forciert: Otto erinnert an seine Verdienste als Hand des Königs.)",
+		},
+		{
+			name:          "Justification Is Escaped",
+			path:          "/characters/1/rolls/2/push",
+			justification: "<b>lauter</b>",
+			wantCode:      http.StatusOK,
+			wantContent:   "(forciert: &lt;b&gt;lauter&lt;/b&gt;)",
+		},
+		{
+			name:          "No Justification",
+			path:          "/characters/1/rolls/2/push",
+			justification: "  ",
+			wantCode:      http.StatusUnprocessableEntity,
+			wantContent:   "Bitte in höchstens 500 Zeichen begründen, wie der Wurf forciert wird.",
+		},
+		{
+			name:          "Already Pushed",
+			path:          "/characters/1/rolls/5/push",
+			justification: "Noch einmal.",
+			wantCode:      http.StatusUnprocessableEntity,
+			wantContent:   "Dieser Wurf kann nicht forciert werden.",
+		},
+		{
+			name:          "Attack Roll",
+			path:          "/characters/1/rolls/7/push",
+			justification: "Otto schlägt noch einmal zu.",
+			wantCode:      http.StatusUnprocessableEntity,
+			wantContent:   "Dieser Wurf kann nicht forciert werden.",
+		},
+		{
+			name:          "Successful Roll",
+			path:          "/characters/1/rolls/1/push",
+			justification: "Noch besser.",
+			wantCode:      http.StatusUnprocessableEntity,
+			wantContent:   "Dieser Wurf kann nicht forciert werden.",
+		},
+		{
+			name:          "Roll Of Another Character",
+			path:          "/characters/2/rolls/2/push",
+			justification: "Viserys hilft.",
+			wantCode:      http.StatusNotFound,
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			form := url.Values{}
+			form.Add("Justification", testCase.justification)
+			form.Add("csrf_token", validCSRF)
+
+			code, _, body := ts.postForm(t, testCase.path, form)
+
+			testHelpers.Equal(t, code, testCase.wantCode)
+			if testCase.wantContent != "" {
+				testHelpers.StringContains(t, body, testCase.wantContent)
+			}
+		})
+	}
+}
+
+func TestConsequencePost(t *testing.T) {
+	app := newTestApplication(t)
+
+	ts := newTestServer(t, app.sessionManager.LoadAndSave(app.mockSession(noSurf(app.authenticate(app.requireAuthentication(app.routesNoMW()))),
+		map[string]any{
+			authenticatedUserIdKey:   mocks.MockGM.ID,
+			authenticatedUserNameKey: mocks.MockGM.Name,
+		})))
+	defer ts.Close()
+
+	_, _, body := ts.get(t, "/campaigns/1/dashboard")
+	testHelpers.StringContains(t, body, "<td>Manipulation (60): 75, forciert 88 - Fehlschlag</td>")
+	testHelpers.StringContains(t, body, "<form action='/campaigns/1/rolls/6/consequence' method='POST'>")
+	validCSRF := extractCSRFToken(t, body)
+
+	tests := []struct {
+		name        string
+		path        string
+		consequence string
+		wantCode    int
+		wantFlash   string
+	}{
+		{
+			name:        "Valid Consequence",
+			path:        "/campaigns/1/rolls/6/consequence",
+			consequence: "Der König lässt Otto als Hand entlassen.",
+			wantCode:    http.StatusSeeOther,
+			wantFlash:   "Folgen für Otto Hightower festgehalten.",
+		},
+		{
+			name:      "No Consequence",
+			path:      "/campaigns/1/rolls/6/consequence",
+			wantCode:  http.StatusSeeOther,
+			wantFlash: "Bitte die Folgen in höchstens 1000 Zeichen beschreiben.",
+		},
+		{
+			name:        "Not A Failed Push",
+			path:        "/campaigns/1/rolls/2/consequence",
+			consequence: "Nichts.",
+			wantCode:    http.StatusSeeOther,
+			wantFlash:   "Für diesen Wurf stehen keine Folgen aus.",
+		},
+		{
+			name:        "Invalid Roll",
+			path:        "/campaigns/1/rolls/abc/consequence",
+			consequence: "Nichts.",
+			wantCode:    http.StatusNotFound,
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			form := url.Values{}
+			form.Add("Consequence", testCase.consequence)
+			form.Add("csrf_token", validCSRF)
+
+			code, header, _ := ts.postForm(t, testCase.path, form)
+
+			testHelpers.Equal(t, code, testCase.wantCode)
+			if testCase.wantFlash != "" {
+				testHelpers.Equal(t, header.Get("Location"), "/campaigns/1/dashboard")
+				_, _, body := ts.get(t, "/campaigns/1/dashboard")
+				testHelpers.StringContains(t, body, testCase.wantFlash)
+			}
+		})
+	}
+}
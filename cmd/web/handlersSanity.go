@@ -0,0 +1,141 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/winik100/NoPenNoPaper/internal/core"
+	"github.com/winik100/NoPenNoPaper/internal/validators"
+)
+
+// number of sanity checks shown in a character's history
+const sanityHistoryLength = 20
+
+type sanityCheckForm struct {
+	Source                   string
+	Loss                     string
+	validators.FormValidator `schema:"-"`
+}
+
+func (app *application) sanityCheckPost(w http.ResponseWriter, r *http.Request) {
+	character, ok := app.loadCharacter(w, r)
+	if !ok {
+		return
+	}
+
+	var form sanityCheckForm
+	err := app.decodePostForm(r, &form)
+	if err != nil {
+		app.clientError(w, http.StatusBadRequest)
+		return
+	}
+
+	loss, err := core.ParseSanityLoss(form.Loss)
+	form.CheckField(err == nil, "Loss", "Ungültiger Verlust, z.B. 1/1d6 oder 0/1d4+1.")
+	form.CheckField(validators.MaxChars(form.Loss, 50), "Loss", "Maximal 50 Zeichen erlaubt.")
+	form.CheckField(validators.MaxChars(form.Source, 255), "Source", "Maximal 255 Zeichen erlaubt.")
+	form.CheckField(character.Tracks("STA"), "Loss", "Dieser Charakter hat keine Stabilität.")
+	if !form.Valid() {
+		data := app.newTemplateData(r)
+		data.Form = form
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		app.renderHtmx(w, r, "sanityCheckFailed", sanityCheckInvalidTmpl, data)
+		return
+	}
+
+	check := character.SanityCheck(form.Source, loss)
+	check.ID, err = app.sanity.Check(check)
+	if err != nil {
+		app.serverError(w, r, err)
+		return
+	}
+
+	app.events.Publish(characterTopic(character.ID), eventSanity)
+	app.publishCharacter(character, eventStats)
+
+	data := app.newTemplateData(r)
+	data.Form = check
+	w.WriteHeader(http.StatusOK)
+	app.renderHtmx(w, r, "sanityCheckResult", sanityCheckResultTmpl, data)
+}
+
+// only the GM running the character's campaign decides when the investigator has overcome their insanity
+func (app *application) recoverSanityPost(w http.ResponseWriter, r *http.Request) {
+	character, ok := app.loadCharacter(w, r)
+	if !ok {
+		return
+	}
+
+	campaign, err := app.characterCampaign(character)
+	if err != nil {
+		app.serverError(w, r, err)
+		return
+	}
+	if !campaign.IsRunBy(app.sessionManager.GetInt(r.Context(), authenticatedUserIdKey)) {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	err = app.sanity.Recover(character.ID)
+	if err != nil {
+		app.serverError(w, r, err)
+		return
+	}
+
+	app.publishCharacter(character, eventStats)
+	app.sessionManager.Put(r.Context(), "flash", fmt.Sprintf("%s hat den Wahnsinn überwunden.", character.Info.Name))
+	http.Redirect(w, r, fmt.Sprintf("/characters/%d", character.ID), http.StatusSeeOther)
+}
+
+// a new in-game day resets the sanity lost towards indefinite insanity
+func (app *application) newDayPost(w http.ResponseWriter, r *http.Request) {
+	campaign, ok := app.loadCampaign(w, r)
+	if !ok {
+		return
+	}
+
+	characters, err := app.characters.GetAllInCampaign(campaign.ID)
+	if err != nil {
+		app.serverError(w, r, err)
+		return
+	}
+
+	err = app.sanity.NewDay(campaign.ID)
+	if err != nil {
+		app.serverError(w, r, err)
+		return
+	}
+
+	for _, character := range characters {
+		app.events.Publish(characterTopic(character.ID), eventStats)
+	}
+	app.events.Publish(campaignTopic(campaign.ID), eventStats)
+	app.sessionManager.Put(r.Context(), "flash", "Ein neuer Spieltag beginnt, der tägliche Stabilitätsverlust wurde zurückgesetzt.")
+	http.Redirect(w, r, fmt.Sprintf("/campaigns/%d/dashboard", campaign.ID), http.StatusSeeOther)
+}
+
+// Cthulhu-Mythos lowers the maximum sanity, every other skill leaves it alone
+func (app *application) capSanity(characterId int, skill string, value int) error {
+	if skill != core.CthulhuMythos {
+		return nil
+	}
+
+	err := app.characters.CapSanity(characterId, core.SanityLimit-value)
+	if err != nil {
+		return err
+	}
+	app.events.Publish(characterTopic(characterId), eventStats)
+	return nil
+}
+
+const sanityCheckInvalidTmpl = `<div id="lastSanityCheck" class="failed">
+					{{range .Form.FieldErrors}}<p>{{.}}</p>{{end}}
+				</div>`
+
+const sanityCheckResultTmpl = `<div id="lastSanityCheck"{{if not .Form.Succeeded}} class="failed"{{end}}>
+					{{with .Form.Source}}{{.}}: {{end}}Stabilitätswurf ({{.Form.Value}}): {{.Form.Result}} - {{.Form.Level}},
+					{{.Form.Lost}} Stabilität verloren ({{.Form.Loss}})
+					{{if .Form.Temporary}}<p>Zeitweiliger Wahnsinn!</p>{{end}}
+					{{if .Form.Indefinite}}<p>Unbestimmter Wahnsinn!</p>{{end}}
+					{{with .Form.Bout}}<p>Anfall: {{.}}</p>{{end}}
+				</div>`
@@ -0,0 +1,187 @@
+package main
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/winik100/NoPenNoPaper/internal/models/mocks"
+	"github.com/winik100/NoPenNoPaper/internal/testHelpers"
+)
+
+func TestSanityCheckPost(t *testing.T) {
+	app := newTestApplication(t)
+
+	ts := newTestServer(t, app.sessionManager.LoadAndSave(app.mockSession(noSurf(app.authenticate(app.requireAuthentication(app.routesNoMW()))),
+		map[string]any{
+			authenticatedUserIdKey:   mocks.MockPlayer.ID,
+			authenticatedUserNameKey: mocks.MockPlayer.Name,
+		})))
+	defer ts.Close()
+
+	_, _, body := ts.get(t, "/characters/1")
+	testHelpers.StringContains(t, body, "Drachenfeuer über King&#39;s Landing (1/1d6)")
+	validCSRF := extractCSRFToken(t, body)
+
+	tests := []struct {
+		name        string
+		path        string
+		source      string
+		loss        string
+		wantCode    int
+		wantContent string
+	}{
+		{
+			name:        "Valid",
+			path:        "/characters/1/sanity",
+			source:      "Ein Drache",
+			loss:        "1/1d6",
+			wantCode:    http.StatusOK,
+			wantContent: "Ein Drache: Stabilitätswurf (50): ",
+		},
+		{
+			name:        "Markup In Source",
+			path:        "/characters/1/sanity",
+			source:      "<script>alert(1)</script>",
+			loss:        "1/1d6",
+			wantCode:    http.StatusOK,
+			wantContent: "&lt;script&gt;alert(1)&lt;/script&gt;: Stabilitätswurf (50): ",
+		},
+		{
+			name:        "Flat Loss",
+			path:        "/characters/1/sanity",
+			loss:        "0/2",
+			wantCode:    http.StatusOK,
+			wantContent: "Stabilitätswurf (50): ",
+		},
+		{
+			name:        "Dice With Modifier",
+			path:        "/characters/1/sanity",
+			loss:        "1d4/2d6+1",
+			wantCode:    http.StatusOK,
+			wantContent: "(1d4/2d6&#43;1)",
+		},
+		{
+			name:        "Missing Slash",
+			path:        "/characters/1/sanity",
+			loss:        "1d6",
+			wantCode:    http.StatusUnprocessableEntity,
+			wantContent: "Ungültiger Verlust",
+		},
+		{
+			name:        "Garbage",
+			path:        "/characters/1/sanity",
+			loss:        "viel/mehr",
+			wantCode:    http.StatusUnprocessableEntity,
+			wantContent: "Ungültiger Verlust",
+		},
+		{
+			name:     "Nonexistent Character",
+			path:     "/characters/69/sanity",
+			loss:     "1/1d6",
+			wantCode: http.StatusNotFound,
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			form := url.Values{}
+			form.Add("Source", testCase.source)
+			form.Add("Loss", testCase.loss)
+			form.Add("csrf_token", validCSRF)
+
+			code, _, body := ts.postForm(t, testCase.path, form)
+
+			testHelpers.Equal(t, code, testCase.wantCode)
+			if testCase.wantContent != "" {
+				testHelpers.StringContains(t, body, testCase.wantContent)
+			}
+		})
+	}
+}
+
+func TestRecoverSanityPost(t *testing.T) {
+	tests := []struct {
+		name      string
+		userId    int
+		userName  string
+		path      string
+		wantCode  int
+		wantFlash string
+	}{
+		{
+			name:      "GM",
+			userId:    mocks.MockGM.ID,
+			userName:  mocks.MockGM.Name,
+			path:      "/characters/1/sanity/recover",
+			wantCode:  http.StatusSeeOther,
+			wantFlash: "Otto Hightower hat den Wahnsinn überwunden.",
+		},
+		{
+			name:     "Player",
+			userId:   mocks.MockPlayer.ID,
+			userName: mocks.MockPlayer.Name,
+			path:     "/characters/1/sanity/recover",
+			wantCode: http.StatusUnauthorized,
+		},
+		{
+			name:     "Nonexistent Character",
+			userId:   mocks.MockGM.ID,
+			userName: mocks.MockGM.Name,
+			path:     "/characters/69/sanity/recover",
+			wantCode: http.StatusNotFound,
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			app := newTestApplication(t)
+
+			ts := newTestServer(t, app.sessionManager.LoadAndSave(app.mockSession(noSurf(app.authenticate(app.requireAuthentication(app.routesNoMW()))),
+				map[string]any{
+					authenticatedUserIdKey:   testCase.userId,
+					authenticatedUserNameKey: testCase.userName,
+				})))
+			defer ts.Close()
+
+			_, _, body := ts.get(t, "/characters/1")
+			validCSRF := extractCSRFToken(t, body)
+
+			form := url.Values{}
+			form.Add("csrf_token", validCSRF)
+			code, header, _ := ts.postForm(t, testCase.path, form)
+
+			testHelpers.Equal(t, code, testCase.wantCode)
+			if testCase.wantFlash != "" {
+				testHelpers.Equal(t, header.Get("Location"), "/characters/1")
+				_, _, body = ts.get(t, "/characters/1")
+				testHelpers.StringContains(t, body, testCase.wantFlash)
+			}
+		})
+	}
+}
+
+func TestNewDayPost(t *testing.T) {
+	app := newTestApplication(t)
+
+	ts := newTestServer(t, app.sessionManager.LoadAndSave(app.mockSession(noSurf(app.authenticate(app.requireAuthentication(app.routesNoMW()))),
+		map[string]any{
+			authenticatedUserIdKey:   mocks.MockGM.ID,
+			authenticatedUserNameKey: mocks.MockGM.Name,
+		})))
+	defer ts.Close()
+
+	_, _, body := ts.get(t, "/campaigns/1/dashboard")
+	testHelpers.StringContains(t, body, "Neuer Spieltag")
+	validCSRF := extractCSRFToken(t, body)
+
+	form := url.Values{}
+	form.Add("csrf_token", validCSRF)
+	code, header, _ := ts.postForm(t, "/campaigns/1/newDay", form)
+
+	testHelpers.Equal(t, code, http.StatusSeeOther)
+	testHelpers.Equal(t, header.Get("Location"), "/campaigns/1/dashboard")
+
+	_, _, body = ts.get(t, "/campaigns/1/dashboard")
+	testHelpers.StringContains(t, body, "Ein neuer Spieltag beginnt")
+}
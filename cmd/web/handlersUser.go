@@ -40,20 +40,47 @@ func (app *application) user(w http.ResponseWriter, r *http.Request) {
 	}
 
 	data := app.newTemplateData(r)
+	characters, err := app.characters.GetAllFrom(userId)
+	if err != nil {
+		app.serverError(w, r, err)
+		return
+	}
+	data.Characters = characters
+
 	if role == core.RoleGM {
-		characters, err := app.characters.GetAll()
+		campaigns, err := app.campaigns.GetAllFrom(userId)
 		if err != nil {
 			app.serverError(w, r, err)
 			return
 		}
-		data.Characters = characters
+		for i, campaign := range campaigns {
+			campaignCharacters, err := app.characters.GetAllInCampaign(campaign.ID)
+			if err != nil {
+				app.serverError(w, r, err)
+				return
+			}
+			campaigns[i].Characters = campaignCharacters
+		}
+		data.Campaigns = campaigns
 	} else {
-		characters, err := app.characters.GetAllFrom(userId)
+		campaigns, err := app.campaigns.GetAllFor(userId)
 		if err != nil {
 			app.serverError(w, r, err)
 			return
 		}
-		data.Characters = characters
+		handouts, err := app.campaigns.GetHandoutsFor(userId)
+		if err != nil {
+			app.serverError(w, r, err)
+			return
+		}
+		for i, campaign := range campaigns {
+			for _, handout := range handouts {
+				if handout.CampaignID == campaign.ID {
+					campaigns[i].Handouts = append(campaigns[i].Handouts, handout)
+				}
+			}
+		}
+		data.Campaigns = campaigns
 	}
 	data.User = user
 
@@ -22,7 +22,7 @@ func TestUser(t *testing.T) {
 			name:                  "Authenticated as Player",
 			authenticatedUserId:   mocks.MockPlayer.ID,
 			authenticatedUserName: mocks.MockPlayer.Name,
-			wantContent:           []string{"<td><a href='/characters/1'>Otto Hightower</a></td>"},
+			wantContent:           []string{"<td><a href='/characters/1'>Otto Hightower</a></td>", "<summary>Karte von Westeros</summary>"},
 			wantCode:              http.StatusOK,
 		},
 		{
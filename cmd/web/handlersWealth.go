@@ -0,0 +1,156 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/winik100/NoPenNoPaper/internal/core"
+	"github.com/winik100/NoPenNoPaper/internal/models"
+	"github.com/winik100/NoPenNoPaper/internal/validators"
+)
+
+const maxLedgerDescriptionLength = 255
+
+type ledgerForm struct {
+	Description string
+	Amount      string
+	Purchase    bool
+}
+
+type eraForm struct {
+	Era core.Era
+}
+
+// records a purchase or an income in the character's cash ledger
+func (app *application) ledgerPost(w http.ResponseWriter, r *http.Request) {
+	character, ok := app.loadCharacter(w, r)
+	if !ok {
+		return
+	}
+
+	var form ledgerForm
+	err := app.decodePostForm(r, &form)
+	if err != nil {
+		app.clientError(w, http.StatusBadRequest)
+		return
+	}
+
+	redirect := fmt.Sprintf("/characters/%d", character.ID)
+	form.Description = strings.TrimSpace(form.Description)
+	if !validators.NotBlank(form.Description) || !validators.MaxChars(form.Description, maxLedgerDescriptionLength) {
+		app.sessionManager.Put(r.Context(), "flash", fmt.Sprintf("Die Buchung braucht eine Beschreibung mit höchstens %d Zeichen.", maxLedgerDescriptionLength))
+		http.Redirect(w, r, redirect, http.StatusSeeOther)
+		return
+	}
+	amount, err := core.ParseMoney(form.Amount)
+	if err != nil || amount == 0 {
+		app.sessionManager.Put(r.Context(), "flash", "Bitte einen Betrag in Dollar angeben, z.B. 12,50.")
+		http.Redirect(w, r, redirect, http.StatusSeeOther)
+		return
+	}
+	if form.Purchase {
+		amount = -amount
+	}
+
+	campaign, err := app.characterCampaign(character)
+	if err != nil {
+		app.serverError(w, r, err)
+		return
+	}
+
+	_, err = app.ledger.Insert(core.LedgerEntry{
+		CharacterID: character.ID,
+		Description: form.Description,
+		Amount:      amount,
+		BookedAt:    time.Now(),
+	}, character.Wealth(campaign.Era).Cash)
+	if err != nil {
+		app.serverError(w, r, err)
+		return
+	}
+
+	app.events.Publish(characterTopic(character.ID), eventCash)
+	http.Redirect(w, r, redirect, http.StatusSeeOther)
+}
+
+func (app *application) deleteLedgerEntryPost(w http.ResponseWriter, r *http.Request) {
+	characterId, ok := app.characterIdFromPath(w, r)
+	if !ok {
+		return
+	}
+	entryId, err := strconv.Atoi(r.PathValue("entryId"))
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	err = app.ledger.Delete(characterId, entryId)
+	if err != nil {
+		app.serverError(w, r, err)
+		return
+	}
+
+	app.events.Publish(characterTopic(characterId), eventCash)
+	http.Redirect(w, r, fmt.Sprintf("/characters/%d", characterId), http.StatusSeeOther)
+}
+
+func (app *application) campaignEraPost(w http.ResponseWriter, r *http.Request) {
+	campaign, ok := app.loadCampaign(w, r)
+	if !ok {
+		return
+	}
+
+	var form eraForm
+	err := app.decodePostForm(r, &form)
+	if err != nil {
+		app.clientError(w, http.StatusBadRequest)
+		return
+	}
+
+	if !validators.PermittedValue(form.Era, core.Classic, core.Modern) {
+		app.clientError(w, http.StatusUnprocessableEntity)
+		return
+	}
+
+	err = app.campaigns.SetEra(campaign.ID, form.Era)
+	if err != nil {
+		app.serverError(w, r, err)
+		return
+	}
+
+	app.sessionManager.Put(r.Context(), "flash", fmt.Sprintf("Die Kampagne spielt jetzt in der Epoche %s.", form.Era))
+	http.Redirect(w, r, fmt.Sprintf("/campaigns/%d", campaign.ID), http.StatusSeeOther)
+}
+
+// the cash the character's ledger starts from. until the first booking that's the cash their credit rating provides.
+func (app *application) ledgerOpening(characterId int, wealth core.Wealth) (core.Money, error) {
+	opening, err := app.ledger.GetOpening(characterId)
+	if err != nil {
+		if errors.Is(err, models.ErrNoRecord) {
+			return wealth.Cash, nil
+		}
+		return 0, err
+	}
+	return opening, nil
+}
+
+// the campaign the character takes part in. characters outside of one get an empty campaign
+// set in the 1920s, which nobody runs.
+func (app *application) characterCampaign(character core.Character) (core.Campaign, error) {
+	if character.CampaignID == 0 {
+		return core.Campaign{Era: core.Classic}, nil
+	}
+
+	campaign, err := app.campaigns.Get(character.CampaignID)
+	if err != nil {
+		if errors.Is(err, models.ErrNoRecord) {
+			return core.Campaign{Era: core.Classic}, nil
+		}
+		return core.Campaign{}, err
+	}
+	return campaign, nil
+}
@@ -0,0 +1,171 @@
+package main
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/winik100/NoPenNoPaper/internal/models/mocks"
+	"github.com/winik100/NoPenNoPaper/internal/testHelpers"
+)
+
+func TestWealth(t *testing.T) {
+	app := newTestApplication(t)
+
+	ts := newTestServer(t, app.sessionManager.LoadAndSave(app.mockSession(noSurf(app.authenticate(app.requireAuthentication(app.routesNoMW()))),
+		map[string]any{
+			authenticatedUserIdKey:   mocks.MockPlayer.ID,
+			authenticatedUserNameKey: mocks.MockPlayer.Name,
+		})))
+	defer ts.Close()
+
+	code, _, body := ts.get(t, "/characters/1")
+
+	// no credit rating left, so only the half dollar of the penniless. the ledger keeps the
+	// opening cash recorded with its first entry though.
+	testHelpers.Equal(t, code, http.StatusOK)
+	testHelpers.StringContains(t, body, "<td>0 (1920er)</td>")
+	testHelpers.StringContains(t, body, "<td>Mittellos</td>")
+	testHelpers.StringContains(t, body, "<td>0,50 $</td>")
+	testHelpers.StringContains(t, body, "<td>Anfangsbestand</td>")
+	testHelpers.StringContains(t, body, "<td>15 $</td>")
+	testHelpers.StringContains(t, body, "<td>Pacht aus Oldtown</td>")
+	testHelpers.StringContains(t, body, "<td>25 $</td>")
+	testHelpers.StringContains(t, body, "<td class='failed'>-2,50 $</td>")
+	testHelpers.StringContains(t, body, "Kontostand: <span>22,50 $</span>")
+}
+
+func TestLedgerPost(t *testing.T) {
+	app := newTestApplication(t)
+
+	ts := newTestServer(t, app.sessionManager.LoadAndSave(app.mockSession(noSurf(app.authenticate(app.requireAuthentication(app.routesNoMW()))),
+		map[string]any{
+			authenticatedUserIdKey:   mocks.MockPlayer.ID,
+			authenticatedUserNameKey: mocks.MockPlayer.Name,
+		})))
+	defer ts.Close()
+
+	_, _, body := ts.get(t, "/characters/1")
+	validCSRF := extractCSRFToken(t, body)
+
+	tests := []struct {
+		name        string
+		path        string
+		description string
+		amount      string
+		wantCode    int
+		wantFlash   string
+	}{
+		{
+			name:        "Purchase",
+			path:        "/characters/1/ledger",
+			description: "Zugfahrt nach Arkham",
+			amount:      "4,50",
+			wantCode:    http.StatusSeeOther,
+		},
+		{
+			name:        "Blank Description",
+			path:        "/characters/1/ledger",
+			description: " ",
+			amount:      "4,50",
+			wantCode:    http.StatusSeeOther,
+			wantFlash:   "Die Buchung braucht eine Beschreibung mit höchstens 255 Zeichen.",
+		},
+		{
+			name:        "Invalid Amount",
+			path:        "/characters/1/ledger",
+			description: "Zugfahrt nach Arkham",
+			amount:      "viel",
+			wantCode:    http.StatusSeeOther,
+			wantFlash:   "Bitte einen Betrag in Dollar angeben, z.B. 12,50.",
+		},
+		{
+			name:        "Zero Amount",
+			path:        "/characters/1/ledger",
+			description: "Zugfahrt nach Arkham",
+			amount:      "0",
+			wantCode:    http.StatusSeeOther,
+			wantFlash:   "Bitte einen Betrag in Dollar angeben, z.B. 12,50.",
+		},
+		{
+			name:        "Nonexistent Character",
+			path:        "/characters/69/ledger",
+			description: "Zugfahrt nach Arkham",
+			amount:      "4,50",
+			wantCode:    http.StatusNotFound,
+		},
+		{
+			name:     "Delete",
+			path:     "/characters/1/ledger/2/delete",
+			wantCode: http.StatusSeeOther,
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			form := url.Values{}
+			form.Add("Description", testCase.description)
+			form.Add("Amount", testCase.amount)
+			form.Add("Purchase", "true")
+			form.Add("csrf_token", validCSRF)
+
+			code, _, _ := ts.postForm(t, testCase.path, form)
+
+			testHelpers.Equal(t, code, testCase.wantCode)
+			if testCase.wantFlash != "" {
+				_, _, body := ts.get(t, "/characters/1")
+				testHelpers.StringContains(t, body, testCase.wantFlash)
+			}
+		})
+	}
+}
+
+func TestCampaignEraPost(t *testing.T) {
+	app := newTestApplication(t)
+
+	ts := newTestServer(t, app.sessionManager.LoadAndSave(app.mockSession(noSurf(app.authenticate(app.requireAuthentication(app.routesNoMW()))),
+		map[string]any{
+			authenticatedUserIdKey:   mocks.MockGM.ID,
+			authenticatedUserNameKey: mocks.MockGM.Name,
+		})))
+	defer ts.Close()
+
+	_, _, body := ts.get(t, "/campaigns/1")
+	validCSRF := extractCSRFToken(t, body)
+	testHelpers.StringContains(t, body, "<option value='classic' selected>1920er</option>")
+
+	tests := []struct {
+		name      string
+		era       string
+		wantCode  int
+		wantFlash string
+	}{
+		{
+			name:      "Modern",
+			era:       "modern",
+			wantCode:  http.StatusSeeOther,
+			wantFlash: "Die Kampagne spielt jetzt in der Epoche Gegenwart.",
+		},
+		{
+			name:     "Unknown Era",
+			era:      "steampunk",
+			wantCode: http.StatusUnprocessableEntity,
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			form := url.Values{}
+			form.Add("Era", testCase.era)
+			form.Add("csrf_token", validCSRF)
+
+			code, _, _ := ts.postForm(t, "/campaigns/1/era", form)
+
+			testHelpers.Equal(t, code, testCase.wantCode)
+			if testCase.wantFlash != "" {
+				_, _, body := ts.get(t, "/campaigns/1")
+				testHelpers.StringContains(t, body, testCase.wantFlash)
+			}
+		})
+	}
+}
@@ -0,0 +1,187 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/winik100/NoPenNoPaper/internal/core"
+	"github.com/winik100/NoPenNoPaper/internal/models"
+)
+
+type weaponForm struct {
+	WeaponId int
+	Bonus    int
+}
+
+type attackResult struct {
+	Weapon core.Weapon
+	Roll   core.Roll
+}
+
+// attack roll against the weapon's skill, characters without the skill attack with its base value
+func (app *application) attackPost(w http.ResponseWriter, r *http.Request) {
+	character, ok := app.loadCharacter(w, r)
+	if !ok {
+		return
+	}
+
+	var form weaponForm
+	err := app.decodePostForm(r, &form)
+	if err != nil {
+		app.clientError(w, http.StatusBadRequest)
+		return
+	}
+
+	weapon, ok := character.CarriedWeapon(form.WeaponId)
+	if !ok || form.Bonus < -maxBonusDice || form.Bonus > maxBonusDice {
+		app.clientError(w, http.StatusUnprocessableEntity)
+		return
+	}
+
+	value, ok, err := app.skillValue(character, weapon.Skill)
+	if err != nil {
+		app.serverError(w, r, err)
+		return
+	}
+	if !ok {
+		app.clientError(w, http.StatusUnprocessableEntity)
+		return
+	}
+
+	roll := core.NewRoll(character.ID, weapon.Skill, value, form.Bonus)
+	roll.Source = core.SourceAttack
+	roll.ID, err = app.rolls.Insert(roll)
+	if err != nil {
+		app.serverError(w, r, err)
+		return
+	}
+	app.events.Publish(characterTopic(character.ID), eventRolls)
+
+	err = app.tickSkill(character, roll)
+	if err != nil {
+		app.serverError(w, r, err)
+		return
+	}
+
+	data := app.newTemplateData(r)
+	data.Character = character
+	data.Form = attackResult{Weapon: weapon, Roll: roll}
+	w.WriteHeader(http.StatusOK)
+	app.renderHtmx(w, r, "attackResult", attackResultTmpl, data)
+}
+
+func (app *application) weaponDamagePost(w http.ResponseWriter, r *http.Request) {
+	character, ok := app.loadCharacter(w, r)
+	if !ok {
+		return
+	}
+
+	var form weaponForm
+	err := app.decodePostForm(r, &form)
+	if err != nil {
+		app.clientError(w, http.StatusBadRequest)
+		return
+	}
+
+	weapon, ok := character.CarriedWeapon(form.WeaponId)
+	if !ok {
+		app.clientError(w, http.StatusUnprocessableEntity)
+		return
+	}
+
+	damage, err := weapon.RollDamage(character.Attributes.DB)
+	if err != nil {
+		app.serverError(w, r, err)
+		return
+	}
+
+	data := app.newTemplateData(r)
+	data.Character = character
+	data.Form = damage
+	w.WriteHeader(http.StatusOK)
+	app.renderHtmx(w, r, "weaponDamage", weaponDamageTmpl, data)
+}
+
+func (app *application) carryWeaponPost(w http.ResponseWriter, r *http.Request) {
+	character, ok := app.loadCharacter(w, r)
+	if !ok {
+		return
+	}
+
+	var form weaponForm
+	err := app.decodePostForm(r, &form)
+	if err != nil {
+		app.clientError(w, http.StatusBadRequest)
+		return
+	}
+
+	redirect := fmt.Sprintf("/characters/%d", character.ID)
+	weapon, err := app.weapons.Get(form.WeaponId)
+	if err != nil {
+		if errors.Is(err, models.ErrNoRecord) {
+			app.sessionManager.Put(r.Context(), "flash", "Unbekannte Waffe.")
+			http.Redirect(w, r, redirect, http.StatusSeeOther)
+		} else {
+			app.serverError(w, r, err)
+		}
+		return
+	}
+
+	err = app.weapons.Carry(character.ID, weapon.ID)
+	if err != nil {
+		if errors.Is(err, models.ErrAlreadyCarried) {
+			app.sessionManager.Put(r.Context(), "flash", fmt.Sprintf("%s trägt bereits %s.", character.Info.Name, weapon.Name))
+			http.Redirect(w, r, redirect, http.StatusSeeOther)
+		} else {
+			app.serverError(w, r, err)
+		}
+		return
+	}
+
+	app.events.Publish(characterTopic(character.ID), eventWeapons)
+	app.sessionManager.Put(r.Context(), "flash", fmt.Sprintf("%s trägt jetzt %s.", character.Info.Name, weapon.Name))
+	http.Redirect(w, r, redirect, http.StatusSeeOther)
+}
+
+func (app *application) dropWeaponPost(w http.ResponseWriter, r *http.Request) {
+	characterId, ok := app.characterIdFromPath(w, r)
+	if !ok {
+		return
+	}
+
+	var form weaponForm
+	err := app.decodePostForm(r, &form)
+	if err != nil {
+		app.clientError(w, http.StatusBadRequest)
+		return
+	}
+
+	err = app.weapons.Drop(characterId, form.WeaponId)
+	if err != nil {
+		app.serverError(w, r, err)
+		return
+	}
+
+	app.events.Publish(characterTopic(characterId), eventWeapons)
+	http.Redirect(w, r, fmt.Sprintf("/characters/%d", characterId), http.StatusSeeOther)
+}
+
+// a jammed weapon can't deal damage, a successful attack offers the damage roll
+const attackResultTmpl = `<div id="lastAttack"{{if not .Form.Roll.Succeeded}} class="failed"{{end}}>
+					{{.Form.Weapon.Name}}, {{.Form.Roll.Name}} ({{.Form.Roll.Value}}): {{.Form.Roll.Result}} - {{.Form.Roll.Level}}
+					{{with .Form.Roll.BonusLabel}}({{.}}){{end}}
+					{{if .Form.Weapon.Malfunctions .Form.Roll.Result}}
+					<p>Ladehemmung!</p>
+					{{else if .Form.Roll.Succeeded}}
+					<form hx-post="/characters/{{.Character.ID}}/weaponDamage" hx-target="#lastAttack" hx-swap="outerHTML">
+						<input type="hidden" name="csrf_token" value="{{.CSRFToken}}">
+						<input type="hidden" name="WeaponId" value="{{.Form.Weapon.ID}}">
+						<button type="submit">Schaden würfeln</button>
+					</form>
+					{{end}}
+				</div>`
+
+const weaponDamageTmpl = `<div id="lastAttack">
+					{{.Form.Weapon.Name}}: {{.Form.Total}} Schaden ({{.Form.Weapon.Damage}}{{if .Form.Weapon.AddsDamageBonus}}, Schadensbonus {{.Form.DamageBonus}}{{end}}{{with .Form.Dice}}, Würfe: {{range $i, $die := .}}{{if $i}}, {{end}}{{$die}}{{end}}{{end}})
+				</div>`
@@ -0,0 +1,175 @@
+package main
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+	"testing"
+
+	"github.com/winik100/NoPenNoPaper/internal/models/mocks"
+	"github.com/winik100/NoPenNoPaper/internal/testHelpers"
+)
+
+func TestWeaponRollsPost(t *testing.T) {
+	app := newTestApplication(t)
+
+	ts := newTestServer(t, app.sessionManager.LoadAndSave(app.mockSession(noSurf(app.authenticate(app.requireAuthentication(app.routesNoMW()))),
+		map[string]any{
+			authenticatedUserIdKey:   mocks.MockPlayer.ID,
+			authenticatedUserNameKey: mocks.MockPlayer.Name,
+		})))
+	defer ts.Close()
+
+	_, _, body := ts.get(t, "/characters/1")
+	testHelpers.StringContains(t, body, "<div id='weapons'>")
+	testHelpers.StringContains(t, body, "<option value=\"2\">Armbrust (Armbrust,")
+	validCSRF := extractCSRFToken(t, body)
+
+	tests := []struct {
+		name        string
+		path        string
+		weaponId    int
+		bonus       string
+		wantCode    int
+		wantContent string
+	}{
+		{
+			name:        "Attack With Base Value",
+			path:        "/characters/1/attack",
+			weaponId:    mocks.MockWeaponDagger.ID,
+			bonus:       "0",
+			wantCode:    http.StatusOK,
+			wantContent: "Dolch, Schwertkampf (10):",
+		},
+		{
+			name:        "Attack With Bonus Die",
+			path:        "/characters/1/attack",
+			weaponId:    mocks.MockWeaponDagger.ID,
+			bonus:       "1",
+			wantCode:    http.StatusOK,
+			wantContent: "(1 Bonuswürfel)",
+		},
+		{
+			name:     "Attack With Weapon Not Carried",
+			path:     "/characters/1/attack",
+			weaponId: mocks.MockWeaponCrossbow.ID,
+			bonus:    "0",
+			wantCode: http.StatusUnprocessableEntity,
+		},
+		{
+			name:     "Attack With Too Many Bonus Dice",
+			path:     "/characters/1/attack",
+			weaponId: mocks.MockWeaponDagger.ID,
+			bonus:    "3",
+			wantCode: http.StatusUnprocessableEntity,
+		},
+		{
+			name:     "Attack Of Nonexistent Character",
+			path:     "/characters/69/attack",
+			weaponId: mocks.MockWeaponDagger.ID,
+			bonus:    "0",
+			wantCode: http.StatusNotFound,
+		},
+		{
+			name:        "Damage With Damage Bonus",
+			path:        "/characters/1/weaponDamage",
+			weaponId:    mocks.MockWeaponDagger.ID,
+			wantCode:    http.StatusOK,
+			wantContent: "Schaden (1d4&#43;DB, Schadensbonus 0, Würfe: ",
+		},
+		{
+			name:     "Damage With Weapon Not Carried",
+			path:     "/characters/1/weaponDamage",
+			weaponId: mocks.MockWeaponCrossbow.ID,
+			wantCode: http.StatusUnprocessableEntity,
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			form := url.Values{}
+			form.Add("WeaponId", strconv.Itoa(testCase.weaponId))
+			form.Add("Bonus", testCase.bonus)
+			form.Add("csrf_token", validCSRF)
+
+			code, _, body := ts.postForm(t, testCase.path, form)
+
+			testHelpers.Equal(t, code, testCase.wantCode)
+			if testCase.wantContent != "" {
+				testHelpers.StringContains(t, body, testCase.wantContent)
+			}
+		})
+	}
+}
+
+func TestCarryWeaponPost(t *testing.T) {
+	app := newTestApplication(t)
+
+	ts := newTestServer(t, app.sessionManager.LoadAndSave(app.mockSession(noSurf(app.authenticate(app.requireAuthentication(app.routesNoMW()))),
+		map[string]any{
+			authenticatedUserIdKey:   mocks.MockPlayer.ID,
+			authenticatedUserNameKey: mocks.MockPlayer.Name,
+		})))
+	defer ts.Close()
+
+	_, _, body := ts.get(t, "/characters/1")
+	validCSRF := extractCSRFToken(t, body)
+
+	tests := []struct {
+		name      string
+		path      string
+		weaponId  int
+		wantCode  int
+		wantFlash string
+	}{
+		{
+			name:      "Carry",
+			path:      "/characters/1/carryWeapon",
+			weaponId:  mocks.MockWeaponCrossbow.ID,
+			wantCode:  http.StatusSeeOther,
+			wantFlash: "Otto Hightower trägt jetzt Armbrust.",
+		},
+		{
+			name:      "Already Carried",
+			path:      "/characters/1/carryWeapon",
+			weaponId:  mocks.MockWeaponDagger.ID,
+			wantCode:  http.StatusSeeOther,
+			wantFlash: "Otto Hightower trägt bereits Dolch.",
+		},
+		{
+			name:      "Unknown Weapon",
+			path:      "/characters/1/carryWeapon",
+			weaponId:  99,
+			wantCode:  http.StatusSeeOther,
+			wantFlash: "Unbekannte Waffe.",
+		},
+		{
+			name:     "Nonexistent Character",
+			path:     "/characters/69/carryWeapon",
+			weaponId: mocks.MockWeaponCrossbow.ID,
+			wantCode: http.StatusNotFound,
+		},
+		{
+			name:     "Drop",
+			path:     "/characters/1/dropWeapon",
+			weaponId: mocks.MockWeaponDagger.ID,
+			wantCode: http.StatusSeeOther,
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			form := url.Values{}
+			form.Add("WeaponId", strconv.Itoa(testCase.weaponId))
+			form.Add("csrf_token", validCSRF)
+
+			code, _, _ := ts.postForm(t, testCase.path, form)
+
+			testHelpers.Equal(t, code, testCase.wantCode)
+			if testCase.wantFlash != "" {
+				_, _, body := ts.get(t, "/characters/1")
+				testHelpers.StringContains(t, body, testCase.wantFlash)
+			}
+		})
+	}
+}
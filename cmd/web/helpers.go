@@ -2,10 +2,16 @@ package main
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
+	"html/template"
 	"net/http"
-	"text/template"
+	"slices"
+	"strconv"
+	"strings"
 
+	"github.com/winik100/NoPenNoPaper/internal/core"
+	"github.com/winik100/NoPenNoPaper/internal/models"
 	"github.com/winik100/NoPenNoPaper/internal/validators"
 )
 
@@ -40,6 +46,36 @@ func (app *application) renderHtmx(w http.ResponseWriter, r *http.Request, templ
 	}
 }
 
+// the id of the character in the request's path, which the access checks were made for. responds
+// with 404 if it isn't a number.
+func (app *application) characterIdFromPath(w http.ResponseWriter, r *http.Request) (int, bool) {
+	characterId, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		http.NotFound(w, r)
+		return 0, false
+	}
+	return characterId, true
+}
+
+// the character in the request's path, responds with 404 if there is none.
+func (app *application) loadCharacter(w http.ResponseWriter, r *http.Request) (core.Character, bool) {
+	characterId, ok := app.characterIdFromPath(w, r)
+	if !ok {
+		return core.Character{}, false
+	}
+
+	character, err := app.characters.Get(characterId)
+	if err != nil {
+		if errors.Is(err, models.ErrNoRecord) {
+			http.NotFound(w, r)
+		} else {
+			app.serverError(w, r, err)
+		}
+		return core.Character{}, false
+	}
+	return character, true
+}
+
 func (app *application) decodePostForm(r *http.Request, dst any) error {
 	err := r.ParseForm()
 	if err != nil {
@@ -66,6 +102,19 @@ func (app *application) clientError(w http.ResponseWriter, status int) {
 	http.Error(w, http.StatusText(status), status)
 }
 
+// investigators and NPCs of the campaign
+func (app *application) campaignCharacters(campaignId int) ([]core.Character, error) {
+	investigators, err := app.characters.GetAllInCampaign(campaignId)
+	if err != nil {
+		return nil, err
+	}
+	npcs, err := app.characters.GetNPCsInCampaign(campaignId)
+	if err != nil {
+		return nil, err
+	}
+	return append(investigators, npcs...), nil
+}
+
 func (form *characterForm) InfoChecks() {
 	for key, info := range form.Info.AsMap() {
 		form.CheckField(validators.NotBlank(info), key, "Dieses Feld kann nicht leer sein.")
@@ -79,6 +128,83 @@ func (form *characterForm) InfoChecks() {
 	form.CheckField(validators.PermittedValue(form.Info.Gender, "männlich", "weiblich"), "Geschlecht", "Geschlecht muss männlich oder weiblich sein.")
 }
 
+// the attributes have to be exactly the ones rolled and recorded by the server
+func (form *characterForm) RolledAttributeChecks() {
+	if form.AttributeRolls == nil {
+		form.AddFieldError("Attribute", "Es wurden keine Attribute gewürfelt.")
+		return
+	}
+	form.CheckField(core.MatchesRolls(form.Attributes, form.AttributeRolls), "Attribute", "Die Attribute weichen von den gewürfelten Werten ab.")
+}
+
+// deductions are checked against the attributes as distributed, before they are applied
+func (form *characterForm) AgeChecks() {
+	age, err := strconv.Atoi(form.Info.Age)
+	if err != nil {
+		return
+	}
+
+	bracket := core.AgeBracketFor(age)
+	if bracket.Deduction == 0 {
+		form.CheckField(form.AgeDeductions.Sum() == 0, "Abzüge", "In diesem Alter gibt es keine Abzüge.")
+		return
+	}
+	form.CheckField(bracket.Permits(form.AgeDeductions, form.Attributes), "Abzüge",
+		fmt.Sprintf("Im Alter von %d müssen genau %d Punkte von %s abgezogen werden, kein Attribut darf unter 1 fallen.",
+			age, bracket.Deduction, strings.Join(bracket.DeductFrom, ", ")))
+}
+
+// every point above a skill's base is paid from the occupation or personal interest points, which are
+// computed from the attributes after the age deductions
+func (form *characterForm) SkillPointChecks(occupation core.Occupation, availableSkills core.Skills) error {
+	if len(form.Skills.Name) != len(form.Skills.Value) || len(form.CustomSkills.Name) != len(form.CustomSkills.Value) ||
+		len(form.CustomSkills.Name) != len(form.CustomSkills.Category) {
+		form.AddGenericError("Ungültige Fertigkeiten.")
+		return nil
+	}
+
+	credit := 0
+	var raises []core.SkillRaise
+	for i, name := range form.Skills.Name {
+		j := slices.Index(availableSkills.Name, name)
+		if j < 0 {
+			form.AddFieldError(name, "Unbekannte Fertigkeit.")
+			continue
+		}
+		raise := core.SkillRaise{Name: name, Base: availableSkills.Value[j], Value: form.Skills.Value[i]}
+		form.CheckField(raise.Base <= raise.Value && raise.Value <= 99, name, fmt.Sprintf("Wert muss zwischen %d und 99 liegen.", raise.Base))
+		if name == core.CthulhuMythos {
+			form.CheckField(raise.Value == raise.Base, name, "Cthulhu-Mythos kann bei der Erschaffung nicht gesteigert werden.")
+		}
+		if name == core.CreditRating {
+			credit = raise.Value
+		}
+		raises = append(raises, raise)
+	}
+	for i, name := range form.CustomSkills.Name {
+		raise := core.SkillRaise{Name: name, Category: form.CustomSkills.Category[i],
+			Base: max(models.DefaultForCategory(form.CustomSkills.Category[i]), 0), Value: form.CustomSkills.Value[i]}
+		if raise.Value < raise.Base || raise.Value > 99 {
+			form.AddGenericError(fmt.Sprintf("%s muss zwischen %d und 99 liegen.", name, raise.Base))
+		}
+		raises = append(raises, raise)
+	}
+
+	form.CheckField(occupation.Credit.Min <= credit && credit <= occupation.Credit.Max, core.CreditRating,
+		fmt.Sprintf("Finanzkraft muss als %s zwischen %d und %d liegen.", occupation.Name, occupation.Credit.Min, occupation.Credit.Max))
+
+	age, _ := strconv.Atoi(form.Info.Age)
+	attributes := form.Attributes.Aged(core.AgeBracketFor(age), form.AgeDeductions)
+	budget, err := occupation.SpendSkillPoints(attributes, raises)
+	if err != nil {
+		return err
+	}
+	if budget.Exceeded() {
+		form.AddGenericError(fmt.Sprintf("Zu viele Fertigkeitspunkte verteilt (%s).", budget))
+	}
+	return nil
+}
+
 func (form *characterForm) AttributeChecks() {
 	for key, attr := range form.Attributes.AsMap() {
 		if key != "BW" {
@@ -22,15 +22,27 @@ type application struct {
 	log            *slog.Logger
 	characters     models.CharacterModelInterface
 	users          models.UserModelInterface
+	campaigns      models.CampaignModelInterface
+	chronicle      models.ChronicleModelInterface
+	combats        models.CombatModelInterface
+	chases         models.ChaseModelInterface
+	rolls          models.RollModelInterface
+	sanity         models.SanityModelInterface
+	occupations    models.OccupationModelInterface
+	weapons        models.WeaponModelInterface
+	spells         models.SpellModelInterface
+	tomes          models.TomeModelInterface
+	ledger         models.LedgerModelInterface
 	templateCache  map[string]*template.Template
 	sessionManager *scs.SessionManager
 	formDecoder    *schema.Decoder
+	events         *eventHub
 }
 
 func main() {
 
 	port := flag.String("port", ":8080", "HTTP Port")
-	dsn := flag.String("dsn", "web:testpwweb@tcp(localhost:3307)/NoPenNoPaper", "MySQL Data Source Name")
+	dsn := flag.String("dsn", "web:testpwweb@tcp(localhost:3307)/NoPenNoPaper?parseTime=true", "MySQL Data Source Name")
 	flag.Parse()
 
 	log := slog.New(slog.NewTextHandler(os.Stdout, nil))
@@ -59,9 +71,21 @@ func main() {
 		log:            log,
 		characters:     &models.CharacterModel{DB: db},
 		users:          &models.UserModel{DB: db},
+		campaigns:      &models.CampaignModel{DB: db},
+		chronicle:      &models.ChronicleModel{DB: db},
+		combats:        &models.CombatModel{DB: db},
+		chases:         &models.ChaseModel{DB: db},
+		rolls:          &models.RollModel{DB: db},
+		sanity:         &models.SanityModel{DB: db},
+		occupations:    &models.OccupationModel{DB: db},
+		weapons:        &models.WeaponModel{DB: db},
+		spells:         &models.SpellModel{DB: db},
+		tomes:          &models.TomeModel{DB: db},
+		ledger:         &models.LedgerModel{DB: db},
 		templateCache:  cache,
 		sessionManager: sessionManager,
 		formDecoder:    formDecoder,
+		events:         newEventHub(),
 	}
 
 	tlsConfig := &tls.Config{
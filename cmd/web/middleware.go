@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"strconv"
 
 	"github.com/justinas/nosurf"
 	"github.com/winik100/NoPenNoPaper/internal/models"
@@ -59,6 +60,8 @@ const authenticatedUserIdKey = "authenticatedUserID"
 const authenticatedUserNameKey = "authenticatedUserName"
 const characterIdKey = "characterId"
 const roleKey = "role"
+const redirectAfterLoginKey = "redirectAfterLogin"
+const rolledAttributesKey = "rolledAttributes"
 
 func (app *application) authenticate(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -88,6 +91,10 @@ func (app *application) authenticate(next http.Handler) http.Handler {
 func (app *application) requireAuthentication(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if !app.isAuthenticated(r) {
+			// remember where to go after logging in, e.g. when following an invite link
+			if r.Method == http.MethodGet {
+				app.sessionManager.Put(r.Context(), redirectAfterLoginKey, r.URL.RequestURI())
+			}
 			app.sessionManager.Put(r.Context(), "flash", "Bitte einloggen, um die Anwendung zu benutzen.")
 			http.Redirect(w, r, "/login", http.StatusSeeOther)
 			return
@@ -109,3 +116,84 @@ func (app *application) requireAuthorization(next http.Handler) http.Handler {
 		next.ServeHTTP(w, r)
 	})
 }
+
+func (app *application) requireGM(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !app.isGM(r) {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (app *application) requireCharacterAccess(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		characterId, err := strconv.Atoi(r.PathValue("id"))
+		if err != nil {
+			http.NotFound(w, r)
+			return
+		}
+
+		character, err := app.characters.Get(characterId)
+		if err != nil {
+			if errors.Is(err, models.ErrNoRecord) {
+				http.NotFound(w, r)
+			} else {
+				app.serverError(w, r, err)
+			}
+			return
+		}
+
+		ok, err := app.canAccessCharacter(r, character)
+		if err != nil {
+			app.serverError(w, r, err)
+			return
+		}
+		if !ok {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// gms may access the campaigns they run, players those they are a member of
+func (app *application) requireCampaignAccess(next http.Handler) http.Handler {
+	return app.campaignGuard(next, false)
+}
+
+// only the gm running the campaign may manage it
+func (app *application) requireCampaignGM(next http.Handler) http.Handler {
+	return app.campaignGuard(next, true)
+}
+
+func (app *application) campaignGuard(next http.Handler, gmOnly bool) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		campaignId, err := strconv.Atoi(r.PathValue("id"))
+		if err != nil {
+			http.NotFound(w, r)
+			return
+		}
+
+		campaign, err := app.campaigns.Get(campaignId)
+		if err != nil {
+			if errors.Is(err, models.ErrNoRecord) {
+				http.NotFound(w, r)
+			} else {
+				app.serverError(w, r, err)
+			}
+			return
+		}
+
+		userId := app.sessionManager.GetInt(r.Context(), authenticatedUserIdKey)
+		if !campaign.IsRunBy(userId) && (gmOnly || !campaign.HasMember(userId)) {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
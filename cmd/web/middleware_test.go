@@ -118,3 +118,116 @@ func TestAuthenticate(t *testing.T) {
 		})
 	}
 }
+
+func TestRequireCharacterAccess(t *testing.T) {
+	app := newTestApplication(t)
+
+	mockNext := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("OK"))
+	})
+
+	tests := []struct {
+		name     string
+		userName string
+		userId   int
+		path     string
+		wantCode int
+	}{
+		{
+			name:     "Own Character",
+			userName: mocks.MockPlayer.Name,
+			userId:   mocks.MockPlayer.ID,
+			path:     "/characters/1",
+			wantCode: http.StatusOK,
+		},
+		{
+			name:     "Character in own Campaign",
+			userName: mocks.MockGM.Name,
+			userId:   mocks.MockGM.ID,
+			path:     "/characters/2",
+			wantCode: http.StatusOK,
+		},
+		{
+			name:     "Foreign Character",
+			userName: "Fremder",
+			userId:   3,
+			path:     "/characters/1",
+			wantCode: http.StatusUnauthorized,
+		},
+		{
+			name:     "Nonexistent Character",
+			userName: mocks.MockPlayer.Name,
+			userId:   mocks.MockPlayer.ID,
+			path:     "/characters/69",
+			wantCode: http.StatusNotFound,
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			mux := http.NewServeMux()
+			mux.Handle("GET /characters/{id}", app.requireCharacterAccess(mockNext))
+
+			rec := httptest.NewRecorder()
+			r, err := http.NewRequest(http.MethodGet, testCase.path, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			app.sessionManager.LoadAndSave(app.mockSession(app.authenticate(mux), map[string]any{
+				authenticatedUserIdKey:   testCase.userId,
+				authenticatedUserNameKey: testCase.userName,
+			})).ServeHTTP(rec, r)
+
+			testHelpers.Equal(t, rec.Result().StatusCode, testCase.wantCode)
+		})
+	}
+}
+
+func TestRequireCampaignGM(t *testing.T) {
+	app := newTestApplication(t)
+
+	mockNext := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("OK"))
+	})
+
+	tests := []struct {
+		name     string
+		userName string
+		userId   int
+		wantCode int
+	}{
+		{
+			name:     "Running GM",
+			userName: mocks.MockGM.Name,
+			userId:   mocks.MockGM.ID,
+			wantCode: http.StatusOK,
+		},
+		{
+			name:     "Member",
+			userName: mocks.MockPlayer.Name,
+			userId:   mocks.MockPlayer.ID,
+			wantCode: http.StatusUnauthorized,
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			mux := http.NewServeMux()
+			mux.Handle("POST /campaigns/{id}/delete", app.requireCampaignGM(mockNext))
+
+			rec := httptest.NewRecorder()
+			r, err := http.NewRequest(http.MethodPost, "/campaigns/1/delete", nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			app.sessionManager.LoadAndSave(app.mockSession(app.authenticate(mux), map[string]any{
+				authenticatedUserIdKey:   testCase.userId,
+				authenticatedUserNameKey: testCase.userName,
+			})).ServeHTTP(rec, r)
+
+			testHelpers.Equal(t, rec.Result().StatusCode, testCase.wantCode)
+		})
+	}
+}
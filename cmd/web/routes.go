@@ -32,28 +32,105 @@ func (app *application) routes() http.Handler {
 
 	mux.Handle("GET /create", protectedChain.ThenFunc(app.createCharacter))
 	mux.Handle("POST /create", protectedChain.ThenFunc(app.createCharacterPost))
-	mux.Handle("GET /characters/{id}/delete", protectedChain.ThenFunc(app.deleteCharacter))
-	mux.Handle("POST /characters/{id}/delete", protectedChain.ThenFunc(app.deleteCharacterPost))
-
-	mux.Handle("GET /characters/{id}", protectedChain.ThenFunc(app.character))
-	mux.Handle("POST /characters/{id}/editStat", protectedChain.ThenFunc(app.editStat))
-	mux.Handle("GET /characters/{id}/addSkill", protectedChain.ThenFunc(app.addSkill))
-	mux.Handle("POST /characters/{id}/addSkill", protectedChain.ThenFunc(app.addSkillPost))
-	mux.Handle("GET /characters/{id}/editSkill", protectedChain.ThenFunc(app.editSkill))
-	mux.Handle("POST /characters/{id}/editSkill", protectedChain.ThenFunc(app.editSkillPost))
-	mux.Handle("GET /characters/{id}/addCustomSkill", protectedChain.ThenFunc(app.addCustomSkill))
-	mux.Handle("POST /characters/{id}/addCustomSkill", protectedChain.ThenFunc(app.addCustomSkillPost))
-	mux.Handle("GET /characters/{id}/editCustomSkill", protectedChain.ThenFunc(app.editCustomSkill))
-	mux.Handle("POST /characters/{id}/editCustomSkill", protectedChain.ThenFunc(app.editCustomSkillPost))
-
-	mux.Handle("GET /characters/{id}/addItem", protectedChain.ThenFunc(app.addItem))
-	mux.Handle("POST /characters/{id}/addItem", protectedChain.ThenFunc(app.addItemPost))
-	mux.Handle("POST /characters/{id}/editItemCount", protectedChain.ThenFunc(app.editItemCount))
-	mux.Handle("POST /characters/{id}/deleteItem", protectedChain.ThenFunc(app.deleteItemPost))
-
-	mux.Handle("GET /characters/{id}/addNote", protectedChain.ThenFunc(app.addNote))
-	mux.Handle("POST /characters/{id}/addNote", protectedChain.ThenFunc(app.addNotePost))
-	mux.Handle("POST /characters/{id}/deleteNote", protectedChain.ThenFunc(app.deleteNotePost))
+	mux.Handle("POST /create/roll", protectedChain.ThenFunc(app.createRollPost))
+
+	characterChain := protectedChain.Append(app.requireCharacterAccess)
+	mux.Handle("GET /characters/{id}/delete", characterChain.ThenFunc(app.deleteCharacter))
+	mux.Handle("POST /characters/{id}/delete", characterChain.ThenFunc(app.deleteCharacterPost))
+
+	mux.Handle("GET /characters/{id}", characterChain.ThenFunc(app.character))
+	mux.Handle("GET /characters/{id}/events", characterChain.ThenFunc(app.characterEvents))
+	mux.Handle("POST /characters/{id}/editAttribute", characterChain.ThenFunc(app.editAttributePost))
+	mux.Handle("POST /characters/{id}/editStat", characterChain.ThenFunc(app.editStat))
+	mux.Handle("POST /characters/{id}/damage", characterChain.ThenFunc(app.damagePost))
+	mux.Handle("POST /characters/{id}/treatWounds", characterChain.ThenFunc(app.treatWoundsPost))
+	mux.Handle("POST /characters/{id}/roll", characterChain.ThenFunc(app.rollPost))
+	mux.Handle("POST /characters/{id}/rolls/{rollId}/spendLuck", characterChain.ThenFunc(app.spendLuckPost))
+	mux.Handle("POST /characters/{id}/rolls/{rollId}/push", characterChain.ThenFunc(app.pushRollPost))
+	mux.Handle("POST /characters/{id}/attack", characterChain.ThenFunc(app.attackPost))
+	mux.Handle("POST /characters/{id}/weaponDamage", characterChain.ThenFunc(app.weaponDamagePost))
+	mux.Handle("POST /characters/{id}/carryWeapon", characterChain.ThenFunc(app.carryWeaponPost))
+	mux.Handle("POST /characters/{id}/dropWeapon", characterChain.ThenFunc(app.dropWeaponPost))
+	mux.Handle("POST /characters/{id}/learnSpell", characterChain.ThenFunc(app.learnSpellPost))
+	mux.Handle("POST /characters/{id}/castSpell", characterChain.ThenFunc(app.castSpellPost))
+	mux.Handle("POST /characters/{id}/acquireTome", characterChain.ThenFunc(app.acquireTomePost))
+	mux.Handle("POST /characters/{id}/studyTome", characterChain.ThenFunc(app.studyTomePost))
+	mux.Handle("POST /characters/{id}/ledger", characterChain.ThenFunc(app.ledgerPost))
+	mux.Handle("POST /characters/{id}/ledger/{entryId}/delete", characterChain.ThenFunc(app.deleteLedgerEntryPost))
+	mux.Handle("POST /characters/{id}/backstory", characterChain.ThenFunc(app.backstoryPost))
+	mux.Handle("POST /characters/{id}/backstory/{entryId}/delete", characterChain.ThenFunc(app.deleteBackstoryEntryPost))
+	mux.Handle("POST /characters/{id}/backstory/{entryId}/keyConnection", characterChain.ThenFunc(app.keyConnectionPost))
+	mux.Handle("POST /characters/{id}/tickSkill", characterChain.ThenFunc(app.tickSkillPost))
+	mux.Handle("POST /characters/{id}/develop", characterChain.ThenFunc(app.developPost))
+	mux.Handle("POST /characters/{id}/sanity", characterChain.ThenFunc(app.sanityCheckPost))
+	mux.Handle("POST /characters/{id}/sanity/recover", characterChain.ThenFunc(app.recoverSanityPost))
+	mux.Handle("GET /characters/{id}/addSkill", characterChain.ThenFunc(app.addSkill))
+	mux.Handle("POST /characters/{id}/addSkill", characterChain.ThenFunc(app.addSkillPost))
+	mux.Handle("GET /characters/{id}/editSkill", characterChain.ThenFunc(app.editSkill))
+	mux.Handle("POST /characters/{id}/editSkill", characterChain.ThenFunc(app.editSkillPost))
+	mux.Handle("GET /characters/{id}/addCustomSkill", characterChain.ThenFunc(app.addCustomSkill))
+	mux.Handle("POST /characters/{id}/addCustomSkill", characterChain.ThenFunc(app.addCustomSkillPost))
+	mux.Handle("GET /characters/{id}/editCustomSkill", characterChain.ThenFunc(app.editCustomSkill))
+	mux.Handle("POST /characters/{id}/editCustomSkill", characterChain.ThenFunc(app.editCustomSkillPost))
+
+	mux.Handle("GET /characters/{id}/addItem", characterChain.ThenFunc(app.addItem))
+	mux.Handle("POST /characters/{id}/addItem", characterChain.ThenFunc(app.addItemPost))
+	mux.Handle("POST /characters/{id}/editItemCount", characterChain.ThenFunc(app.editItemCount))
+	mux.Handle("POST /characters/{id}/deleteItem", characterChain.ThenFunc(app.deleteItemPost))
+
+	mux.Handle("GET /characters/{id}/addNote", characterChain.ThenFunc(app.addNote))
+	mux.Handle("POST /characters/{id}/addNote", characterChain.ThenFunc(app.addNotePost))
+	mux.Handle("POST /characters/{id}/deleteNote", characterChain.ThenFunc(app.deleteNotePost))
+
+	gmChain := protectedChain.Append(app.requireGM)
+	campaignChain := protectedChain.Append(app.requireCampaignAccess)
+	campaignGMChain := protectedChain.Append(app.requireCampaignGM)
+	mux.Handle("GET /campaigns/create", gmChain.ThenFunc(app.createCampaign))
+	mux.Handle("POST /campaigns/create", gmChain.ThenFunc(app.createCampaignPost))
+	mux.Handle("GET /npcs", gmChain.ThenFunc(app.npcs))
+	mux.Handle("GET /npcs/create", gmChain.ThenFunc(app.createNPC))
+	mux.Handle("POST /npcs/create", gmChain.ThenFunc(app.createNPCPost))
+	mux.Handle("GET /campaigns/join", protectedChain.ThenFunc(app.joinCampaign))
+	mux.Handle("POST /campaigns/join", protectedChain.ThenFunc(app.joinCampaignPost))
+	mux.Handle("GET /campaigns/{id}", campaignChain.ThenFunc(app.campaign))
+	mux.Handle("GET /campaigns/{id}/events", campaignChain.ThenFunc(app.campaignEvents))
+	mux.Handle("POST /campaigns/{id}/revealHandout", campaignGMChain.ThenFunc(app.revealHandoutPost))
+	mux.Handle("POST /campaigns/{id}/hideHandout", campaignGMChain.ThenFunc(app.hideHandoutPost))
+	mux.Handle("GET /campaigns/{id}/dashboard", campaignGMChain.ThenFunc(app.campaignDashboard))
+	mux.Handle("POST /campaigns/{id}/table", campaignGMChain.ThenFunc(app.setTablePost))
+	mux.Handle("POST /campaigns/{id}/recoverLuck", campaignGMChain.ThenFunc(app.recoverLuckPost))
+	mux.Handle("POST /campaigns/{id}/rolls/{rollId}/consequence", campaignGMChain.ThenFunc(app.consequencePost))
+	mux.Handle("POST /campaigns/{id}/newDay", campaignGMChain.ThenFunc(app.newDayPost))
+	mux.Handle("POST /campaigns/{id}/era", campaignGMChain.ThenFunc(app.campaignEraPost))
+	mux.Handle("GET /campaigns/{id}/chronicle", campaignChain.ThenFunc(app.campaignChronicle))
+	mux.Handle("GET /campaigns/{id}/chronicle/create", campaignGMChain.ThenFunc(app.createChronicleEntry))
+	mux.Handle("POST /campaigns/{id}/chronicle/create", campaignGMChain.ThenFunc(app.createChronicleEntryPost))
+	mux.Handle("GET /campaigns/{id}/chronicle/{entryId}", campaignChain.ThenFunc(app.chronicleEntry))
+	mux.Handle("GET /campaigns/{id}/chronicle/{entryId}/edit", campaignGMChain.ThenFunc(app.editChronicleEntry))
+	mux.Handle("POST /campaigns/{id}/chronicle/{entryId}/edit", campaignGMChain.ThenFunc(app.editChronicleEntryPost))
+	mux.Handle("POST /campaigns/{id}/chronicle/{entryId}/delete", campaignGMChain.ThenFunc(app.deleteChronicleEntryPost))
+	mux.Handle("GET /campaigns/{id}/combat", campaignChain.ThenFunc(app.combat))
+	mux.Handle("POST /campaigns/{id}/combat/start", campaignGMChain.ThenFunc(app.startCombatPost))
+	mux.Handle("POST /campaigns/{id}/combat/ready", campaignGMChain.ThenFunc(app.readyFirearmPost))
+	mux.Handle("POST /campaigns/{id}/combat/next", campaignGMChain.ThenFunc(app.nextTurnPost))
+	mux.Handle("POST /campaigns/{id}/combat/target", campaignGMChain.ThenFunc(app.combatTargetPost))
+	mux.Handle("POST /campaigns/{id}/combat/damage", campaignGMChain.ThenFunc(app.combatDamagePost))
+	mux.Handle("POST /campaigns/{id}/combat/end", campaignGMChain.ThenFunc(app.endCombatPost))
+	mux.Handle("GET /campaigns/{id}/opposed", campaignGMChain.ThenFunc(app.opposedRoll))
+	mux.Handle("POST /campaigns/{id}/opposed", campaignGMChain.ThenFunc(app.opposedRollPost))
+	mux.Handle("GET /campaigns/{id}/chase", campaignChain.ThenFunc(app.chase))
+	mux.Handle("POST /campaigns/{id}/chase/start", campaignGMChain.ThenFunc(app.startChasePost))
+	mux.Handle("POST /campaigns/{id}/chase/location", campaignGMChain.ThenFunc(app.chaseLocationPost))
+	mux.Handle("POST /campaigns/{id}/chase/move", campaignGMChain.ThenFunc(app.chaseMovePost))
+	mux.Handle("POST /campaigns/{id}/chase/next", campaignGMChain.ThenFunc(app.nextChaseRoundPost))
+	mux.Handle("POST /campaigns/{id}/chase/end", campaignGMChain.ThenFunc(app.endChasePost))
+	mux.Handle("POST /campaigns/{id}/delete", campaignGMChain.ThenFunc(app.deleteCampaignPost))
+	mux.Handle("POST /campaigns/{id}/addMember", campaignGMChain.ThenFunc(app.addCampaignMemberPost))
+	mux.Handle("POST /campaigns/{id}/removeMember", campaignGMChain.ThenFunc(app.removeCampaignMemberPost))
+	mux.Handle("POST /campaigns/{id}/addCharacter", campaignGMChain.ThenFunc(app.addCampaignCharacterPost))
+	mux.Handle("POST /campaigns/{id}/removeCharacter", campaignGMChain.ThenFunc(app.removeCampaignCharacterPost))
+	mux.Handle("POST /campaigns/{id}/createInvite", campaignGMChain.ThenFunc(app.createInvitePost))
+	mux.Handle("POST /campaigns/{id}/revokeInvite", campaignGMChain.ThenFunc(app.revokeInvitePost))
 
 	//some helpers
 	mux.Handle("GET /customSkillInput", protectedChain.ThenFunc(app.customSkillInput))
@@ -84,11 +161,36 @@ func (app *application) routesNoMW() http.Handler {
 
 	mux.HandleFunc("GET /create", app.createCharacter)
 	mux.HandleFunc("POST /create", app.createCharacterPost)
+	mux.HandleFunc("POST /create/roll", app.createRollPost)
 	mux.HandleFunc("GET /characters/{id}/delete", app.deleteCharacter)
 	mux.HandleFunc("POST /characters/{id}/delete", app.deleteCharacterPost)
 
 	mux.HandleFunc("GET /characters/{id}", app.character)
-	mux.HandleFunc("GET /characters/{id}/editStat", app.editStat)
+	mux.HandleFunc("GET /characters/{id}/events", app.characterEvents)
+	mux.HandleFunc("POST /characters/{id}/editAttribute", app.editAttributePost)
+	mux.HandleFunc("POST /characters/{id}/editStat", app.editStat)
+	mux.HandleFunc("POST /characters/{id}/damage", app.damagePost)
+	mux.HandleFunc("POST /characters/{id}/treatWounds", app.treatWoundsPost)
+	mux.HandleFunc("POST /characters/{id}/roll", app.rollPost)
+	mux.HandleFunc("POST /characters/{id}/rolls/{rollId}/spendLuck", app.spendLuckPost)
+	mux.HandleFunc("POST /characters/{id}/rolls/{rollId}/push", app.pushRollPost)
+	mux.HandleFunc("POST /characters/{id}/attack", app.attackPost)
+	mux.HandleFunc("POST /characters/{id}/weaponDamage", app.weaponDamagePost)
+	mux.HandleFunc("POST /characters/{id}/carryWeapon", app.carryWeaponPost)
+	mux.HandleFunc("POST /characters/{id}/dropWeapon", app.dropWeaponPost)
+	mux.HandleFunc("POST /characters/{id}/learnSpell", app.learnSpellPost)
+	mux.HandleFunc("POST /characters/{id}/castSpell", app.castSpellPost)
+	mux.HandleFunc("POST /characters/{id}/acquireTome", app.acquireTomePost)
+	mux.HandleFunc("POST /characters/{id}/studyTome", app.studyTomePost)
+	mux.HandleFunc("POST /characters/{id}/ledger", app.ledgerPost)
+	mux.HandleFunc("POST /characters/{id}/ledger/{entryId}/delete", app.deleteLedgerEntryPost)
+	mux.HandleFunc("POST /characters/{id}/backstory", app.backstoryPost)
+	mux.HandleFunc("POST /characters/{id}/backstory/{entryId}/delete", app.deleteBackstoryEntryPost)
+	mux.HandleFunc("POST /characters/{id}/backstory/{entryId}/keyConnection", app.keyConnectionPost)
+	mux.HandleFunc("POST /characters/{id}/tickSkill", app.tickSkillPost)
+	mux.HandleFunc("POST /characters/{id}/develop", app.developPost)
+	mux.HandleFunc("POST /characters/{id}/sanity", app.sanityCheckPost)
+	mux.HandleFunc("POST /characters/{id}/sanity/recover", app.recoverSanityPost)
 	mux.HandleFunc("GET /characters/{id}/addSkill", app.addSkill)
 	mux.HandleFunc("POST /characters/{id}/addSkill", app.addSkillPost)
 	mux.HandleFunc("GET /characters/{id}/editSkill", app.editSkill)
@@ -106,6 +208,53 @@ func (app *application) routesNoMW() http.Handler {
 	mux.HandleFunc("POST /characters/{id}/addNote", app.addNotePost)
 	mux.HandleFunc("POST /characters/{id}/deleteNote", app.deleteNotePost)
 
+	mux.HandleFunc("GET /campaigns/create", app.createCampaign)
+	mux.HandleFunc("POST /campaigns/create", app.createCampaignPost)
+	mux.HandleFunc("GET /npcs", app.npcs)
+	mux.HandleFunc("GET /npcs/create", app.createNPC)
+	mux.HandleFunc("POST /npcs/create", app.createNPCPost)
+	mux.HandleFunc("GET /campaigns/join", app.joinCampaign)
+	mux.HandleFunc("POST /campaigns/join", app.joinCampaignPost)
+	mux.HandleFunc("GET /campaigns/{id}", app.campaign)
+	mux.HandleFunc("GET /campaigns/{id}/events", app.campaignEvents)
+	mux.HandleFunc("POST /campaigns/{id}/revealHandout", app.revealHandoutPost)
+	mux.HandleFunc("POST /campaigns/{id}/hideHandout", app.hideHandoutPost)
+	mux.HandleFunc("GET /campaigns/{id}/dashboard", app.campaignDashboard)
+	mux.HandleFunc("POST /campaigns/{id}/table", app.setTablePost)
+	mux.HandleFunc("POST /campaigns/{id}/recoverLuck", app.recoverLuckPost)
+	mux.HandleFunc("POST /campaigns/{id}/rolls/{rollId}/consequence", app.consequencePost)
+	mux.HandleFunc("POST /campaigns/{id}/newDay", app.newDayPost)
+	mux.HandleFunc("POST /campaigns/{id}/era", app.campaignEraPost)
+	mux.HandleFunc("GET /campaigns/{id}/chronicle", app.campaignChronicle)
+	mux.HandleFunc("GET /campaigns/{id}/chronicle/create", app.createChronicleEntry)
+	mux.HandleFunc("POST /campaigns/{id}/chronicle/create", app.createChronicleEntryPost)
+	mux.HandleFunc("GET /campaigns/{id}/chronicle/{entryId}", app.chronicleEntry)
+	mux.HandleFunc("GET /campaigns/{id}/chronicle/{entryId}/edit", app.editChronicleEntry)
+	mux.HandleFunc("POST /campaigns/{id}/chronicle/{entryId}/edit", app.editChronicleEntryPost)
+	mux.HandleFunc("POST /campaigns/{id}/chronicle/{entryId}/delete", app.deleteChronicleEntryPost)
+	mux.HandleFunc("GET /campaigns/{id}/combat", app.combat)
+	mux.HandleFunc("POST /campaigns/{id}/combat/start", app.startCombatPost)
+	mux.HandleFunc("POST /campaigns/{id}/combat/ready", app.readyFirearmPost)
+	mux.HandleFunc("POST /campaigns/{id}/combat/next", app.nextTurnPost)
+	mux.HandleFunc("POST /campaigns/{id}/combat/target", app.combatTargetPost)
+	mux.HandleFunc("POST /campaigns/{id}/combat/damage", app.combatDamagePost)
+	mux.HandleFunc("POST /campaigns/{id}/combat/end", app.endCombatPost)
+	mux.HandleFunc("GET /campaigns/{id}/opposed", app.opposedRoll)
+	mux.HandleFunc("POST /campaigns/{id}/opposed", app.opposedRollPost)
+	mux.HandleFunc("GET /campaigns/{id}/chase", app.chase)
+	mux.HandleFunc("POST /campaigns/{id}/chase/start", app.startChasePost)
+	mux.HandleFunc("POST /campaigns/{id}/chase/location", app.chaseLocationPost)
+	mux.HandleFunc("POST /campaigns/{id}/chase/move", app.chaseMovePost)
+	mux.HandleFunc("POST /campaigns/{id}/chase/next", app.nextChaseRoundPost)
+	mux.HandleFunc("POST /campaigns/{id}/chase/end", app.endChasePost)
+	mux.HandleFunc("POST /campaigns/{id}/delete", app.deleteCampaignPost)
+	mux.HandleFunc("POST /campaigns/{id}/addMember", app.addCampaignMemberPost)
+	mux.HandleFunc("POST /campaigns/{id}/removeMember", app.removeCampaignMemberPost)
+	mux.HandleFunc("POST /campaigns/{id}/addCharacter", app.addCampaignCharacterPost)
+	mux.HandleFunc("POST /campaigns/{id}/removeCharacter", app.removeCampaignCharacterPost)
+	mux.HandleFunc("POST /campaigns/{id}/createInvite", app.createInvitePost)
+	mux.HandleFunc("POST /campaigns/{id}/revokeInvite", app.revokeInvitePost)
+
 	//some helpers
 	mux.HandleFunc("GET /customSkillInput", app.customSkillInput)
 
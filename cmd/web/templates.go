@@ -1,21 +1,41 @@
 package main
 
 import (
+	"bytes"
 	"html/template"
 	"io/fs"
 	"net/http"
 	"path/filepath"
 	"slices"
 	"strings"
+	"time"
 
 	"github.com/justinas/nosurf"
 	"github.com/winik100/NoPenNoPaper/internal/core"
 	"github.com/winik100/NoPenNoPaper/ui"
+	"github.com/yuin/goldmark"
 )
 
 type templateData struct {
 	Characters      []core.Character
 	Character       core.Character
+	Campaigns       []core.Campaign
+	Campaign        core.Campaign
+	Chronicle       []core.ChronicleEntry
+	ChronicleEntry  core.ChronicleEntry
+	Combat          core.Combat
+	Chase           core.Chase
+	Rolls           []core.Roll
+	PushedRolls     []core.PushedRoll
+	SanityChecks    []core.SanityCheck
+	Occupations     []core.Occupation
+	Weapons         []core.Weapon
+	Spells          []core.Spell
+	Tomes           []core.Tome
+	Wealth          core.Wealth
+	Ledger          []core.LedgerEntry
+	Opening         core.Money
+	Balance         core.Money
 	User            core.User
 	Form            any
 	AdditionalData  any
@@ -39,19 +59,11 @@ func (app *application) newTemplateData(r *http.Request) templateData {
 }
 
 func half(value int) int {
-	res := value / 2
-	if res == 0 {
-		return 1
-	}
-	return res
+	return core.Half(value)
 }
 
 func fifth(value int) int {
-	res := value / 5
-	if res == 0 {
-		return 1
-	}
-	return res
+	return core.Fifth(value)
 }
 
 func contains(skills []string, skill string) bool {
@@ -62,11 +74,38 @@ func trim(s string) string {
 	return strings.Join(strings.Split(s, " "), "")
 }
 
+// raw html within the markdown is omitted by goldmark's default renderer
+func markdown(s string) template.HTML {
+	var buf bytes.Buffer
+	err := goldmark.Convert([]byte(s), &buf)
+	if err != nil {
+		return template.HTML(template.HTMLEscapeString(s))
+	}
+	return template.HTML(buf.String())
+}
+
+func shortDate(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format("02.01.2006")
+}
+
+func humanDate(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Local().Format("02.01.2006 15:04")
+}
+
 var funcs = template.FuncMap{
-	"half":     half,
-	"fifth":    fifth,
-	"contains": contains,
-	"trim":     trim,
+	"half":      half,
+	"fifth":     fifth,
+	"contains":  contains,
+	"trim":      trim,
+	"humanDate": humanDate,
+	"shortDate": shortDate,
+	"markdown":  markdown,
 }
 
 func newTemplateCache() (map[string]*template.Template, error) {
@@ -1,8 +1,11 @@
 package main
 
 import (
+	"net/http/httptest"
+	"strings"
 	"testing"
 
+	"github.com/winik100/NoPenNoPaper/internal/core"
 	"github.com/winik100/NoPenNoPaper/internal/testHelpers"
 )
 
@@ -60,3 +63,20 @@ func TestHalfAndFifth(t *testing.T) {
 		})
 	}
 }
+
+func TestRenderHtmxEscapes(t *testing.T) {
+	app := newTestApplication(t)
+
+	// custom skill names are chosen by the player, but the GM may roll them as well
+	data := templateData{Form: core.Roll{Name: "<script>alert(1)</script>", Value: 50}}
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("POST", "/characters/1/roll", nil)
+
+	app.renderHtmx(w, r, "rollResult", rollResultTmpl, data)
+
+	body := w.Body.String()
+	testHelpers.StringContains(t, body, "&lt;script&gt;alert(1)&lt;/script&gt; (50): ")
+	if strings.Contains(body, "<script>") {
+		t.Errorf("unescaped roll name in %q", body)
+	}
+}
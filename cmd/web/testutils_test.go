@@ -39,9 +39,21 @@ func newTestApplication(t *testing.T) *application {
 		log:            slog.New(slog.NewTextHandler(io.Discard, nil)),
 		characters:     &mocks.CharacterModel{},
 		users:          &mocks.UserModel{},
+		campaigns:      &mocks.CampaignModel{},
+		chronicle:      &mocks.ChronicleModel{},
+		combats:        &mocks.CombatModel{},
+		chases:         &mocks.ChaseModel{},
+		rolls:          &mocks.RollModel{},
+		sanity:         &mocks.SanityModel{},
+		occupations:    &mocks.OccupationModel{},
+		weapons:        &mocks.WeaponModel{},
+		spells:         &mocks.SpellModel{},
+		tomes:          &mocks.TomeModel{},
+		ledger:         &mocks.LedgerModel{},
 		templateCache:  templateCache,
 		formDecoder:    formDecoder,
 		sessionManager: sessionManager,
+		events:         newEventHub(),
 	}
 }
 
@@ -10,6 +10,7 @@ require (
 	github.com/justinas/alice v1.2.0
 	github.com/justinas/nosurf v1.1.1
 	github.com/justinian/dice v1.0.2
+	github.com/yuin/goldmark v1.7.4
 	golang.org/x/crypto v0.25.0
 )
 
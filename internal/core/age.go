@@ -0,0 +1,131 @@
+package core
+
+import (
+	"slices"
+	"strconv"
+
+	"github.com/justinian/dice"
+)
+
+// BI improvement checks can't raise BI any further
+const maxEducation = 99
+
+// physical attributes age deductions may be spread over
+var physicalAttributes = []string{"ST", "KO", "GE", "GR"}
+
+// the rules' adjustments for an age bracket. MOV penalties are part of the derived movement rate.
+type AgeBracket struct {
+	MinAge          int
+	Deduction       int      //points the player spreads over the attributes below
+	DeductFrom      []string //attributes the deduction may be taken from
+	BI              int      //flat deductions
+	ER              int
+	EducationChecks int //BI improvement checks
+	LuckRolls       int //rolls for luck, the highest counts
+}
+
+// ordered by age, youngest first
+var ageBrackets = []AgeBracket{
+	{MinAge: 15, Deduction: 5, DeductFrom: []string{"ST", "GR"}, BI: 5, LuckRolls: 2},
+	{MinAge: 20, EducationChecks: 1, LuckRolls: 1},
+	{MinAge: 40, Deduction: 5, DeductFrom: []string{"ST", "KO", "GE"}, ER: 5, EducationChecks: 2, LuckRolls: 1},
+	{MinAge: 50, Deduction: 10, DeductFrom: []string{"ST", "KO", "GE"}, ER: 10, EducationChecks: 3, LuckRolls: 1},
+	{MinAge: 60, Deduction: 20, DeductFrom: []string{"ST", "KO", "GE"}, ER: 15, EducationChecks: 4, LuckRolls: 1},
+	{MinAge: 70, Deduction: 40, DeductFrom: []string{"ST", "KO", "GE"}, ER: 20, EducationChecks: 4, LuckRolls: 1},
+	{MinAge: 80, Deduction: 80, DeductFrom: []string{"ST", "KO", "GE"}, ER: 25, EducationChecks: 4, LuckRolls: 1},
+}
+
+func AgeBracketFor(age int) AgeBracket {
+	bracket := ageBrackets[0]
+	for _, b := range ageBrackets {
+		if age >= b.MinAge {
+			bracket = b
+		}
+	}
+	return bracket
+}
+
+func (character Character) AgeBracket() AgeBracket {
+	age, _ := strconv.Atoi(character.Info.Age)
+	return AgeBracketFor(age)
+}
+
+// points the player takes from each physical attribute
+type AgeDeductions struct {
+	ST int
+	KO int
+	GE int
+	GR int
+}
+
+func (d AgeDeductions) AsMap() map[string]int {
+	return map[string]int{
+		"ST": d.ST,
+		"KO": d.KO,
+		"GE": d.GE,
+		"GR": d.GR,
+	}
+}
+
+func (d AgeDeductions) Sum() int {
+	return d.ST + d.KO + d.GE + d.GR
+}
+
+// whether the deductions add up to the bracket's total, only touch the permitted attributes
+// and leave every attribute at 1 or more
+func (b AgeBracket) Permits(deductions AgeDeductions, attributes CharacterAttributes) bool {
+	if deductions.Sum() != b.Deduction {
+		return false
+	}
+
+	values := attributes.AsMap()
+	for _, attr := range physicalAttributes {
+		deduction := deductions.AsMap()[attr]
+		if deduction < 0 || deduction >= values[attr] {
+			return false
+		}
+		if deduction > 0 && !slices.Contains(b.DeductFrom, attr) {
+			return false
+		}
+	}
+	return true
+}
+
+// outcome of one BI improvement check
+type EducationCheck struct {
+	Roll int
+	Gain int
+}
+
+// the attributes after the player's and the bracket's flat deductions, before any BI improvement
+func (a CharacterAttributes) Aged(bracket AgeBracket, deductions AgeDeductions) CharacterAttributes {
+	a.ST -= deductions.ST
+	a.KO -= deductions.KO
+	a.GE -= deductions.GE
+	a.GR -= deductions.GR
+	a.BI = max(a.BI-bracket.BI, 1)
+	a.ER = max(a.ER-bracket.ER, 1)
+	return a
+}
+
+// applies the bracket's deductions and rolls its BI improvement checks. the deductions have to be
+// permitted by the bracket.
+func (character Character) ApplyAge(deductions AgeDeductions) (Character, []EducationCheck) {
+	bracket := character.AgeBracket()
+	character.Attributes = character.Attributes.Aged(bracket, deductions)
+	attributes := &character.Attributes
+
+	var checks []EducationCheck
+	for range bracket.EducationChecks {
+		check := EducationCheck{Roll: RollD100()}
+		if check.Roll > attributes.BI {
+			res, _, err := dice.Roll("1d10")
+			if err == nil {
+				check.Gain = min(res.Int(), max(maxEducation-attributes.BI, 0))
+				attributes.BI += check.Gain
+			}
+		}
+		checks = append(checks, check)
+	}
+	return character, checks
+}
@@ -0,0 +1,231 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/winik100/NoPenNoPaper/internal/testHelpers"
+)
+
+func TestAgeBracketFor(t *testing.T) {
+	tests := []struct {
+		name          string
+		age           int
+		wantMinAge    int
+		wantDeduction int
+		wantChecks    int
+	}{
+		{
+			name:          "Younger Than Allowed",
+			age:           12,
+			wantMinAge:    15,
+			wantDeduction: 5,
+			wantChecks:    0,
+		},
+		{
+			name:          "15",
+			age:           15,
+			wantMinAge:    15,
+			wantDeduction: 5,
+			wantChecks:    0,
+		},
+		{
+			name:          "19",
+			age:           19,
+			wantMinAge:    15,
+			wantDeduction: 5,
+			wantChecks:    0,
+		},
+		{
+			name:          "20",
+			age:           20,
+			wantMinAge:    20,
+			wantDeduction: 0,
+			wantChecks:    1,
+		},
+		{
+			name:          "39",
+			age:           39,
+			wantMinAge:    20,
+			wantDeduction: 0,
+			wantChecks:    1,
+		},
+		{
+			name:          "40",
+			age:           40,
+			wantMinAge:    40,
+			wantDeduction: 5,
+			wantChecks:    2,
+		},
+		{
+			name:          "79",
+			age:           79,
+			wantMinAge:    70,
+			wantDeduction: 40,
+			wantChecks:    4,
+		},
+		{
+			name:          "80",
+			age:           80,
+			wantMinAge:    80,
+			wantDeduction: 80,
+			wantChecks:    4,
+		},
+		{
+			name:          "Older Than Any Bracket",
+			age:           120,
+			wantMinAge:    80,
+			wantDeduction: 80,
+			wantChecks:    4,
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			bracket := AgeBracketFor(testCase.age)
+			testHelpers.Equal(t, bracket.MinAge, testCase.wantMinAge)
+			testHelpers.Equal(t, bracket.Deduction, testCase.wantDeduction)
+			testHelpers.Equal(t, bracket.EducationChecks, testCase.wantChecks)
+		})
+	}
+}
+
+func TestAgeBracketPermits(t *testing.T) {
+	attributes := CharacterAttributes{ST: 50, KO: 50, GE: 50, GR: 50}
+
+	tests := []struct {
+		name       string
+		age        int
+		deductions AgeDeductions
+		want       bool
+	}{
+		{
+			name:       "Young From ST And GR",
+			age:        15,
+			deductions: AgeDeductions{ST: 2, GR: 3},
+			want:       true,
+		},
+		{
+			name:       "Young From GE",
+			age:        15,
+			deductions: AgeDeductions{ST: 2, GE: 3},
+			want:       false,
+		},
+		{
+			name:       "Nothing To Deduct",
+			age:        30,
+			deductions: AgeDeductions{},
+			want:       true,
+		},
+		{
+			name:       "Deduction Where None Is Due",
+			age:        30,
+			deductions: AgeDeductions{ST: 1},
+			want:       false,
+		},
+		{
+			name:       "Too Little",
+			age:        50,
+			deductions: AgeDeductions{ST: 5, KO: 4},
+			want:       false,
+		},
+		{
+			name:       "Too Much",
+			age:        50,
+			deductions: AgeDeductions{ST: 5, KO: 6},
+			want:       false,
+		},
+		{
+			name:       "Negative Deduction",
+			age:        50,
+			deductions: AgeDeductions{ST: 15, KO: -5},
+			want:       false,
+		},
+		{
+			name:       "Old From GR",
+			age:        50,
+			deductions: AgeDeductions{ST: 5, GR: 5},
+			want:       false,
+		},
+		{
+			name:       "Attribute Left At 1",
+			age:        80,
+			deductions: AgeDeductions{ST: 49, KO: 31},
+			want:       true,
+		},
+		{
+			name:       "Attribute Down To 0",
+			age:        80,
+			deductions: AgeDeductions{ST: 50, KO: 30},
+			want:       false,
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			got := AgeBracketFor(testCase.age).Permits(testCase.deductions, attributes)
+			testHelpers.Equal(t, got, testCase.want)
+		})
+	}
+}
+
+func TestAged(t *testing.T) {
+	tests := []struct {
+		name       string
+		age        int
+		attributes CharacterAttributes
+		deductions AgeDeductions
+		want       CharacterAttributes
+	}{
+		{
+			name:       "Young",
+			age:        17,
+			attributes: CharacterAttributes{ST: 50, GR: 60, BI: 70, ER: 40},
+			deductions: AgeDeductions{ST: 5},
+			want:       CharacterAttributes{ST: 45, GR: 60, BI: 65, ER: 40},
+		},
+		{
+			name:       "Old",
+			age:        65,
+			attributes: CharacterAttributes{ST: 50, KO: 50, GE: 50, BI: 70, ER: 40},
+			deductions: AgeDeductions{ST: 10, KO: 5, GE: 5},
+			want:       CharacterAttributes{ST: 40, KO: 45, GE: 45, BI: 70, ER: 25},
+		},
+		{
+			name:       "Flat Deductions Stop At 1",
+			age:        85,
+			attributes: CharacterAttributes{ST: 90, KO: 90, GE: 90, BI: 3, ER: 10},
+			deductions: AgeDeductions{ST: 40, KO: 40},
+			want:       CharacterAttributes{ST: 50, KO: 50, GE: 90, BI: 3, ER: 1},
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			got := testCase.attributes.Aged(AgeBracketFor(testCase.age), testCase.deductions)
+			testHelpers.Equal(t, got, testCase.want)
+		})
+	}
+}
+
+func TestApplyAge(t *testing.T) {
+	for range 100 {
+		character := Character{
+			Info:       CharacterInfo{Age: "70"},
+			Attributes: CharacterAttributes{ST: 80, KO: 80, GE: 80, BI: 95, ER: 50},
+		}
+		aged, checks := character.ApplyAge(AgeDeductions{ST: 20, KO: 20})
+
+		testHelpers.Equal(t, len(checks), 4)
+		if aged.Attributes.BI > maxEducation {
+			t.Fatalf("BI %d above %d", aged.Attributes.BI, maxEducation)
+		}
+		gained := 0
+		for _, check := range checks {
+			if check.Roll <= 95 && check.Gain != 0 {
+				t.Fatalf("check %d at BI 95 or below must not gain", check.Roll)
+			}
+			gained += check.Gain
+		}
+		testHelpers.Equal(t, aged.Attributes.BI, 95+gained)
+	}
+}
@@ -0,0 +1,101 @@
+package core
+
+type BackstorySection string
+
+const (
+	Ideology    BackstorySection = "ideology"
+	People      BackstorySection = "people"
+	Locations   BackstorySection = "locations"
+	Possessions BackstorySection = "possessions"
+	Traits      BackstorySection = "traits"
+	Injuries    BackstorySection = "injuries"
+	Phobias     BackstorySection = "phobias"
+	Encounters  BackstorySection = "encounters"
+)
+
+// in the order of the official sheet
+var BackstorySections = []BackstorySection{Ideology, People, Locations, Possessions, Traits, Injuries, Phobias, Encounters}
+
+func (s BackstorySection) String() string {
+	switch s {
+	case Ideology:
+		return "Weltanschauung & Überzeugungen"
+	case People:
+		return "Wichtige Personen"
+	case Locations:
+		return "Bedeutsame Orte"
+	case Possessions:
+		return "Wertvoller Besitz"
+	case Traits:
+		return "Eigenschaften"
+	case Injuries:
+		return "Verletzungen & Narben"
+	case Phobias:
+		return "Phobien & Manien"
+	case Encounters:
+		return "Begegnungen mit fremden Entitäten"
+	}
+	return ""
+}
+
+// only beliefs, people, places and possessions can hold an investigator together when sanity is regained
+func (s BackstorySection) CanBeKeyConnection() bool {
+	switch s {
+	case Ideology, People, Locations, Possessions:
+		return true
+	}
+	return false
+}
+
+type BackstoryEntry struct {
+	ID            int
+	Section       BackstorySection
+	Text          string
+	KeyConnection bool //set by the GM, at most one entry per character
+}
+
+type Backstory []BackstoryEntry
+
+// a section of the backstory together with its entries
+type BackstoryPart struct {
+	Section BackstorySection
+	Entries []BackstoryEntry
+}
+
+// the entries of the given section, in the order they were added
+func (b Backstory) Section(section BackstorySection) []BackstoryEntry {
+	var entries []BackstoryEntry
+	for _, entry := range b {
+		if entry.Section == section {
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
+
+// every section in the order of the official sheet, including empty ones
+func (b Backstory) Sections() []BackstoryPart {
+	parts := make([]BackstoryPart, len(BackstorySections))
+	for i, section := range BackstorySections {
+		parts[i] = BackstoryPart{Section: section, Entries: b.Section(section)}
+	}
+	return parts
+}
+
+func (b Backstory) Entry(entryId int) (BackstoryEntry, bool) {
+	for _, entry := range b {
+		if entry.ID == entryId {
+			return entry, true
+		}
+	}
+	return BackstoryEntry{}, false
+}
+
+func (b Backstory) KeyConnection() (BackstoryEntry, bool) {
+	for _, entry := range b {
+		if entry.KeyConnection {
+			return entry, true
+		}
+	}
+	return BackstoryEntry{}, false
+}
@@ -0,0 +1,102 @@
+package core
+
+import (
+	"slices"
+	"time"
+)
+
+type Campaign struct {
+	ID         int
+	Title      string
+	CreatedBy  int
+	Era        Era //decides what money is worth
+	Members    []User
+	Characters []Character
+	Invites    []Invite
+	Table      []int //ids of the characters currently at the gaming table
+	Handouts   []Handout
+	NPCs       []Character //only loaded for the GM
+}
+
+func (c Campaign) IsRunBy(userId int) bool {
+	return c.CreatedBy == userId
+}
+
+func (c Campaign) HasMember(userId int) bool {
+	for _, member := range c.Members {
+		if member.ID == userId {
+			return true
+		}
+	}
+	return false
+}
+
+func (c Campaign) AtTable(characterId int) bool {
+	return slices.Contains(c.Table, characterId)
+}
+
+type Invite struct {
+	Code          string
+	CampaignID    int
+	CampaignTitle string
+	Expires       time.Time //zero value if the invite never expires
+	SingleUse     bool
+	Used          bool
+}
+
+func (i Invite) Usable(now time.Time) bool {
+	if i.SingleUse && i.Used {
+		return false
+	}
+	return i.Expires.IsZero() || now.Before(i.Expires)
+}
+
+// a material the GM of a campaign has revealed to its players
+type Handout struct {
+	ID             int
+	MaterialID     int
+	CampaignID     int
+	Title          string
+	FileName       string
+	UploadedBy     int
+	RevealedTo     int //0 if revealed to all members of the campaign
+	RevealedToName string
+}
+
+func (h Handout) RevealedToAll() bool {
+	return h.RevealedTo == 0
+}
+
+// what happened in one game session of a campaign
+type ChronicleEntry struct {
+	ID         int
+	CampaignID int
+	Date       time.Time
+	Title      string
+	Body       string      //markdown
+	Characters []Character //only ID and Info.Name are set
+	Handouts   []Handout
+	Notes      Notes
+}
+
+func (e ChronicleEntry) HasCharacter(characterId int) bool {
+	for _, character := range e.Characters {
+		if character.ID == characterId {
+			return true
+		}
+	}
+	return false
+}
+
+func (e ChronicleEntry) HasHandout(handoutId int) bool {
+	for _, handout := range e.Handouts {
+		if handout.ID == handoutId {
+			return true
+		}
+	}
+	return false
+}
+
+func (e ChronicleEntry) HasNote(noteId int) bool {
+	return slices.Contains(e.Notes.ID, noteId)
+}
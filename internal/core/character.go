@@ -1,22 +1,86 @@
 package core
 
 import (
+	"errors"
+	"fmt"
 	"slices"
+	"strconv"
 
 	"github.com/justinian/dice"
 )
 
+const KindInvestigator = "investigator"
+const KindNPC = "npc"
+
 type Character struct {
 	ID           int
+	CreatedBy    int
+	CampaignID   int //0 if not part of any campaign
+	Kind         string
+	TrackTP      bool //only NPCs may go without hit points or sanity
+	TrackSTA     bool
 	Info         CharacterInfo
+	Backstory    Backstory
 	Attributes   CharacterAttributes
 	Stats        CharacterStats
 	Skills       Skills
 	CustomSkills CustomSkills
+	Ticks        []string //skills marked for improvement
 	Items        Items
+	Weapons      []Weapon //carried weapons
+	Spells       []Spell  //known spells
+	Tomes        []Tome   //owned tomes
 	Notes        Notes
 }
 
+func (character Character) IsNPC() bool {
+	return character.Kind == KindNPC
+}
+
+// whether the given stat is shown and tracked for this character
+func (character Character) Tracks(stat string) bool {
+	if !character.IsNPC() {
+		return true
+	}
+	switch stat {
+	case "TP":
+		return character.TrackTP
+	case "STA":
+		return character.TrackSTA
+	}
+	return true
+}
+
+// looks up the value a roll on the given skill, attribute or LUCK is made against
+func (character Character) RollTarget(name string) (int, bool) {
+	if name == "LUCK" {
+		return character.Stats.LUCK, true
+	}
+	if value, ok := character.Attributes.AsMap()[name]; ok && name != "BW" {
+		return value, true
+	}
+	for i, skill := range character.Skills.Name {
+		if skill == name {
+			return character.Skills.Value[i], true
+		}
+	}
+	for i, skill := range character.CustomSkills.Name {
+		if skill == name {
+			return character.CustomSkills.Value[i], true
+		}
+	}
+	return 0, false
+}
+
+func (character Character) IsTicked(skill string) bool {
+	return slices.Contains(character.Ticks, skill)
+}
+
+// whether the name belongs to one of the character's skills or custom skills
+func (character Character) HasSkill(name string) bool {
+	return slices.Contains(character.Skills.Name, name) || slices.Contains(character.CustomSkills.Name, name)
+}
+
 func (character Character) AddableSkills(availableSkills Skills) Skills {
 	var addableSkills Skills
 	for i, sk := range availableSkills.Name {
@@ -28,16 +92,25 @@ func (character Character) AddableSkills(availableSkills Skills) Skills {
 	return addableSkills
 }
 
+func (character Character) IsCritical() bool {
+	return (character.Tracks("TP") && character.Stats.IsCritical("TP")) ||
+		(character.Tracks("STA") && character.Stats.IsCritical("STA"))
+}
+
 func (character Character) DeriveStats() CharacterStats {
 	tp := (character.Attributes.KO + character.Attributes.GR) / 10
-	sta := character.Attributes.MA
+	sta := min(character.Attributes.MA, character.MaxSanity())
 	mp := character.Attributes.MA / 5
 
-	res, _, err := dice.Roll("3d6kh3")
-	if err != nil {
-		return CharacterStats{}
+	// the young roll luck twice and keep the better result
+	var luck int
+	for range max(character.AgeBracket().LuckRolls, 1) {
+		res, _, err := dice.Roll("3d6kh3")
+		if err != nil {
+			return CharacterStats{}
+		}
+		luck = max(luck, res.Int()*5)
 	}
-	luck := res.Int() * 5
 
 	return CharacterStats{
 		MaxTP:   tp,
@@ -51,6 +124,51 @@ func (character Character) DeriveStats() CharacterStats {
 	}
 }
 
+// damage bonus, build and movement rate follow from the other attributes and the age.
+// NPCs keep the movement rate they were given, since creatures move as they please.
+func (character Character) DeriveAttributes() CharacterAttributes {
+	attributes := character.Attributes
+	attributes.DB, attributes.Build = damageBonus(attributes.ST + attributes.GR)
+	if !character.IsNPC() {
+		age, _ := strconv.Atoi(character.Info.Age)
+		attributes.BW = movementRate(attributes, age)
+	}
+	return attributes
+}
+
+// by ST+GR, every 80 points beyond 284 add another 1d6 and 1 build
+func damageBonus(sum int) (string, int) {
+	switch {
+	case sum < 65:
+		return "-2", -2
+	case sum < 85:
+		return "-1", -1
+	case sum < 125:
+		return "0", 0
+	case sum < 165:
+		return "+1d4", 1
+	case sum < 205:
+		return "+1d6", 2
+	}
+	dice := 2 + (sum-205)/80
+	return fmt.Sprintf("+%dd6", dice), dice + 1
+}
+
+// 7 if ST and GE are both below GR, 9 if both are above, 8 otherwise. from 40 on every decade costs 1.
+func movementRate(a CharacterAttributes, age int) int {
+	bw := 8
+	switch {
+	case a.ST < a.GR && a.GE < a.GR:
+		bw = 7
+	case a.ST > a.GR && a.GE > a.GR:
+		bw = 9
+	}
+	if age >= 40 {
+		bw -= (age - 30) / 10
+	}
+	return max(bw, 0)
+}
+
 type CharacterInfo struct {
 	Name       string
 	Profession string
@@ -81,6 +199,9 @@ type CharacterAttributes struct {
 	GR int
 	IN int
 	BW int
+
+	DB    string //damage bonus, derived from ST and GR
+	Build int
 }
 
 func (a CharacterAttributes) AsMap() map[string]int {
@@ -97,6 +218,35 @@ func (a CharacterAttributes) AsMap() map[string]int {
 	}
 }
 
+var ErrUnknownAttribute = errors.New("core: unknown attribute")
+
+// sets a single attribute. for investigators BW is overwritten by DeriveAttributes.
+func (a *CharacterAttributes) Set(name string, value int) error {
+	switch name {
+	case "BW":
+		a.BW = value
+	case "ST":
+		a.ST = value
+	case "GE":
+		a.GE = value
+	case "MA":
+		a.MA = value
+	case "KO":
+		a.KO = value
+	case "ER":
+		a.ER = value
+	case "BI":
+		a.BI = value
+	case "GR":
+		a.GR = value
+	case "IN":
+		a.IN = value
+	default:
+		return ErrUnknownAttribute
+	}
+	return nil
+}
+
 func (a CharacterAttributes) OrderedKeys() []string {
 	return []string{"ST", "GE", "MA", "KO", "ER", "BI", "GR", "IN", "BW"}
 }
@@ -110,6 +260,24 @@ type CharacterStats struct {
 	MP      int
 	MaxLUCK int
 	LUCK    int
+
+	SanityLossToday    int
+	TemporaryInsanity  bool
+	IndefiniteInsanity bool
+
+	MajorWound  bool
+	Unconscious bool
+	Dying       bool
+	Dead        bool
+}
+
+// losing a fifth of the sanity the day started with causes indefinite insanity
+func (st CharacterStats) DailySanityThreshold() int {
+	return Fifth(st.STA + st.SanityLossToday)
+}
+
+func (st CharacterStats) IsInsane() bool {
+	return st.TemporaryInsanity || st.IndefiniteInsanity || st.STA == 0
 }
 
 func (st CharacterStats) GetStatMax(stat string) int {
@@ -126,6 +294,19 @@ func (st CharacterStats) GetStatMax(stat string) int {
 	return -1
 }
 
+func (st CharacterStats) IsCritical(stat string) bool {
+	return IsCriticalValue(st.CurrentAsMap()[stat], st.GetStatMax(stat))
+}
+
+// a stat is critical once it has dropped to a fifth of its maximum
+func IsCriticalValue(current, max int) bool {
+	threshold := max / 5
+	if threshold == 0 {
+		threshold = 1
+	}
+	return current <= threshold
+}
+
 func (st CharacterStats) OrderedKeysCurrent() []string {
 	return []string{"TP", "STA", "MP", "LUCK"}
 }
@@ -0,0 +1,233 @@
+package core
+
+import (
+	"errors"
+	"slices"
+
+	"github.com/justinian/dice"
+)
+
+var (
+	ErrNotChasing        = errors.New("core: character is not part of the chase")
+	ErrNoMovementActions = errors.New("core: no movement actions left this round")
+)
+
+type ChaseRole string
+
+const (
+	Pursuer ChaseRole = "pursuer"
+	Quarry  ChaseRole = "quarry"
+)
+
+func (r ChaseRole) String() string {
+	if r == Quarry {
+		return "Flüchtender"
+	}
+	return "Verfolger"
+}
+
+type Obstacle string
+
+const (
+	NoObstacle Obstacle = ""
+	Hazard     Obstacle = "hazard"  //failing the roll gets the character through, but costs 1d3 movement actions
+	Barrier    Obstacle = "barrier" //failing the roll keeps the character from passing
+)
+
+func (o Obstacle) String() string {
+	switch o {
+	case Hazard:
+		return "Gefahr"
+	case Barrier:
+		return "Barriere"
+	}
+	return ""
+}
+
+type Location struct {
+	Position int
+	Name     string
+	Obstacle Obstacle
+	Skill    string //rolled to overcome the obstacle
+}
+
+type Chaser struct {
+	CharacterID int
+	Name        string
+	IsNPC       bool
+	Role        ChaseRole
+	MOV         int //after the speed roll
+	Position    int
+	Actions     int //movement actions left this round, negative if lost to a hazard beyond those left
+}
+
+type Chase struct {
+	CampaignID   int
+	Round        int
+	Participants []Chaser
+	Locations    []Location //only those the GM described, every other position is an empty stretch
+}
+
+// a location together with whoever is there right now
+type TrackLocation struct {
+	Location
+	Chasers []Chaser
+}
+
+type ChaseMove struct {
+	Chaser   Chaser
+	Location Location
+	Roll     *Roll //nil if the location had no obstacle
+	Lost     int   //movement actions lost to a hazard
+	Moved    bool
+}
+
+// the KO roll deciding a participant's speed for the chase: an extreme success adds 1 to MOV, a failure costs 1
+func SpeedRoll(characterId, ko, mov int) (Roll, int) {
+	roll := NewRoll(characterId, "KO", ko, 0)
+	roll.Source = SourceChase
+	switch {
+	case roll.Level >= Extreme:
+		mov++
+	case !roll.Succeeded():
+		mov--
+	}
+	return roll, max(mov, 1)
+}
+
+func (c Chase) slowestMOV() int {
+	slowest := 0
+	for i, p := range c.Participants {
+		if i == 0 || p.MOV < slowest {
+			slowest = p.MOV
+		}
+	}
+	return slowest
+}
+
+// everyone gets one movement action per round, plus one for every point of MOV above the slowest participant
+func (c Chase) MovementActions(chaser Chaser) int {
+	return 1 + chaser.MOV - c.slowestMOV()
+}
+
+// a quarry faster than every pursuer gets away right after the speed rolls
+func (c Chase) Escapes(chaser Chaser) bool {
+	if chaser.Role != Quarry {
+		return false
+	}
+	for _, p := range c.Participants {
+		if p.Role == Pursuer && p.MOV >= chaser.MOV {
+			return false
+		}
+	}
+	return true
+}
+
+// starts the next round, movement actions lost beyond those left are taken from the new ones
+func (c *Chase) NextRound() {
+	c.Round++
+	for i := range c.Participants {
+		c.Participants[i].Actions = c.MovementActions(c.Participants[i]) + min(c.Participants[i].Actions, 0)
+	}
+}
+
+func (c Chase) Participant(characterId int) (Chaser, bool) {
+	for _, p := range c.Participants {
+		if p.CharacterID == characterId {
+			return p, true
+		}
+	}
+	return Chaser{}, false
+}
+
+func (c Chase) Location(position int) Location {
+	for _, location := range c.Locations {
+		if location.Position == position {
+			return location
+		}
+	}
+	return Location{Position: position}
+}
+
+// the location the character would move to next
+func (c Chase) NextLocation(characterId int) (Location, error) {
+	chaser, ok := c.Participant(characterId)
+	if !ok {
+		return Location{}, ErrNotChasing
+	}
+	if chaser.Actions < 1 {
+		return Location{}, ErrNoMovementActions
+	}
+	return c.Location(chaser.Position + 1), nil
+}
+
+// moves the character one location ahead for a movement action. the roll only matters if the location
+// holds an obstacle and there has to be one then.
+func (c *Chase) Move(characterId int, roll *Roll) (ChaseMove, error) {
+	location, err := c.NextLocation(characterId)
+	if err != nil {
+		return ChaseMove{}, err
+	}
+	i := slices.IndexFunc(c.Participants, func(p Chaser) bool {
+		return p.CharacterID == characterId
+	})
+	chaser := &c.Participants[i]
+
+	move := ChaseMove{Location: location, Moved: true}
+	chaser.Actions--
+	if location.Obstacle != NoObstacle {
+		move.Roll = roll
+		if roll == nil || !roll.Succeeded() {
+			switch location.Obstacle {
+			case Hazard:
+				move.Lost = rollLostActions()
+				chaser.Actions -= move.Lost
+			case Barrier:
+				move.Moved = false
+			}
+		}
+	}
+	if move.Moved {
+		chaser.Position++
+	}
+	move.Chaser = *chaser
+	return move, nil
+}
+
+func rollLostActions() int {
+	res, _, err := dice.Roll("1d3")
+	if err != nil {
+		return 1
+	}
+	return res.Int()
+}
+
+// locations can be described at most this many positions beyond the end of the track
+const MaxLocationsAhead = 10
+
+// the last position of the track: one beyond the furthest participant or the furthest described location
+func (c Chase) TrackEnd() int {
+	last := 0
+	for _, p := range c.Participants {
+		last = max(last, p.Position+1)
+	}
+	for _, location := range c.Locations {
+		last = max(last, location.Position)
+	}
+	return last
+}
+
+// every position from the start up to the end of the track
+func (c Chase) Track() []TrackLocation {
+	last := c.TrackEnd()
+	track := make([]TrackLocation, last+1)
+	for position := range track {
+		track[position].Location = c.Location(position)
+	}
+	for _, p := range c.Participants {
+		if p.Position >= 0 && p.Position <= last {
+			track[p.Position].Chasers = append(track[p.Position].Chasers, p)
+		}
+	}
+	return track
+}
@@ -0,0 +1,245 @@
+package core
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/winik100/NoPenNoPaper/internal/testHelpers"
+)
+
+func TestMovementActions(t *testing.T) {
+	chase := Chase{Participants: []Chaser{
+		{CharacterID: 1, Role: Quarry, MOV: 8},
+		{CharacterID: 2, Role: Pursuer, MOV: 7},
+		{CharacterID: 3, Role: Pursuer, MOV: 10},
+	}}
+
+	tests := []struct {
+		name        string
+		chaser      Chaser
+		wantActions int
+	}{
+		{
+			name:        "Slowest",
+			chaser:      chase.Participants[1],
+			wantActions: 1,
+		},
+		{
+			name:        "Faster Than The Slowest",
+			chaser:      chase.Participants[0],
+			wantActions: 2,
+		},
+		{
+			name:        "Fastest",
+			chaser:      chase.Participants[2],
+			wantActions: 4,
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			testHelpers.Equal(t, chase.MovementActions(testCase.chaser), testCase.wantActions)
+		})
+	}
+}
+
+func TestEscapes(t *testing.T) {
+	tests := []struct {
+		name        string
+		pursuers    []int
+		quarryMOV   int
+		wantEscapes bool
+	}{
+		{
+			name:        "Faster Than Every Pursuer",
+			pursuers:    []int{7, 8},
+			quarryMOV:   9,
+			wantEscapes: true,
+		},
+		{
+			name:        "As Fast As A Pursuer",
+			pursuers:    []int{7, 9},
+			quarryMOV:   9,
+			wantEscapes: false,
+		},
+		{
+			name:        "Nobody Pursuing",
+			quarryMOV:   1,
+			wantEscapes: true,
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			quarry := Chaser{CharacterID: 1, Role: Quarry, MOV: testCase.quarryMOV}
+			chase := Chase{Participants: []Chaser{quarry}}
+			for i, mov := range testCase.pursuers {
+				chase.Participants = append(chase.Participants, Chaser{CharacterID: i + 2, Role: Pursuer, MOV: mov})
+			}
+			testHelpers.Equal(t, chase.Escapes(quarry), testCase.wantEscapes)
+		})
+	}
+}
+
+func TestNextRound(t *testing.T) {
+	chase := Chase{Round: 1, Participants: []Chaser{
+		{CharacterID: 1, MOV: 9, Actions: 1},
+		{CharacterID: 2, MOV: 7, Actions: 0},
+		{CharacterID: 3, MOV: 8, Actions: -2},
+	}}
+
+	chase.NextRound()
+
+	testHelpers.Equal(t, chase.Round, 2)
+	testHelpers.Equal(t, chase.Participants[0].Actions, 3)
+	testHelpers.Equal(t, chase.Participants[1].Actions, 1)
+	testHelpers.Equal(t, chase.Participants[2].Actions, 0)
+}
+
+func TestChaseMove(t *testing.T) {
+	success := &Roll{Level: Regular}
+	failure := &Roll{Level: Failure}
+
+	tests := []struct {
+		name         string
+		obstacle     Obstacle
+		roll         *Roll
+		actions      int
+		wantErr      error
+		wantMoved    bool
+		wantPosition int
+		wantLost     bool
+	}{
+		{
+			name:         "Empty Stretch",
+			actions:      1,
+			wantMoved:    true,
+			wantPosition: 3,
+		},
+		{
+			name:         "Hazard Overcome",
+			obstacle:     Hazard,
+			roll:         success,
+			actions:      1,
+			wantMoved:    true,
+			wantPosition: 3,
+		},
+		{
+			name:         "Hazard Failed",
+			obstacle:     Hazard,
+			roll:         failure,
+			actions:      1,
+			wantMoved:    true,
+			wantPosition: 3,
+			wantLost:     true,
+		},
+		{
+			name:         "Barrier Overcome",
+			obstacle:     Barrier,
+			roll:         success,
+			actions:      2,
+			wantMoved:    true,
+			wantPosition: 3,
+		},
+		{
+			name:         "Barrier Failed",
+			obstacle:     Barrier,
+			roll:         failure,
+			actions:      2,
+			wantMoved:    false,
+			wantPosition: 2,
+		},
+		{
+			name:         "Barrier Without Roll",
+			obstacle:     Barrier,
+			actions:      2,
+			wantMoved:    false,
+			wantPosition: 2,
+		},
+		{
+			name:    "No Actions Left",
+			actions: 0,
+			wantErr: ErrNoMovementActions,
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			chase := Chase{
+				Participants: []Chaser{{CharacterID: 1, Position: 2, Actions: testCase.actions}},
+				Locations:    []Location{{Position: 3, Name: "Marktplatz", Obstacle: testCase.obstacle, Skill: "Springen"}},
+			}
+
+			move, err := chase.Move(1, testCase.roll)
+			if testCase.wantErr != nil {
+				testHelpers.Equal(t, errors.Is(err, testCase.wantErr), true)
+				return
+			}
+			testHelpers.NilError(t, err)
+			testHelpers.Equal(t, move.Moved, testCase.wantMoved)
+			testHelpers.Equal(t, move.Chaser.Position, testCase.wantPosition)
+			testHelpers.Equal(t, chase.Participants[0].Position, testCase.wantPosition)
+			testHelpers.Equal(t, move.Location.Name, "Marktplatz")
+			if testCase.wantLost {
+				if move.Lost < 1 || move.Lost > 3 {
+					t.Fatalf("lost %d movement actions", move.Lost)
+				}
+			} else {
+				testHelpers.Equal(t, move.Lost, 0)
+			}
+			testHelpers.Equal(t, move.Chaser.Actions, testCase.actions-1-move.Lost)
+		})
+	}
+
+	_, err := Chase{}.NextLocation(1)
+	testHelpers.Equal(t, errors.Is(err, ErrNotChasing), true)
+}
+
+func TestTrack(t *testing.T) {
+	tests := []struct {
+		name       string
+		chase      Chase
+		wantLength int
+	}{
+		{
+			name:       "Nobody Moved",
+			chase:      Chase{Participants: []Chaser{{CharacterID: 1}}},
+			wantLength: 2,
+		},
+		{
+			name: "One Beyond The Furthest",
+			chase: Chase{Participants: []Chaser{
+				{CharacterID: 1, Position: 4},
+				{CharacterID: 2, Position: 1},
+			}},
+			wantLength: 6,
+		},
+		{
+			name: "Up To The Furthest Location",
+			chase: Chase{
+				Participants: []Chaser{{CharacterID: 1, Position: 1}},
+				Locations:    []Location{{Position: 7, Name: "Hafen"}},
+			},
+			wantLength: 8,
+		},
+		{
+			name:       "Empty",
+			chase:      Chase{},
+			wantLength: 1,
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			track := testCase.chase.Track()
+			testHelpers.Equal(t, len(track), testCase.wantLength)
+			testHelpers.Equal(t, testCase.chase.TrackEnd(), testCase.wantLength-1)
+			for position, location := range track {
+				testHelpers.Equal(t, location.Position, position)
+			}
+			for _, p := range testCase.chase.Participants {
+				testHelpers.Equal(t, track[p.Position].Chasers[0].Position, p.Position)
+			}
+		})
+	}
+}
@@ -0,0 +1,130 @@
+package core
+
+import (
+	"cmp"
+	"slices"
+
+	"github.com/justinian/dice"
+)
+
+// bonus on GE for characters who start the round with a readied firearm
+const ReadiedFirearmBonus = 50
+
+type Combat struct {
+	CampaignID   int
+	Round        int
+	CurrentID    int //character whose turn it is
+	TargetID     int //0 if no target was chosen
+	Participants []Combatant
+}
+
+type Combatant struct {
+	CharacterID      int
+	Name             string
+	IsNPC            bool
+	GE               int
+	ReadiedFirearm   bool
+	ReadiedNextRound bool //readying or lowering a firearm only counts once the next round starts
+	TieBreak         int  //rolled once when joining the combat
+	DefendedRound    int  //last round the combatant dodged or fought back, 0 if never
+	TP               int
+	MaxTP            int
+}
+
+func (c Combatant) Initiative() int {
+	if c.ReadiedFirearm {
+		return c.GE + ReadiedFirearmBonus
+	}
+	return c.GE
+}
+
+// d100 roll deciding between combatants with equal initiative and GE
+func RollTieBreak() int {
+	res, _, err := dice.Roll("1d100")
+	if err != nil {
+		return 1
+	}
+	return res.Int()
+}
+
+// orders combatants by initiative, ties go to the higher GE and then the higher tie break roll
+func SortByInitiative(combatants []Combatant) {
+	slices.SortStableFunc(combatants, func(a, b Combatant) int {
+		return cmp.Or(
+			cmp.Compare(b.Initiative(), a.Initiative()),
+			cmp.Compare(b.GE, a.GE),
+			cmp.Compare(b.TieBreak, a.TieBreak),
+			cmp.Compare(a.CharacterID, b.CharacterID),
+		)
+	})
+}
+
+// whether the combatant shares its initiative with another one
+func (c Combat) IsTied(characterId int) bool {
+	var initiative int
+	for _, p := range c.Participants {
+		if p.CharacterID == characterId {
+			initiative = p.Initiative()
+		}
+	}
+	for _, p := range c.Participants {
+		if p.CharacterID != characterId && p.Initiative() == initiative {
+			return true
+		}
+	}
+	return false
+}
+
+func (c Combat) Current() (Combatant, bool) {
+	for _, p := range c.Participants {
+		if p.CharacterID == c.CurrentID {
+			return p, true
+		}
+	}
+	return Combatant{}, false
+}
+
+func (c Combat) Target() (Combatant, bool) {
+	for _, p := range c.Participants {
+		if p.CharacterID == c.TargetID {
+			return p, true
+		}
+	}
+	return Combatant{}, false
+}
+
+func (c Combat) HasParticipant(characterId int) bool {
+	return slices.ContainsFunc(c.Participants, func(p Combatant) bool {
+		return p.CharacterID == characterId
+	})
+}
+
+// hands the turn to the next combatant, starting a new round after the last one.
+// expects the participants to be sorted by initiative.
+func (c *Combat) Advance() {
+	c.TargetID = 0
+	if len(c.Participants) == 0 {
+		return
+	}
+
+	index := slices.IndexFunc(c.Participants, func(p Combatant) bool {
+		return p.CharacterID == c.CurrentID
+	})
+	if index == -1 || index == len(c.Participants)-1 {
+		if index != -1 {
+			c.Round++
+			c.startRound()
+		}
+		c.CurrentID = c.Participants[0].CharacterID
+		return
+	}
+	c.CurrentID = c.Participants[index+1].CharacterID
+}
+
+// firearms readied during the last round count from now on, which may change the turn order
+func (c *Combat) startRound() {
+	for i := range c.Participants {
+		c.Participants[i].ReadiedFirearm = c.Participants[i].ReadiedNextRound
+	}
+	SortByInitiative(c.Participants)
+}
@@ -0,0 +1,103 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/winik100/NoPenNoPaper/internal/testHelpers"
+)
+
+func TestSortByInitiative(t *testing.T) {
+	combatants := []Combatant{
+		{CharacterID: 1, GE: 50, TieBreak: 10},
+		{CharacterID: 2, GE: 70, TieBreak: 10},
+		{CharacterID: 3, GE: 40, ReadiedFirearm: true, TieBreak: 10},
+		{CharacterID: 4, GE: 50, TieBreak: 90},
+		{CharacterID: 5, GE: 50, TieBreak: 10},
+	}
+
+	SortByInitiative(combatants)
+
+	// 90 with a readied firearm, 70, then 50 three times: the higher tie break, then the lower id
+	want := []int{3, 2, 4, 1, 5}
+	for i, characterId := range want {
+		testHelpers.Equal(t, combatants[i].CharacterID, characterId)
+	}
+
+	combat := Combat{Participants: combatants}
+	testHelpers.Equal(t, combat.IsTied(1), true)
+	testHelpers.Equal(t, combat.IsTied(2), false)
+	testHelpers.Equal(t, combat.IsTied(3), false)
+}
+
+func TestAdvance(t *testing.T) {
+	combat := Combat{
+		Round:     1,
+		CurrentID: 1,
+		TargetID:  2,
+		Participants: []Combatant{
+			{CharacterID: 1, GE: 70},
+			{CharacterID: 2, GE: 60},
+			{CharacterID: 3, GE: 50},
+		},
+	}
+
+	combat.Advance()
+	testHelpers.Equal(t, combat.CurrentID, 2)
+	testHelpers.Equal(t, combat.TargetID, 0)
+
+	// readying a firearm mid-round keeps the order until the round is over
+	combat.Participants[2].ReadiedNextRound = true
+	combat.Advance()
+	testHelpers.Equal(t, combat.CurrentID, 3)
+	testHelpers.Equal(t, combat.Round, 1)
+	testHelpers.Equal(t, combat.Participants[2].ReadiedFirearm, false)
+
+	combat.Advance()
+	testHelpers.Equal(t, combat.Round, 2)
+	testHelpers.Equal(t, combat.CurrentID, 3)
+	testHelpers.Equal(t, combat.Participants[0].Initiative(), 100)
+
+	combat.Advance()
+	testHelpers.Equal(t, combat.CurrentID, 1)
+	combat.Advance()
+	testHelpers.Equal(t, combat.CurrentID, 2)
+	combat.Advance()
+	testHelpers.Equal(t, combat.Round, 3)
+	testHelpers.Equal(t, combat.CurrentID, 3)
+}
+
+func TestAdvanceWithoutCurrent(t *testing.T) {
+	tests := []struct {
+		name          string
+		combat        Combat
+		wantCurrentID int
+		wantRound     int
+	}{
+		{
+			name:          "Current Combatant Left",
+			combat:        Combat{Round: 2, CurrentID: 9, Participants: []Combatant{{CharacterID: 1}, {CharacterID: 2}}},
+			wantCurrentID: 1,
+			wantRound:     2,
+		},
+		{
+			name:          "Nobody Left",
+			combat:        Combat{Round: 2, CurrentID: 9},
+			wantCurrentID: 9,
+			wantRound:     2,
+		},
+		{
+			name:          "Single Combatant",
+			combat:        Combat{Round: 2, CurrentID: 1, Participants: []Combatant{{CharacterID: 1}}},
+			wantCurrentID: 1,
+			wantRound:     3,
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			testCase.combat.Advance()
+			testHelpers.Equal(t, testCase.combat.CurrentID, testCase.wantCurrentID)
+			testHelpers.Equal(t, testCase.combat.Round, testCase.wantRound)
+		})
+	}
+}
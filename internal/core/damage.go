@@ -0,0 +1,79 @@
+package core
+
+import "strings"
+
+// outcome of a single hit
+type Damage struct {
+	Amount      int
+	TP          int  //hit points left after the hit
+	MajorWound  bool //the hit itself was a major wound
+	Unconscious bool
+	Dying       bool
+	Dead        bool
+}
+
+// evaluates a single hit: at least half the maximum hit points is a major wound, more than the
+// maximum kills outright. at 0 hit points a major wound means dying, otherwise unconsciousness.
+func (st CharacterStats) TakeDamage(amount int) Damage {
+	damage := Damage{
+		Amount:      amount,
+		TP:          max(st.TP-amount, 0),
+		MajorWound:  amount*2 >= st.MaxTP,
+		Unconscious: st.Unconscious,
+		Dying:       st.Dying,
+		Dead:        st.Dead || amount > st.MaxTP,
+	}
+	if damage.TP == 0 && !damage.Dead {
+		if st.MajorWound || damage.MajorWound {
+			damage.Dying = true
+			damage.Unconscious = false
+		} else {
+			damage.Unconscious = !damage.Dying
+		}
+	}
+	return damage
+}
+
+// what the hit caused, empty if nothing but hit points were lost
+func (d Damage) Consequences() string {
+	var consequences []string
+	if d.MajorWound {
+		consequences = append(consequences, "Schwere Wunde")
+	}
+	switch {
+	case d.Dead:
+		consequences = append(consequences, "Tot")
+	case d.Dying:
+		consequences = append(consequences, "Sterbend")
+	case d.Unconscious:
+		consequences = append(consequences, "Bewusstlos")
+	}
+	return strings.Join(consequences, ", ")
+}
+
+// applies the outcome of a hit to the stats
+func (st CharacterStats) Apply(damage Damage) CharacterStats {
+	st.TP = damage.TP
+	st.MajorWound = st.MajorWound || damage.MajorWound
+	st.Unconscious = damage.Unconscious
+	st.Dying = damage.Dying
+	st.Dead = damage.Dead
+	return st
+}
+
+// the most severe condition first, empty while the character is unharmed
+func (st CharacterStats) Condition() string {
+	var conditions []string
+	switch {
+	case st.Dead:
+		return "Tot"
+	case st.Dying:
+		conditions = append(conditions, "Sterbend")
+	case st.Unconscious:
+		conditions = append(conditions, "Bewusstlos")
+	}
+	if st.MajorWound {
+		conditions = append(conditions, "Schwere Wunde")
+	}
+	return strings.Join(conditions, ", ")
+}
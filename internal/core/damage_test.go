@@ -0,0 +1,171 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/winik100/NoPenNoPaper/internal/testHelpers"
+)
+
+func TestTakeDamage(t *testing.T) {
+	tests := []struct {
+		name   string
+		stats  CharacterStats
+		amount int
+		want   Damage
+	}{
+		{
+			name:   "Scratch",
+			stats:  CharacterStats{MaxTP: 12, TP: 12},
+			amount: 3,
+			want:   Damage{Amount: 3, TP: 9},
+		},
+		{
+			name:   "Just Below Half",
+			stats:  CharacterStats{MaxTP: 12, TP: 12},
+			amount: 5,
+			want:   Damage{Amount: 5, TP: 7},
+		},
+		{
+			name:   "Half Is A Major Wound",
+			stats:  CharacterStats{MaxTP: 12, TP: 12},
+			amount: 6,
+			want:   Damage{Amount: 6, TP: 6, MajorWound: true},
+		},
+		{
+			name:   "Half Of An Odd Maximum",
+			stats:  CharacterStats{MaxTP: 11, TP: 11},
+			amount: 6,
+			want:   Damage{Amount: 6, TP: 5, MajorWound: true},
+		},
+		{
+			name:   "Down To 0 Knocks Out",
+			stats:  CharacterStats{MaxTP: 12, TP: 4},
+			amount: 4,
+			want:   Damage{Amount: 4, TP: 0, Unconscious: true},
+		},
+		{
+			name:   "Hit Points Stop At 0",
+			stats:  CharacterStats{MaxTP: 12, TP: 4},
+			amount: 5,
+			want:   Damage{Amount: 5, TP: 0, Unconscious: true},
+		},
+		{
+			name:   "Down To 0 With A Major Wound",
+			stats:  CharacterStats{MaxTP: 12, TP: 6},
+			amount: 6,
+			want:   Damage{Amount: 6, TP: 0, MajorWound: true, Dying: true},
+		},
+		{
+			name:   "Down To 0 After An Earlier Major Wound",
+			stats:  CharacterStats{MaxTP: 12, TP: 2, MajorWound: true},
+			amount: 2,
+			want:   Damage{Amount: 2, TP: 0, Dying: true},
+		},
+		{
+			name:   "Hit At 0 While Unconscious",
+			stats:  CharacterStats{MaxTP: 12, TP: 0, Unconscious: true},
+			amount: 1,
+			want:   Damage{Amount: 1, TP: 0, Unconscious: true},
+		},
+		{
+			name:   "No Damage At 0",
+			stats:  CharacterStats{MaxTP: 12, TP: 0, Unconscious: true},
+			amount: 0,
+			want:   Damage{Amount: 0, TP: 0, Unconscious: true},
+		},
+		{
+			name:   "Major Wound At 0 While Unconscious",
+			stats:  CharacterStats{MaxTP: 12, TP: 0, Unconscious: true},
+			amount: 6,
+			want:   Damage{Amount: 6, TP: 0, MajorWound: true, Dying: true},
+		},
+		{
+			name:   "Hit At 0 While Dying",
+			stats:  CharacterStats{MaxTP: 12, TP: 0, MajorWound: true, Dying: true},
+			amount: 1,
+			want:   Damage{Amount: 1, TP: 0, Dying: true},
+		},
+		{
+			name:   "Exactly The Maximum",
+			stats:  CharacterStats{MaxTP: 12, TP: 12},
+			amount: 12,
+			want:   Damage{Amount: 12, TP: 0, MajorWound: true, Dying: true},
+		},
+		{
+			name:   "More Than The Maximum Kills",
+			stats:  CharacterStats{MaxTP: 12, TP: 12},
+			amount: 13,
+			want:   Damage{Amount: 13, TP: 0, MajorWound: true, Dead: true},
+		},
+		{
+			name:   "The Dead Stay Dead",
+			stats:  CharacterStats{MaxTP: 12, TP: 0, Dead: true},
+			amount: 1,
+			want:   Damage{Amount: 1, TP: 0, Dead: true},
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			testHelpers.Equal(t, testCase.stats.TakeDamage(testCase.amount), testCase.want)
+		})
+	}
+}
+
+func TestApplyDamage(t *testing.T) {
+	stats := CharacterStats{MaxTP: 12, TP: 12}
+
+	stats = stats.Apply(stats.TakeDamage(6))
+	testHelpers.Equal(t, stats.TP, 6)
+	testHelpers.Equal(t, stats.Condition(), "Schwere Wunde")
+
+	stats = stats.Apply(stats.TakeDamage(1))
+	testHelpers.Equal(t, stats.TP, 5)
+	testHelpers.Equal(t, stats.MajorWound, true)
+
+	stats = stats.Apply(stats.TakeDamage(5))
+	testHelpers.Equal(t, stats.Condition(), "Sterbend, Schwere Wunde")
+
+	stats = stats.Apply(stats.TakeDamage(13))
+	testHelpers.Equal(t, stats.Condition(), "Tot")
+}
+
+func TestConsequences(t *testing.T) {
+	tests := []struct {
+		name   string
+		damage Damage
+		want   string
+	}{
+		{
+			name:   "Nothing",
+			damage: Damage{Amount: 2, TP: 8},
+			want:   "",
+		},
+		{
+			name:   "Major Wound",
+			damage: Damage{Amount: 6, TP: 6, MajorWound: true},
+			want:   "Schwere Wunde",
+		},
+		{
+			name:   "Unconscious",
+			damage: Damage{Amount: 2, TP: 0, Unconscious: true},
+			want:   "Bewusstlos",
+		},
+		{
+			name:   "Dying",
+			damage: Damage{Amount: 6, TP: 0, MajorWound: true, Dying: true},
+			want:   "Schwere Wunde, Sterbend",
+		},
+		{
+			name:   "Dead",
+			damage: Damage{Amount: 13, TP: 0, MajorWound: true, Dead: true},
+			want:   "Schwere Wunde, Tot",
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			testHelpers.Equal(t, testCase.damage.Consequences(), testCase.want)
+		})
+	}
+}
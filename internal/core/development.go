@@ -0,0 +1,54 @@
+package core
+
+import "github.com/justinian/dice"
+
+// skill value that grants sanity once reached
+const SkillMastery = 90
+
+type Improvement struct {
+	Skill      string
+	Roll       int
+	Old        int
+	New        int
+	SanityGain int
+}
+
+func (i Improvement) Improved() bool {
+	return i.New > i.Old
+}
+
+// rolls d100 against the skill. above the value (or 96+) the skill rises by 1d10,
+// reaching 90 for the first time also grants 2d6 sanity.
+func DevelopSkill(skill string, value int) Improvement {
+	improvement := Improvement{Skill: skill, Roll: RollD100(), Old: value, New: value}
+	if improvement.Roll <= value && improvement.Roll < 96 {
+		return improvement
+	}
+
+	res, _, err := dice.Roll("1d10")
+	if err != nil {
+		return improvement
+	}
+	improvement.New = value + res.Int()
+
+	if improvement.Old < SkillMastery && improvement.New >= SkillMastery {
+		res, _, err = dice.Roll("2d6")
+		if err == nil {
+			improvement.SanityGain = res.Int()
+		}
+	}
+	return improvement
+}
+
+// runs the development phase for every ticked skill of the character
+func (character Character) Develop() []Improvement {
+	var improvements []Improvement
+	for _, skill := range character.Ticks {
+		value, ok := character.RollTarget(skill)
+		if !ok {
+			continue
+		}
+		improvements = append(improvements, DevelopSkill(skill, value))
+	}
+	return improvements
+}
@@ -0,0 +1,67 @@
+package core
+
+import (
+	"fmt"
+
+	"github.com/justinian/dice"
+)
+
+// the dice rolled for one attribute, multiplied by 5 after adding the bonus
+type AttributeRoll struct {
+	Attribute string
+	Dice      []int
+	Bonus     int
+	Value     int
+}
+
+var attributeDice = []struct {
+	attribute string
+	count     int
+	bonus     int
+}{
+	{"ST", 3, 0},
+	{"KO", 3, 0},
+	{"GE", 3, 0},
+	{"ER", 3, 0},
+	{"MA", 3, 0},
+	{"GR", 2, 6},
+	{"IN", 2, 6},
+	{"BI", 2, 6},
+}
+
+// the rules' alternative to the fixed array: 3d6×5 for ST, KO, GE, ER and MA, (2d6+6)×5 for GR, IN and BI
+func RollAttributes() (CharacterAttributes, []AttributeRoll, error) {
+	var attributes CharacterAttributes
+	var rolls []AttributeRoll
+	for _, ad := range attributeDice {
+		res, _, err := dice.Roll(fmt.Sprintf("%dd6", ad.count))
+		if err != nil {
+			return CharacterAttributes{}, nil, err
+		}
+		roll := AttributeRoll{Attribute: ad.attribute, Bonus: ad.bonus, Value: (res.Int() + ad.bonus) * 5}
+		if std, ok := res.(dice.StdResult); ok {
+			roll.Dice = std.Rolls
+		}
+
+		err = attributes.Set(ad.attribute, roll.Value)
+		if err != nil {
+			return CharacterAttributes{}, nil, err
+		}
+		rolls = append(rolls, roll)
+	}
+	return attributes, rolls, nil
+}
+
+// whether the attributes are exactly the rolled ones, BW is derived and not rolled
+func MatchesRolls(attributes CharacterAttributes, rolls []AttributeRoll) bool {
+	if len(rolls) != len(attributeDice) {
+		return false
+	}
+	values := attributes.AsMap()
+	for _, roll := range rolls {
+		if values[roll.Attribute] != roll.Value {
+			return false
+		}
+	}
+	return true
+}
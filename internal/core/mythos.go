@@ -0,0 +1,82 @@
+package core
+
+type Spell struct {
+	ID          int
+	Name        string
+	MPCost      int
+	SANCost     string //a flat number or dice like 1d6, rolled on every casting
+	CastingTime string
+}
+
+type Tome struct {
+	ID         int
+	Name       string
+	Language   string
+	MythosGain int    //Cthulhu-Mythos gained by a full study
+	SANLoss    string //a flat number or dice like 2d10, rolled once the study is done
+	StudyWeeks int
+	Studied    bool //only set for tomes a character owns
+}
+
+// sanity lost outside of a check, e.g. to casting a spell or studying a tome
+type SanityCost struct {
+	Lost       int
+	Temporary  bool //temporary insanity set in with this loss
+	Indefinite bool //indefinite insanity set in with this loss
+	Bout       string
+}
+
+func (c SanityCost) CausedInsanity() bool {
+	return c.Temporary || c.Indefinite
+}
+
+type SpellCasting struct {
+	CharacterID int
+	Spell       Spell
+	Sanity      SanityCost
+}
+
+type TomeStudy struct {
+	CharacterID int
+	Tome        Tome
+	Sanity      SanityCost
+	Mythos      int //Cthulhu-Mythos after the study, filled in once it is stored
+}
+
+func (character Character) KnownSpell(spellId int) (Spell, bool) {
+	for _, spell := range character.Spells {
+		if spell.ID == spellId {
+			return spell, true
+		}
+	}
+	return Spell{}, false
+}
+
+func (character Character) OwnedTome(tomeId int) (Tome, bool) {
+	for _, tome := range character.Tomes {
+		if tome.ID == tomeId {
+			return tome, true
+		}
+	}
+	return Tome{}, false
+}
+
+// rolls the sanity cost of the spell. whether the character has the magic points left is only
+// checked when the casting is stored, so the cost is deducted atomically.
+func (character Character) CastSpell(spell Spell) SpellCasting {
+	return SpellCasting{CharacterID: character.ID, Spell: spell, Sanity: character.loseSanity(spell.SANCost)}
+}
+
+// rolls the sanity lost to a full study of the tome
+func (character Character) StudyTome(tome Tome) TomeStudy {
+	return TomeStudy{CharacterID: character.ID, Tome: tome, Sanity: character.loseSanity(tome.SANLoss)}
+}
+
+func (character Character) loseSanity(part string) SanityCost {
+	cost := SanityCost{Lost: min(rollLoss(part), character.Stats.STA)}
+	cost.Temporary, cost.Indefinite = character.Stats.insanityFrom(cost.Lost)
+	if cost.CausedInsanity() {
+		cost.Bout = RollBout()
+	}
+	return cost
+}
@@ -0,0 +1,114 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// the skill credit rating is tracked as, its value has to stay within the occupation's range
+const CreditRating = "Finanzkraft"
+
+var ErrInvalidSkillPoints = errors.New("core: invalid skill point formula")
+
+// a term of a skill point formula like BI×4 or (GE|ST)×2, alternatives count with the highest attribute
+var skillPointTerm = regexp.MustCompile(`^\(?([A-Z]{2}(?:\|[A-Z]{2})*)\)?[×*x](\d+)$`)
+
+type CreditRange struct {
+	Min int
+	Max int
+}
+
+type Occupation struct {
+	ID          int
+	Name        string
+	SkillPoints string   //formula of the occupation skill points, e.g. BI×2+(GE|ST)×2
+	Skills      []string //skill names or custom skill categories
+	Credit      CreditRange
+}
+
+// evaluates the occupation's skill point formula for the attributes
+func (o Occupation) OccupationPoints(attributes CharacterAttributes) (int, error) {
+	values := attributes.AsMap()
+	points := 0
+	for _, term := range strings.Split(strings.ReplaceAll(o.SkillPoints, " ", ""), "+") {
+		match := skillPointTerm.FindStringSubmatch(term)
+		if match == nil {
+			return 0, ErrInvalidSkillPoints
+		}
+		factor, err := strconv.Atoi(match[2])
+		if err != nil {
+			return 0, ErrInvalidSkillPoints
+		}
+
+		best := 0
+		for _, attr := range strings.Split(match[1], "|") {
+			value, ok := values[attr]
+			if !ok {
+				return 0, ErrInvalidSkillPoints
+			}
+			best = max(best, value)
+		}
+		points += best * factor
+	}
+	return points, nil
+}
+
+// credit rating always counts as an occupation skill
+func (o Occupation) IsOccupationSkill(name, category string) bool {
+	return name == CreditRating || slices.Contains(o.Skills, name) || (category != "" && slices.Contains(o.Skills, category))
+}
+
+// points for skills of the player's choice
+func PersonalInterestPoints(attributes CharacterAttributes) int {
+	return attributes.IN * 2
+}
+
+// a skill as chosen at creation, every point above the base has to be paid for
+type SkillRaise struct {
+	Name     string
+	Category string //only set for custom skills
+	Base     int
+	Value    int
+}
+
+type SkillPointBudget struct {
+	Occupation       int
+	PersonalInterest int
+	SpentOccupation  int
+	SpentPersonal    int
+}
+
+// occupation skills are paid from the occupation points first, anything else from the personal interest points
+func (o Occupation) SpendSkillPoints(attributes CharacterAttributes, raises []SkillRaise) (SkillPointBudget, error) {
+	occupationPoints, err := o.OccupationPoints(attributes)
+	if err != nil {
+		return SkillPointBudget{}, err
+	}
+	budget := SkillPointBudget{Occupation: occupationPoints, PersonalInterest: PersonalInterestPoints(attributes)}
+
+	occupational := 0
+	for _, raise := range raises {
+		points := max(raise.Value-raise.Base, 0)
+		if o.IsOccupationSkill(raise.Name, raise.Category) {
+			occupational += points
+		} else {
+			budget.SpentPersonal += points
+		}
+	}
+	budget.SpentOccupation = min(occupational, budget.Occupation)
+	budget.SpentPersonal += occupational - budget.SpentOccupation
+	return budget, nil
+}
+
+func (b SkillPointBudget) Exceeded() bool {
+	return b.SpentOccupation > b.Occupation || b.SpentPersonal > b.PersonalInterest
+}
+
+func (b SkillPointBudget) String() string {
+	return fmt.Sprintf("Berufspunkte: %d/%d, persönliche Interessen: %d/%d",
+		b.SpentOccupation, b.Occupation, b.SpentPersonal, b.PersonalInterest)
+}
@@ -0,0 +1,198 @@
+package core
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/winik100/NoPenNoPaper/internal/testHelpers"
+)
+
+func TestOccupationPoints(t *testing.T) {
+	attributes := CharacterAttributes{ST: 40, GE: 70, BI: 60, ER: 50}
+
+	tests := []struct {
+		name        string
+		skillPoints string
+		want        int
+		wantErr     error
+	}{
+		{
+			name:        "Single Attribute",
+			skillPoints: "BI×4",
+			want:        240,
+		},
+		{
+			name:        "Alternatives Count With The Highest",
+			skillPoints: "BI×2+(GE|ST)×2",
+			want:        260,
+		},
+		{
+			name:        "Three Alternatives",
+			skillPoints: "BI×2+(ST|ER|GE)×2",
+			want:        260,
+		},
+		{
+			name:        "Other Multiplication Signs And Spaces",
+			skillPoints: "BI*2 + ER x2",
+			want:        220,
+		},
+		{
+			name:        "Missing Factor",
+			skillPoints: "BI×",
+			wantErr:     ErrInvalidSkillPoints,
+		},
+		{
+			name:        "Unknown Attribute",
+			skillPoints: "XY×4",
+			wantErr:     ErrInvalidSkillPoints,
+		},
+		{
+			name:        "Lower Case",
+			skillPoints: "bi×4",
+			wantErr:     ErrInvalidSkillPoints,
+		},
+		{
+			name:        "Trailing Plus",
+			skillPoints: "BI×4+",
+			wantErr:     ErrInvalidSkillPoints,
+		},
+		{
+			name:        "Empty",
+			skillPoints: "",
+			wantErr:     ErrInvalidSkillPoints,
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			points, err := Occupation{SkillPoints: testCase.skillPoints}.OccupationPoints(attributes)
+			if testCase.wantErr != nil {
+				testHelpers.Equal(t, errors.Is(err, testCase.wantErr), true)
+				return
+			}
+			testHelpers.NilError(t, err)
+			testHelpers.Equal(t, points, testCase.want)
+		})
+	}
+}
+
+func TestIsOccupationSkill(t *testing.T) {
+	occupation := Occupation{Skills: []string{"Bibliotheksnutzung", "Fremdsprache"}}
+
+	tests := []struct {
+		name     string
+		skill    string
+		category string
+		want     bool
+	}{
+		{
+			name:  "Listed Skill",
+			skill: "Bibliotheksnutzung",
+			want:  true,
+		},
+		{
+			name:  "Credit Rating",
+			skill: CreditRating,
+			want:  true,
+		},
+		{
+			name:     "Custom Skill Of A Listed Category",
+			skill:    "Latein",
+			category: "Fremdsprache",
+			want:     true,
+		},
+		{
+			name:     "Custom Skill Of Another Category",
+			skill:    "Malerei",
+			category: "Kunst/Handwerk",
+			want:     false,
+		},
+		{
+			name:  "Other Skill",
+			skill: "Klettern",
+			want:  false,
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			testHelpers.Equal(t, occupation.IsOccupationSkill(testCase.skill, testCase.category), testCase.want)
+		})
+	}
+}
+
+func TestSpendSkillPoints(t *testing.T) {
+	// 120 occupation points, 100 personal interest points
+	occupation := Occupation{SkillPoints: "BI×2", Skills: []string{"Bibliotheksnutzung", "Geschichte"}}
+	attributes := CharacterAttributes{BI: 60, IN: 50}
+
+	tests := []struct {
+		name         string
+		raises       []SkillRaise
+		want         SkillPointBudget
+		wantExceeded bool
+	}{
+		{
+			name: "Nothing Raised",
+			want: SkillPointBudget{Occupation: 120, PersonalInterest: 100},
+		},
+		{
+			name: "Occupation Skills",
+			raises: []SkillRaise{
+				{Name: "Bibliotheksnutzung", Base: 20, Value: 80},
+				{Name: "Geschichte", Base: 5, Value: 65},
+			},
+			want: SkillPointBudget{Occupation: 120, PersonalInterest: 100, SpentOccupation: 120},
+		},
+		{
+			name: "Occupation Skills Overflow Into Personal Interest",
+			raises: []SkillRaise{
+				{Name: "Bibliotheksnutzung", Base: 20, Value: 90},
+				{Name: "Geschichte", Base: 5, Value: 65},
+			},
+			want: SkillPointBudget{Occupation: 120, PersonalInterest: 100, SpentOccupation: 120, SpentPersonal: 10},
+		},
+		{
+			name: "Other Skills Only From Personal Interest",
+			raises: []SkillRaise{
+				{Name: "Klettern", Base: 20, Value: 60},
+			},
+			want: SkillPointBudget{Occupation: 120, PersonalInterest: 100, SpentPersonal: 40},
+		},
+		{
+			name: "Lowered Skills Cost Nothing",
+			raises: []SkillRaise{
+				{Name: "Klettern", Base: 20, Value: 10},
+			},
+			want: SkillPointBudget{Occupation: 120, PersonalInterest: 100},
+		},
+		{
+			name: "All Points Spent",
+			raises: []SkillRaise{
+				{Name: "Bibliotheksnutzung", Base: 20, Value: 90},
+				{Name: "Geschichte", Base: 5, Value: 95},
+				{Name: "Klettern", Base: 20, Value: 80},
+			},
+			want: SkillPointBudget{Occupation: 120, PersonalInterest: 100, SpentOccupation: 120, SpentPersonal: 100},
+		},
+		{
+			name: "One Point Too Many",
+			raises: []SkillRaise{
+				{Name: "Bibliotheksnutzung", Base: 20, Value: 90},
+				{Name: "Geschichte", Base: 5, Value: 95},
+				{Name: "Klettern", Base: 20, Value: 81},
+			},
+			want:         SkillPointBudget{Occupation: 120, PersonalInterest: 100, SpentOccupation: 120, SpentPersonal: 101},
+			wantExceeded: true,
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			budget, err := occupation.SpendSkillPoints(attributes, testCase.raises)
+			testHelpers.NilError(t, err)
+			testHelpers.Equal(t, budget, testCase.want)
+			testHelpers.Equal(t, budget.Exceeded(), testCase.wantExceeded)
+		})
+	}
+}
@@ -0,0 +1,151 @@
+package core
+
+import "errors"
+
+// the skill used to dodge, its base value is half of GE
+const Dodge = "Ausweichen"
+
+// a maneuver against a target whose Build exceeds the attacker's by this much is impossible
+const maxManeuverBuildDifference = 3
+
+// bonus and penalty dice cancel each other out, at most two of either remain
+const maxNetBonus = 2
+
+var ErrManeuverImpossible = errors.New("core: target too big for a maneuver")
+
+type Defense string
+
+const (
+	NoDefense        Defense = ""
+	DefenseDodge     Defense = "dodge"
+	DefenseFightBack Defense = "fightBack"
+)
+
+func (d Defense) String() string {
+	switch d {
+	case DefenseDodge:
+		return "Ausweichen"
+	case DefenseFightBack:
+		return "Zurückschlagen"
+	}
+	return "Vergleichender Wurf"
+}
+
+type OpposedOutcome int
+
+const (
+	Stalemate OpposedOutcome = iota
+	AttackerWins
+	DefenderWins
+)
+
+type OpposedRoll struct {
+	Attacker    Roll
+	Defender    Roll
+	Defense     Defense
+	Maneuver    bool
+	Outnumbered bool //the defender already defended this round, the attacker got a bonus die
+	Penalty     int  //penalty dice of a maneuver against a larger target
+	Outcome     OpposedOutcome
+}
+
+func (o OpposedRoll) AttackerWon() bool {
+	return o.Outcome == AttackerWins
+}
+
+func (o OpposedRoll) DefenderWon() bool {
+	return o.Outcome == DefenderWins
+}
+
+// one side of an opposed roll
+type Contestant struct {
+	CharacterID int
+	Skill       string
+	Value       int
+	Bonus       int
+	Build       int
+}
+
+// rolls both sides against each other. a maneuver costs the attacker a penalty die per point of Build
+// the defender has over them, an outnumbered defender grants the attacker a bonus die.
+func RollOpposed(attacker, defender Contestant, defense Defense, maneuver, outnumbered bool) (OpposedRoll, error) {
+	opposed := OpposedRoll{Defense: defense, Maneuver: maneuver, Outnumbered: outnumbered}
+	bonus := attacker.Bonus
+	if maneuver {
+		penalty, ok := ManeuverPenalty(attacker.Build, defender.Build)
+		if !ok {
+			return OpposedRoll{}, ErrManeuverImpossible
+		}
+		opposed.Penalty = penalty
+		bonus -= penalty
+	}
+	if outnumbered {
+		bonus++
+	}
+
+	opposed.Attacker = NewRoll(attacker.CharacterID, attacker.Skill, attacker.Value, clampBonus(bonus))
+	opposed.Defender = NewRoll(defender.CharacterID, defender.Skill, defender.Value, clampBonus(defender.Bonus))
+	opposed.Attacker.Source = SourceOpposed
+	opposed.Defender.Source = SourceOpposed
+	opposed.Outcome = ResolveOpposed(opposed.Attacker, opposed.Defender, defense)
+	return opposed, nil
+}
+
+func clampBonus(bonus int) int {
+	return max(-maxNetBonus, min(bonus, maxNetBonus))
+}
+
+// the character's dodge skill, or half of GE if it was never raised
+func (character Character) DodgeValue() int {
+	if value, ok := character.RollTarget(Dodge); ok {
+		return value
+	}
+	return Half(character.Attributes.GE)
+}
+
+// the higher success level wins, if neither side succeeds nobody does. ties go to the dodger and
+// against fighting back to the attacker, without a defense to the higher skill value.
+func ResolveOpposed(attacker, defender Roll, defense Defense) OpposedOutcome {
+	switch {
+	case !attacker.Succeeded() && !defender.Succeeded():
+		return Stalemate
+	case attacker.Level > defender.Level:
+		return AttackerWins
+	case defender.Level > attacker.Level:
+		return DefenderWins
+	}
+
+	switch defense {
+	case DefenseDodge:
+		return DefenderWins
+	case DefenseFightBack:
+		return AttackerWins
+	}
+	switch {
+	case attacker.Value > defender.Value:
+		return AttackerWins
+	case defender.Value > attacker.Value:
+		return DefenderWins
+	}
+	return Stalemate
+}
+
+// every point of Build the target has over the attacker is a penalty die on the maneuver,
+// 3 or more make it impossible
+func ManeuverPenalty(attackerBuild, defenderBuild int) (int, bool) {
+	difference := defenderBuild - attackerBuild
+	if difference >= maxManeuverBuildDifference {
+		return 0, false
+	}
+	return max(difference, 0), true
+}
+
+// whether the combatant already dodged or fought back this round, which gives every further attacker a bonus die
+func (c Combat) HasDefended(characterId int) bool {
+	for _, p := range c.Participants {
+		if p.CharacterID == characterId {
+			return p.DefendedRound == c.Round
+		}
+	}
+	return false
+}
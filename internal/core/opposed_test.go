@@ -0,0 +1,214 @@
+package core
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/winik100/NoPenNoPaper/internal/testHelpers"
+)
+
+func TestResolveOpposed(t *testing.T) {
+	tests := []struct {
+		name     string
+		attacker Roll
+		defender Roll
+		defense  Defense
+		want     OpposedOutcome
+	}{
+		{
+			name:     "Higher Level Wins",
+			attacker: Roll{Value: 50, Level: Hard},
+			defender: Roll{Value: 50, Level: Regular},
+			defense:  DefenseDodge,
+			want:     AttackerWins,
+		},
+		{
+			name:     "Defender Succeeds Alone",
+			attacker: Roll{Value: 50, Level: Failure},
+			defender: Roll{Value: 50, Level: Regular},
+			defense:  DefenseFightBack,
+			want:     DefenderWins,
+		},
+		{
+			name:     "Dodge Tie",
+			attacker: Roll{Value: 70, Level: Hard},
+			defender: Roll{Value: 30, Level: Hard},
+			defense:  DefenseDodge,
+			want:     DefenderWins,
+		},
+		{
+			name:     "Fight Back Tie",
+			attacker: Roll{Value: 30, Level: Regular},
+			defender: Roll{Value: 70, Level: Regular},
+			defense:  DefenseFightBack,
+			want:     AttackerWins,
+		},
+		{
+			name:     "No Defense Tie - Higher Attacker Skill",
+			attacker: Roll{Value: 60, Level: Regular},
+			defender: Roll{Value: 40, Level: Regular},
+			defense:  NoDefense,
+			want:     AttackerWins,
+		},
+		{
+			name:     "No Defense Tie - Higher Defender Skill",
+			attacker: Roll{Value: 40, Level: Extreme},
+			defender: Roll{Value: 60, Level: Extreme},
+			defense:  NoDefense,
+			want:     DefenderWins,
+		},
+		{
+			name:     "No Defense Tie - Equal Skill",
+			attacker: Roll{Value: 50, Level: Regular},
+			defender: Roll{Value: 50, Level: Regular},
+			defense:  NoDefense,
+			want:     Stalemate,
+		},
+		{
+			name:     "Both Fail",
+			attacker: Roll{Value: 50, Level: Failure},
+			defender: Roll{Value: 50, Level: Fumble},
+			defense:  DefenseDodge,
+			want:     Stalemate,
+		},
+		{
+			name:     "Both Fail Fighting Back",
+			attacker: Roll{Value: 50, Level: Fumble},
+			defender: Roll{Value: 50, Level: Failure},
+			defense:  DefenseFightBack,
+			want:     Stalemate,
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			got := ResolveOpposed(testCase.attacker, testCase.defender, testCase.defense)
+			testHelpers.Equal(t, got, testCase.want)
+		})
+	}
+}
+
+func TestManeuverPenalty(t *testing.T) {
+	tests := []struct {
+		name          string
+		attackerBuild int
+		defenderBuild int
+		wantPenalty   int
+		wantPossible  bool
+	}{
+		{
+			name:          "Smaller Target",
+			attackerBuild: 1,
+			defenderBuild: -1,
+			wantPenalty:   0,
+			wantPossible:  true,
+		},
+		{
+			name:          "Difference 0",
+			attackerBuild: 0,
+			defenderBuild: 0,
+			wantPenalty:   0,
+			wantPossible:  true,
+		},
+		{
+			name:          "Difference 1",
+			attackerBuild: 0,
+			defenderBuild: 1,
+			wantPenalty:   1,
+			wantPossible:  true,
+		},
+		{
+			name:          "Difference 2",
+			attackerBuild: -1,
+			defenderBuild: 1,
+			wantPenalty:   2,
+			wantPossible:  true,
+		},
+		{
+			name:          "Difference 3",
+			attackerBuild: -1,
+			defenderBuild: 2,
+			wantPenalty:   0,
+			wantPossible:  false,
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			penalty, possible := ManeuverPenalty(testCase.attackerBuild, testCase.defenderBuild)
+			testHelpers.Equal(t, penalty, testCase.wantPenalty)
+			testHelpers.Equal(t, possible, testCase.wantPossible)
+
+			opposed, err := RollOpposed(
+				Contestant{CharacterID: 1, Skill: "Faustschlag", Value: 50, Build: testCase.attackerBuild},
+				Contestant{CharacterID: 2, Skill: "Faustschlag", Value: 50, Build: testCase.defenderBuild},
+				DefenseFightBack, true, false)
+			if !testCase.wantPossible {
+				testHelpers.Equal(t, errors.Is(err, ErrManeuverImpossible), true)
+				return
+			}
+			testHelpers.NilError(t, err)
+			testHelpers.Equal(t, opposed.Penalty, testCase.wantPenalty)
+			testHelpers.Equal(t, opposed.Attacker.Bonus, -testCase.wantPenalty)
+		})
+	}
+}
+
+func TestOutnumberedBonus(t *testing.T) {
+	tests := []struct {
+		name          string
+		attackerBonus int
+		attackerBuild int
+		defenderBuild int
+		maneuver      bool
+		outnumbered   bool
+		wantBonus     int
+	}{
+		{
+			name:        "Outnumbered",
+			outnumbered: true,
+			wantBonus:   1,
+		},
+		{
+			name:          "At Most Two Bonus Dice",
+			attackerBonus: 2,
+			outnumbered:   true,
+			wantBonus:     2,
+		},
+		{
+			name:          "Outnumbered Cancels A Penalty Die",
+			defenderBuild: 1,
+			maneuver:      true,
+			outnumbered:   true,
+			wantBonus:     0,
+		},
+		{
+			name:          "At Most Two Penalty Dice",
+			attackerBonus: -1,
+			defenderBuild: 2,
+			maneuver:      true,
+			wantBonus:     -2,
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			opposed, err := RollOpposed(
+				Contestant{CharacterID: 1, Skill: "Faustschlag", Value: 50, Bonus: testCase.attackerBonus, Build: testCase.attackerBuild},
+				Contestant{CharacterID: 2, Skill: Dodge, Value: 50, Build: testCase.defenderBuild},
+				DefenseDodge, testCase.maneuver, testCase.outnumbered)
+			testHelpers.NilError(t, err)
+			testHelpers.Equal(t, opposed.Attacker.Bonus, testCase.wantBonus)
+			testHelpers.Equal(t, opposed.Defender.Bonus, 0)
+			testHelpers.Equal(t, opposed.Attacker.Source, SourceOpposed)
+		})
+	}
+}
+
+func TestDodgeValue(t *testing.T) {
+	untrained := Character{Attributes: CharacterAttributes{GE: 55}}
+	testHelpers.Equal(t, untrained.DodgeValue(), 27)
+
+	trained := Character{Attributes: CharacterAttributes{GE: 55}, Skills: Skills{Name: []string{Dodge}, Value: []int{60}}}
+	testHelpers.Equal(t, trained.DodgeValue(), 60)
+}
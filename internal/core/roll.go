@@ -0,0 +1,247 @@
+package core
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/justinian/dice"
+)
+
+type SuccessLevel int
+
+// ordered from worst to best, so levels can be compared directly
+const (
+	Fumble SuccessLevel = iota
+	Failure
+	Regular
+	Hard
+	Extreme
+	Critical
+)
+
+func (l SuccessLevel) String() string {
+	switch l {
+	case Fumble:
+		return "Patzer"
+	case Failure:
+		return "Fehlschlag"
+	case Regular:
+		return "Erfolg"
+	case Hard:
+		return "Schwieriger Erfolg"
+	case Extreme:
+		return "Extremer Erfolg"
+	case Critical:
+		return "Kritischer Erfolg"
+	}
+	return "Unbekannt"
+}
+
+// what a roll was made for. only rolls made on the sheet itself can be pushed, the outcome of
+// attacks, opposed rolls and chases is settled as soon as they are rolled.
+type RollSource string
+
+const (
+	SourceSheet   RollSource = "sheet"
+	SourceAttack  RollSource = "attack"
+	SourceOpposed RollSource = "opposed"
+	SourceChase   RollSource = "chase"
+)
+
+type Roll struct {
+	ID          int
+	CharacterID int
+	Name        string //skill, attribute or LUCK
+	Value       int    //value rolled against
+	Bonus       int    //net bonus dice, negative for penalty dice
+	Tens        []int  //every tens die rolled, the one used is part of the result
+	Result      int
+	Level       SuccessLevel
+	LuckSpent   int
+	RolledAt    time.Time
+	Source      RollSource
+
+	PushedFrom    int    //the failed roll this one pushes, 0 if it isn't a push
+	Justification string //how the character pushes the roll
+	Consequence   string //what happens after a failed push, written by the GM
+	Pushed        bool   //whether this roll was pushed afterwards
+}
+
+// a failed push together with the roll it pushed
+type PushedRoll struct {
+	CharacterName string
+	Original      Roll
+	Push          Roll
+}
+
+func (r Roll) Succeeded() bool {
+	return r.Level >= Regular
+}
+
+// luck can turn a failure into a regular success, but neither fumbles, luck rolls nor pushes.
+// once pushed, a roll stays failed and the push decides.
+func (r Roll) CanSpendLuck() bool {
+	return r.Level == Failure && r.Name != "LUCK" && r.LuckSpent == 0 && !r.IsPush() && !r.Pushed
+}
+
+func (r Roll) IsPush() bool {
+	return r.PushedFrom != 0
+}
+
+// a failed sheet roll can be pushed once, unless it was a fumble, a luck roll or a push itself
+func (r Roll) CanPush() bool {
+	return r.Source == SourceSheet && r.Level == Failure && r.Name != "LUCK" && r.LuckSpent == 0 && !r.IsPush() && !r.Pushed
+}
+
+// a failed push leaves it to the GM to decide what goes wrong
+func (r Roll) AwaitsConsequence() bool {
+	return r.IsPush() && !r.Succeeded() && r.Consequence == ""
+}
+
+// rolls again against the same value with the same dice
+func (r Roll) Push(justification string) Roll {
+	push := NewRoll(r.CharacterID, r.Name, r.Value, r.Bonus)
+	push.Source = r.Source
+	push.PushedFrom = r.ID
+	push.Justification = justification
+	return push
+}
+
+// points of luck needed to lower the result down to the rolled value
+func (r Roll) LuckNeeded() int {
+	return max(r.Result-r.Value, 0)
+}
+
+// lowers the result to the rolled value and returns the luck spent on it
+func (r *Roll) SpendLuck() int {
+	spent := r.LuckNeeded()
+	r.Result = r.Value
+	r.Level = Regular
+	r.LuckSpent = spent
+	return spent
+}
+
+func (r Roll) BonusLabel() string {
+	switch {
+	case r.Bonus == 1:
+		return "1 Bonuswürfel"
+	case r.Bonus > 1:
+		return fmt.Sprintf("%d Bonuswürfel", r.Bonus)
+	case r.Bonus == -1:
+		return "1 Strafwürfel"
+	case r.Bonus < -1:
+		return fmt.Sprintf("%d Strafwürfel", -r.Bonus)
+	}
+	return ""
+}
+
+func Half(value int) int {
+	res := value / 2
+	if res == 0 {
+		return 1
+	}
+	return res
+}
+
+func Fifth(value int) int {
+	res := value / 5
+	if res == 0 {
+		return 1
+	}
+	return res
+}
+
+func RollD100() int {
+	res, _, err := dice.Roll("1d100")
+	if err != nil {
+		return 100
+	}
+	return res.Int()
+}
+
+type PercentileRoll struct {
+	Tens   []int //0 to 90
+	Units  int   //0 to 9
+	Result int
+}
+
+// rolls one units die and 1 + |bonus| tens dice. with bonus dice the lowest result is kept,
+// with penalty dice (negative bonus) the highest.
+func RollPercentile(bonus int) PercentileRoll {
+	count := 1 + max(bonus, -bonus)
+	tens := make([]int, count)
+	for i := range tens {
+		tens[i] = rollD10() * 10
+	}
+	return PickPercentile(tens, rollD10(), bonus)
+}
+
+// combines every tens die with the units die, 00 and 0 count as 100
+func PickPercentile(tens []int, units, bonus int) PercentileRoll {
+	roll := PercentileRoll{Tens: tens, Units: units}
+	for i, ten := range tens {
+		result := ten + units
+		if result == 0 {
+			result = 100
+		}
+		if i == 0 || (bonus > 0 && result < roll.Result) || (bonus < 0 && result > roll.Result) {
+			roll.Result = result
+		}
+	}
+	return roll
+}
+
+// 0 to 9
+func rollD10() int {
+	res, _, err := dice.Roll("1d10")
+	if err != nil {
+		return 0
+	}
+	return res.Int() - 1
+}
+
+// a 1 is always critical. values below 50 fumble on 96-100, all others only on 100.
+func Classify(result, value int) SuccessLevel {
+	switch {
+	case result == 1:
+		return Critical
+	case result == 100, value < 50 && result >= 96:
+		return Fumble
+	case result <= Fifth(value):
+		return Extreme
+	case result <= Half(value):
+		return Hard
+	case result <= value:
+		return Regular
+	}
+	return Failure
+}
+
+// rolls 1d100 with the given net bonus dice against the given value
+func NewRoll(characterId int, name string, value, bonus int) Roll {
+	percentile := RollPercentile(bonus)
+	return Roll{
+		CharacterID: characterId,
+		Name:        name,
+		Value:       value,
+		Bonus:       bonus,
+		Tens:        percentile.Tens,
+		Result:      percentile.Result,
+		Level:       Classify(percentile.Result, value),
+		RolledAt:    time.Now(),
+	}
+}
+
+// end of session luck recovery: a d100 above the current luck regains 1d10 points, up to the maximum
+func RecoverLuck(luck, maxLuck int) (result, gain int) {
+	result = RollD100()
+	if result <= luck {
+		return result, 0
+	}
+
+	res, _, err := dice.Roll("1d10")
+	if err != nil {
+		return result, 0
+	}
+	return result, min(res.Int(), max(maxLuck-luck, 0))
+}
@@ -0,0 +1,266 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/winik100/NoPenNoPaper/internal/testHelpers"
+)
+
+func TestClassify(t *testing.T) {
+	tests := []struct {
+		name   string
+		result int
+		value  int
+		want   SuccessLevel
+	}{
+		{
+			name:   "01 Is Critical",
+			result: 1,
+			value:  50,
+			want:   Critical,
+		},
+		{
+			name:   "01 Is Critical For Tiny Values",
+			result: 1,
+			value:  1,
+			want:   Critical,
+		},
+		{
+			name:   "100 Fumbles",
+			result: 100,
+			value:  99,
+			want:   Fumble,
+		},
+		{
+			name:   "96 Fumbles Below 50",
+			result: 96,
+			value:  49,
+			want:   Fumble,
+		},
+		{
+			name:   "95 Fails Below 50",
+			result: 95,
+			value:  49,
+			want:   Failure,
+		},
+		{
+			name:   "96 Fails At 50",
+			result: 96,
+			value:  50,
+			want:   Failure,
+		},
+		{
+			name:   "99 Succeeds At 99",
+			result: 99,
+			value:  99,
+			want:   Regular,
+		},
+		{
+			name:   "Extreme At A Fifth",
+			result: 10,
+			value:  50,
+			want:   Extreme,
+		},
+		{
+			name:   "Hard Above A Fifth",
+			result: 11,
+			value:  50,
+			want:   Hard,
+		},
+		{
+			name:   "Hard At Half",
+			result: 25,
+			value:  50,
+			want:   Hard,
+		},
+		{
+			name:   "Regular Above Half",
+			result: 26,
+			value:  50,
+			want:   Regular,
+		},
+		{
+			name:   "Regular At Value",
+			result: 50,
+			value:  50,
+			want:   Regular,
+		},
+		{
+			name:   "Failure Above Value",
+			result: 51,
+			value:  50,
+			want:   Failure,
+		},
+		{
+			name:   "Fifth Of Tiny Values Is 1",
+			result: 2,
+			value:  3,
+			want:   Regular,
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			testHelpers.Equal(t, Classify(testCase.result, testCase.value), testCase.want)
+		})
+	}
+}
+
+func TestPickPercentile(t *testing.T) {
+	tests := []struct {
+		name  string
+		tens  []int
+		units int
+		bonus int
+		want  int
+	}{
+		{
+			name:  "01",
+			tens:  []int{0},
+			units: 1,
+			want:  1,
+		},
+		{
+			name:  "00 And 0 Is 100",
+			tens:  []int{0},
+			units: 0,
+			want:  100,
+		},
+		{
+			name:  "90 And 0 Is 90",
+			tens:  []int{90},
+			units: 0,
+			want:  90,
+		},
+		{
+			name:  "99",
+			tens:  []int{90},
+			units: 9,
+			want:  99,
+		},
+		{
+			name:  "Bonus Die Keeps The Lowest",
+			tens:  []int{70, 20},
+			units: 5,
+			bonus: 1,
+			want:  25,
+		},
+		{
+			name:  "Penalty Die Keeps The Highest",
+			tens:  []int{20, 70},
+			units: 5,
+			bonus: -1,
+			want:  75,
+		},
+		{
+			name:  "Bonus Die Avoids 100",
+			tens:  []int{0, 50},
+			units: 0,
+			bonus: 1,
+			want:  50,
+		},
+		{
+			name:  "Penalty Die Turns 10 Into 100",
+			tens:  []int{10, 0},
+			units: 0,
+			bonus: -1,
+			want:  100,
+		},
+		{
+			name:  "Two Penalty Dice",
+			tens:  []int{10, 40, 30},
+			units: 3,
+			bonus: -2,
+			want:  43,
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			roll := PickPercentile(testCase.tens, testCase.units, testCase.bonus)
+			testHelpers.Equal(t, roll.Result, testCase.want)
+			testHelpers.Equal(t, len(roll.Tens), len(testCase.tens))
+		})
+	}
+}
+
+func TestRollPercentile(t *testing.T) {
+	for _, bonus := range []int{-2, -1, 0, 1, 2} {
+		for range 100 {
+			roll := RollPercentile(bonus)
+			testHelpers.Equal(t, len(roll.Tens), 1+max(bonus, -bonus))
+			if roll.Result < 1 || roll.Result > 100 {
+				t.Fatalf("result %d out of range", roll.Result)
+			}
+		}
+	}
+}
+
+func TestRollLuckAndPush(t *testing.T) {
+	tests := []struct {
+		name          string
+		roll          Roll
+		wantLuck      bool
+		wantPush      bool
+		wantLuckSpent int
+	}{
+		{
+			name:          "Failed Sheet Roll",
+			roll:          Roll{Name: "Bibliotheksnutzung", Value: 40, Result: 55, Level: Failure, Source: SourceSheet},
+			wantLuck:      true,
+			wantPush:      true,
+			wantLuckSpent: 15,
+		},
+		{
+			name:     "Success",
+			roll:     Roll{Name: "Bibliotheksnutzung", Value: 40, Result: 30, Level: Regular, Source: SourceSheet},
+			wantLuck: false,
+			wantPush: false,
+		},
+		{
+			name:     "Fumble",
+			roll:     Roll{Name: "Bibliotheksnutzung", Value: 40, Result: 98, Level: Fumble, Source: SourceSheet},
+			wantLuck: false,
+			wantPush: false,
+		},
+		{
+			name:     "Luck Roll",
+			roll:     Roll{Name: "LUCK", Value: 40, Result: 55, Level: Failure, Source: SourceSheet},
+			wantLuck: false,
+			wantPush: false,
+		},
+		{
+			name:          "Attack Roll",
+			roll:          Roll{Name: "Nahkampf (Handgemenge)", Value: 40, Result: 55, Level: Failure, Source: SourceAttack},
+			wantLuck:      true,
+			wantPush:      false,
+			wantLuckSpent: 15,
+		},
+		{
+			name:     "Pushed Roll",
+			roll:     Roll{Name: "Bibliotheksnutzung", Value: 40, Result: 55, Level: Failure, Source: SourceSheet, Pushed: true},
+			wantLuck: false,
+			wantPush: false,
+		},
+		{
+			name:     "Push",
+			roll:     Roll{Name: "Bibliotheksnutzung", Value: 40, Result: 55, Level: Failure, Source: SourceSheet, PushedFrom: 1},
+			wantLuck: false,
+			wantPush: false,
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			testHelpers.Equal(t, testCase.roll.CanSpendLuck(), testCase.wantLuck)
+			testHelpers.Equal(t, testCase.roll.CanPush(), testCase.wantPush)
+			if testCase.wantLuck {
+				roll := testCase.roll
+				testHelpers.Equal(t, roll.SpendLuck(), testCase.wantLuckSpent)
+				testHelpers.Equal(t, roll.Result, roll.Value)
+				testHelpers.Equal(t, roll.Level, Regular)
+				testHelpers.Equal(t, roll.CanSpendLuck(), false)
+			}
+		})
+	}
+}
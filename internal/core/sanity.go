@@ -0,0 +1,206 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/justinian/dice"
+)
+
+// sanity can never exceed this limit minus the character's Cthulhu-Mythos
+const SanityLimit = 99
+
+const CthulhuMythos = "Cthulhu-Mythos"
+
+// losing at least this much sanity in a single check causes temporary insanity
+const TemporaryInsanityLoss = 5
+
+var ErrInvalidSanityLoss = errors.New("core: invalid sanity loss")
+
+// a single part of a loss expression: a flat number or dice like 1d6, 2d4+1
+var lossPartRX = regexp.MustCompile(`^(?:(\d{1,2})|(\d{0,2})[dD](\d{1,3})([+-]\d{1,2})?)$`)
+
+// no scenario asks for more, and it keeps the rolls cheap
+const (
+	maxLossDice  = 20
+	maxLossSides = 100
+)
+
+// sanity loss of a check as written in scenarios, e.g. 1/1d6:
+// the first part is lost on a success, the second on a failure
+type SanityLoss struct {
+	Success string
+	Failure string
+}
+
+func ParseSanityLoss(expr string) (SanityLoss, error) {
+	success, failure, ok := strings.Cut(strings.ReplaceAll(expr, " ", ""), "/")
+	if !ok {
+		return SanityLoss{}, ErrInvalidSanityLoss
+	}
+	if _, _, _, ok := parseLossPart(success); !ok {
+		return SanityLoss{}, ErrInvalidSanityLoss
+	}
+	if _, _, _, ok := parseLossPart(failure); !ok {
+		return SanityLoss{}, ErrInvalidSanityLoss
+	}
+	return SanityLoss{Success: success, Failure: failure}, nil
+}
+
+func (l SanityLoss) String() string {
+	return l.Success + "/" + l.Failure
+}
+
+// rolls the given part of a loss expression, never less than 0
+func rollLoss(part string) int {
+	count, sides, modifier, ok := parseLossPart(part)
+	if !ok {
+		return 0
+	}
+	if sides == 0 {
+		return count
+	}
+
+	res, _, err := dice.Roll(fmt.Sprintf("%dd%d", count, sides))
+	if err != nil {
+		return 0
+	}
+	return max(res.Int()+modifier, 0)
+}
+
+// the highest possible result of the given part of a loss expression
+func maxLoss(part string) int {
+	count, sides, modifier, ok := parseLossPart(part)
+	if !ok {
+		return 0
+	}
+	if sides == 0 {
+		return count
+	}
+	return max(count*sides+modifier, 0)
+}
+
+// sides is 0 for flat losses
+func parseLossPart(part string) (count, sides, modifier int, ok bool) {
+	matches := lossPartRX.FindStringSubmatch(part)
+	if matches == nil {
+		return 0, 0, 0, false
+	}
+	if matches[1] != "" {
+		count, _ = strconv.Atoi(matches[1])
+		return count, 0, 0, true
+	}
+
+	count = 1
+	if matches[2] != "" {
+		count, _ = strconv.Atoi(matches[2])
+	}
+	sides, _ = strconv.Atoi(matches[3])
+	if count < 1 || count > maxLossDice || sides < 1 || sides > maxLossSides {
+		return 0, 0, 0, false
+	}
+	if matches[4] != "" {
+		modifier, _ = strconv.Atoi(matches[4])
+	}
+	return count, sides, modifier, true
+}
+
+var boutsOfMadness = []string{
+	"Amnesie: erinnert sich an nichts seit dem letzten sicheren Ort",
+	"Psychosomatische Behinderung: blind, taub oder gelähmt",
+	"Gewalt: greift alles und jeden in der Nähe an",
+	"Paranoia: traut niemandem und fühlt sich verfolgt",
+	"Wichtige Person: hält jemanden in der Nähe für eine wichtige Bezugsperson",
+	"Ohnmacht: bricht bewusstlos zusammen",
+	"Flucht in Panik: rennt mit allen Mitteln davon",
+	"Hysterie: unkontrolliertes Lachen, Weinen oder Schreien",
+	"Phobie: entwickelt eine neue Phobie, die sofort auftritt",
+	"Manie: entwickelt eine neue Manie, der sofort nachgegeben wird",
+}
+
+// rolls 1d10 on the table of bouts of madness, lasting 1d10 rounds
+func RollBout() string {
+	res, _, err := dice.Roll("1d10")
+	if err != nil {
+		return ""
+	}
+	bout := boutsOfMadness[res.Int()-1]
+
+	res, _, err = dice.Roll("1d10")
+	if err != nil {
+		return bout
+	}
+	return fmt.Sprintf("%s (%d Runden)", bout, res.Int())
+}
+
+type SanityCheck struct {
+	ID          int
+	CharacterID int
+	Source      string //what caused the check
+	Loss        SanityLoss
+	Value       int //sanity before the check
+	Result      int
+	Level       SuccessLevel
+	Lost        int
+	Temporary   bool //temporary insanity set in with this check
+	Indefinite  bool //indefinite insanity set in with this check
+	Bout        string
+	CheckedAt   time.Time
+}
+
+func (c SanityCheck) Succeeded() bool {
+	return c.Level >= Regular
+}
+
+func (c SanityCheck) CausedInsanity() bool {
+	return c.Temporary || c.Indefinite
+}
+
+// rolls a sanity check against the character's current sanity. a fumble loses the maximum,
+// losing 5+ at once causes temporary insanity and losing a fifth of the day's starting sanity
+// causes indefinite insanity, both starting with a bout of madness.
+func (character Character) SanityCheck(source string, loss SanityLoss) SanityCheck {
+	sanity := character.Stats.STA
+	check := SanityCheck{
+		CharacterID: character.ID,
+		Source:      source,
+		Loss:        loss,
+		Value:       sanity,
+		Result:      RollD100(),
+		CheckedAt:   time.Now(),
+	}
+	check.Level = Classify(check.Result, sanity)
+
+	switch {
+	case check.Succeeded():
+		check.Lost = rollLoss(loss.Success)
+	case check.Level == Fumble:
+		check.Lost = maxLoss(loss.Failure)
+	default:
+		check.Lost = rollLoss(loss.Failure)
+	}
+	check.Lost = min(check.Lost, sanity)
+
+	check.Temporary, check.Indefinite = character.Stats.insanityFrom(check.Lost)
+	if check.CausedInsanity() {
+		check.Bout = RollBout()
+	}
+	return check
+}
+
+// the insanity setting in when losing the given amount of sanity at once
+func (stats CharacterStats) insanityFrom(lost int) (temporary, indefinite bool) {
+	temporary = !stats.TemporaryInsanity && lost >= TemporaryInsanityLoss
+	indefinite = !stats.IndefiniteInsanity && lost > 0 && stats.SanityLossToday+lost >= stats.DailySanityThreshold()
+	return temporary, indefinite
+}
+
+// the highest sanity the character can have, lowered by every point of Cthulhu-Mythos
+func (character Character) MaxSanity() int {
+	mythos, _ := character.RollTarget(CthulhuMythos)
+	return max(SanityLimit-mythos, 0)
+}
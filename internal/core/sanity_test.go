@@ -0,0 +1,238 @@
+package core
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/winik100/NoPenNoPaper/internal/testHelpers"
+)
+
+func TestParseSanityLoss(t *testing.T) {
+	tests := []struct {
+		name    string
+		expr    string
+		want    SanityLoss
+		wantErr error
+	}{
+		{
+			name: "Flat And Dice",
+			expr: "1/1d6",
+			want: SanityLoss{Success: "1", Failure: "1d6"},
+		},
+		{
+			name: "No Loss On Success",
+			expr: "0/1d4+1",
+			want: SanityLoss{Success: "0", Failure: "1d4+1"},
+		},
+		{
+			name: "Dice On Both Sides",
+			expr: "1d3/1D10",
+			want: SanityLoss{Success: "1d3", Failure: "1D10"},
+		},
+		{
+			name: "Spaces",
+			expr: " 1 / 1d6 - 1 ",
+			want: SanityLoss{Success: "1", Failure: "1d6-1"},
+		},
+		{
+			name: "Die Without Count",
+			expr: "0/d8",
+			want: SanityLoss{Success: "0", Failure: "d8"},
+		},
+		{
+			name: "Most Dice",
+			expr: "1d10/20d100",
+			want: SanityLoss{Success: "1d10", Failure: "20d100"},
+		},
+		{
+			name:    "Too Many Dice",
+			expr:    "1/21d6",
+			wantErr: ErrInvalidSanityLoss,
+		},
+		{
+			name:    "Too Many Sides",
+			expr:    "1/1d101",
+			wantErr: ErrInvalidSanityLoss,
+		},
+		{
+			name:    "No Dice",
+			expr:    "1/0d6",
+			wantErr: ErrInvalidSanityLoss,
+		},
+		{
+			name:    "Die Without Sides",
+			expr:    "1/1d0",
+			wantErr: ErrInvalidSanityLoss,
+		},
+		{
+			name:    "Huge Numbers",
+			expr:    "99999999999999999999/1d6",
+			wantErr: ErrInvalidSanityLoss,
+		},
+		{
+			name:    "Missing Slash",
+			expr:    "1d6",
+			wantErr: ErrInvalidSanityLoss,
+		},
+		{
+			name:    "Missing Failure",
+			expr:    "1/",
+			wantErr: ErrInvalidSanityLoss,
+		},
+		{
+			name:    "Three Parts",
+			expr:    "1/1d6/2",
+			wantErr: ErrInvalidSanityLoss,
+		},
+		{
+			name:    "Negative Loss",
+			expr:    "-1/1d6",
+			wantErr: ErrInvalidSanityLoss,
+		},
+		{
+			name:    "Text",
+			expr:    "wenig/viel",
+			wantErr: ErrInvalidSanityLoss,
+		},
+		{
+			name:    "Empty",
+			expr:    "",
+			wantErr: ErrInvalidSanityLoss,
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			loss, err := ParseSanityLoss(testCase.expr)
+			if testCase.wantErr != nil {
+				testHelpers.Equal(t, errors.Is(err, testCase.wantErr), true)
+				return
+			}
+			testHelpers.NilError(t, err)
+			testHelpers.Equal(t, loss, testCase.want)
+		})
+	}
+}
+
+func TestMaxLoss(t *testing.T) {
+	tests := []struct {
+		name string
+		part string
+		want int
+	}{
+		{
+			name: "Nothing",
+			part: "0",
+			want: 0,
+		},
+		{
+			name: "Flat",
+			part: "3",
+			want: 3,
+		},
+		{
+			name: "Single Die",
+			part: "1d6",
+			want: 6,
+		},
+		{
+			name: "Die Without Count",
+			part: "d8",
+			want: 8,
+		},
+		{
+			name: "Dice With Modifier",
+			part: "2d4+1",
+			want: 9,
+		},
+		{
+			name: "Negative Modifier",
+			part: "1d6-1",
+			want: 5,
+		},
+		{
+			name: "Never Below 0",
+			part: "1d3-5",
+			want: 0,
+		},
+		{
+			name: "Invalid",
+			part: "viel",
+			want: 0,
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			testHelpers.Equal(t, maxLoss(testCase.part), testCase.want)
+		})
+	}
+}
+
+func TestRollLoss(t *testing.T) {
+	for range 100 {
+		lost := rollLoss("2d4+1")
+		if lost < 3 || lost > 9 {
+			t.Fatalf("2d4+1 rolled %d", lost)
+		}
+		testHelpers.Equal(t, rollLoss("3"), 3)
+		testHelpers.Equal(t, rollLoss("1d3-5"), 0)
+	}
+}
+
+func TestInsanityFrom(t *testing.T) {
+	tests := []struct {
+		name           string
+		stats          CharacterStats
+		lost           int
+		wantTemporary  bool
+		wantIndefinite bool
+	}{
+		{
+			name:  "Nothing Lost",
+			stats: CharacterStats{STA: 10},
+			lost:  0,
+		},
+		{
+			name:  "4 Lost",
+			stats: CharacterStats{STA: 60},
+			lost:  4,
+		},
+		{
+			name:          "5 Lost",
+			stats:         CharacterStats{STA: 60},
+			lost:          5,
+			wantTemporary: true,
+		},
+		{
+			name:  "Already Temporarily Insane",
+			stats: CharacterStats{STA: 60, TemporaryInsanity: true},
+			lost:  5,
+		},
+		{
+			name:           "A Fifth Of The Day's Sanity",
+			stats:          CharacterStats{STA: 45, SanityLossToday: 5},
+			lost:           6,
+			wantTemporary:  true,
+			wantIndefinite: true,
+		},
+		{
+			name:  "Just Below A Fifth Of The Day's Sanity",
+			stats: CharacterStats{STA: 45, SanityLossToday: 5},
+			lost:  4,
+		},
+		{
+			name:  "Already Indefinitely Insane",
+			stats: CharacterStats{STA: 45, SanityLossToday: 5, IndefiniteInsanity: true},
+			lost:  4,
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			temporary, indefinite := testCase.stats.insanityFrom(testCase.lost)
+			testHelpers.Equal(t, temporary, testCase.wantTemporary)
+			testHelpers.Equal(t, indefinite, testCase.wantIndefinite)
+		})
+	}
+}
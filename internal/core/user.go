@@ -17,6 +17,7 @@ func (u User) IsGM() bool {
 }
 
 type Materials struct {
+	ID       []int
 	Title    []string
 	FileName []string
 }
@@ -0,0 +1,153 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var ErrInvalidMoney = errors.New("core: invalid amount of money")
+
+type Era string
+
+const (
+	Classic Era = "classic" //the 1920s
+	Modern  Era = "modern"
+)
+
+func (e Era) String() string {
+	if e == Modern {
+		return "Gegenwart"
+	}
+	return "1920er"
+}
+
+// prices of the modern era are 20 times those of the 1920s
+func (e Era) factor() Money {
+	if e == Modern {
+		return 20
+	}
+	return 1
+}
+
+// amounts in cents, so the half dollar of the penniless fits
+type Money int
+
+func (m Money) String() string {
+	sign := ""
+	if m < 0 {
+		sign = "-"
+		m = -m
+	}
+
+	dollars := strconv.Itoa(int(m / 100))
+	for i := len(dollars) - 3; i > 0; i -= 3 {
+		dollars = dollars[:i] + "." + dollars[i:]
+	}
+	if cents := m % 100; cents != 0 {
+		return fmt.Sprintf("%s%s,%02d $", sign, dollars, cents)
+	}
+	return fmt.Sprintf("%s%s $", sign, dollars)
+}
+
+var moneyRX = regexp.MustCompile(`^(\d{1,9})(?:[.,](\d{1,2}))?$`)
+
+// parses positive amounts like 12, 12,5 or 12.50 dollars
+func ParseMoney(s string) (Money, error) {
+	matches := moneyRX.FindStringSubmatch(strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(s), "$")))
+	if matches == nil {
+		return 0, ErrInvalidMoney
+	}
+
+	dollars, _ := strconv.Atoi(matches[1])
+	var cents int
+	if matches[2] != "" {
+		cents, _ = strconv.Atoi(matches[2])
+		if len(matches[2]) == 1 {
+			cents *= 10
+		}
+	}
+	return Money(dollars*100 + cents), nil
+}
+
+type Wealth struct {
+	Era          Era
+	CreditRating int
+	Level        string
+	Cash         Money
+	Assets       Money
+	Spending     Money //what can be spent a day without keeping track of it
+}
+
+type wealthBracket struct {
+	minRating int
+	level     string
+	perPoint  bool //cash and assets are multiplied by the credit rating
+	cash      Money
+	assets    Money
+	spending  Money
+}
+
+// the 1920s brackets, ordered by the lowest credit rating of each
+var wealthBrackets = []wealthBracket{
+	{minRating: 0, level: "Mittellos", cash: 50, spending: 50},
+	{minRating: 1, level: "Arm", perPoint: true, cash: 100, assets: 1000, spending: 200},
+	{minRating: 10, level: "Durchschnittlich", perPoint: true, cash: 200, assets: 5000, spending: 1000},
+	{minRating: 50, level: "Wohlhabend", perPoint: true, cash: 500, assets: 50000, spending: 5000},
+	{minRating: 90, level: "Reich", perPoint: true, cash: 2000, assets: 200000, spending: 25000},
+	{minRating: 99, level: "Superreich", cash: 5000000, assets: 500000000, spending: 500000},
+}
+
+// cash, assets and spending level the character's credit rating provides in the given era
+func (character Character) Wealth(era Era) Wealth {
+	rating, _ := character.RollTarget(CreditRating)
+	rating = max(rating, 0)
+
+	var bracket wealthBracket
+	for _, b := range wealthBrackets {
+		if rating >= b.minRating {
+			bracket = b
+		}
+	}
+
+	wealth := Wealth{
+		Era:          era,
+		CreditRating: rating,
+		Level:        bracket.level,
+		Cash:         bracket.cash * era.factor(),
+		Assets:       bracket.assets * era.factor(),
+		Spending:     bracket.spending * era.factor(),
+	}
+	if bracket.perPoint {
+		wealth.Cash *= Money(rating)
+		wealth.Assets *= Money(rating)
+	}
+	return wealth
+}
+
+// a purchase has a negative amount, income a positive one
+type LedgerEntry struct {
+	ID          int
+	CharacterID int
+	Description string
+	Amount      Money
+	BookedAt    time.Time
+	Balance     Money //after this entry, filled in by RunningBalance
+}
+
+func (e LedgerEntry) IsPurchase() bool {
+	return e.Amount < 0
+}
+
+// fills in the balance after each of the entries, which have to be ordered oldest first
+func RunningBalance(opening Money, entries []LedgerEntry) Money {
+	balance := opening
+	for i := range entries {
+		balance += entries[i].Amount
+		entries[i].Balance = balance
+	}
+	return balance
+}
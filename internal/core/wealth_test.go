@@ -0,0 +1,248 @@
+package core
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/winik100/NoPenNoPaper/internal/testHelpers"
+)
+
+func TestWealth(t *testing.T) {
+	tests := []struct {
+		name      string
+		rating    int
+		era       Era
+		wantLevel string
+		wantCash  Money
+		wantAsset Money
+		wantSpend Money
+	}{
+		{
+			name:      "CR 0",
+			rating:    0,
+			era:       Classic,
+			wantLevel: "Mittellos",
+			wantCash:  50,
+			wantAsset: 0,
+			wantSpend: 50,
+		},
+		{
+			name:      "CR 1",
+			rating:    1,
+			era:       Classic,
+			wantLevel: "Arm",
+			wantCash:  100,
+			wantAsset: 1000,
+			wantSpend: 200,
+		},
+		{
+			name:      "CR 9",
+			rating:    9,
+			era:       Classic,
+			wantLevel: "Arm",
+			wantCash:  900,
+			wantAsset: 9000,
+			wantSpend: 200,
+		},
+		{
+			name:      "CR 10",
+			rating:    10,
+			era:       Classic,
+			wantLevel: "Durchschnittlich",
+			wantCash:  2000,
+			wantAsset: 50000,
+			wantSpend: 1000,
+		},
+		{
+			name:      "CR 49",
+			rating:    49,
+			era:       Classic,
+			wantLevel: "Durchschnittlich",
+			wantCash:  9800,
+			wantAsset: 245000,
+			wantSpend: 1000,
+		},
+		{
+			name:      "CR 50",
+			rating:    50,
+			era:       Classic,
+			wantLevel: "Wohlhabend",
+			wantCash:  25000,
+			wantAsset: 2500000,
+			wantSpend: 5000,
+		},
+		{
+			name:      "CR 98",
+			rating:    98,
+			era:       Classic,
+			wantLevel: "Reich",
+			wantCash:  196000,
+			wantAsset: 19600000,
+			wantSpend: 25000,
+		},
+		{
+			name:      "CR 99",
+			rating:    99,
+			era:       Classic,
+			wantLevel: "Superreich",
+			wantCash:  5000000,
+			wantAsset: 500000000,
+			wantSpend: 500000,
+		},
+		{
+			name:      "CR 0 Today",
+			rating:    0,
+			era:       Modern,
+			wantLevel: "Mittellos",
+			wantCash:  1000,
+			wantAsset: 0,
+			wantSpend: 1000,
+		},
+		{
+			name:      "CR 10 Today",
+			rating:    10,
+			era:       Modern,
+			wantLevel: "Durchschnittlich",
+			wantCash:  40000,
+			wantAsset: 1000000,
+			wantSpend: 20000,
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			character := Character{Skills: Skills{Name: []string{CreditRating}, Value: []int{testCase.rating}}}
+			wealth := character.Wealth(testCase.era)
+			testHelpers.Equal(t, wealth.CreditRating, testCase.rating)
+			testHelpers.Equal(t, wealth.Level, testCase.wantLevel)
+			testHelpers.Equal(t, wealth.Cash, testCase.wantCash)
+			testHelpers.Equal(t, wealth.Assets, testCase.wantAsset)
+			testHelpers.Equal(t, wealth.Spending, testCase.wantSpend)
+		})
+	}
+
+	t.Run("Without Credit Rating", func(t *testing.T) {
+		wealth := Character{}.Wealth(Classic)
+		testHelpers.Equal(t, wealth.CreditRating, 0)
+		testHelpers.Equal(t, wealth.Level, "Mittellos")
+	})
+}
+
+func TestParseMoney(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    Money
+		wantErr error
+	}{
+		{
+			name:  "Dollars",
+			input: "12",
+			want:  1200,
+		},
+		{
+			name:  "Comma With One Digit",
+			input: "12,5",
+			want:  1250,
+		},
+		{
+			name:  "Point With Two Digits",
+			input: "12.05",
+			want:  1205,
+		},
+		{
+			name:  "Dollar Sign And Spaces",
+			input: " 12,50 $ ",
+			want:  1250,
+		},
+		{
+			name:  "Zero",
+			input: "0",
+			want:  0,
+		},
+		{
+			name:  "Nine Digits",
+			input: "999999999,99",
+			want:  99999999999,
+		},
+		{
+			name:    "Ten Digits",
+			input:   "1000000000",
+			wantErr: ErrInvalidMoney,
+		},
+		{
+			name:    "Three Decimals",
+			input:   "12,505",
+			wantErr: ErrInvalidMoney,
+		},
+		{
+			name:    "Thousands Separator",
+			input:   "1.000,00",
+			wantErr: ErrInvalidMoney,
+		},
+		{
+			name:    "Negative",
+			input:   "-5",
+			wantErr: ErrInvalidMoney,
+		},
+		{
+			name:    "Empty",
+			input:   "",
+			wantErr: ErrInvalidMoney,
+		},
+		{
+			name:    "Text",
+			input:   "viel",
+			wantErr: ErrInvalidMoney,
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			money, err := ParseMoney(testCase.input)
+			if testCase.wantErr != nil {
+				testHelpers.Equal(t, errors.Is(err, testCase.wantErr), true)
+				return
+			}
+			testHelpers.NilError(t, err)
+			testHelpers.Equal(t, money, testCase.want)
+		})
+	}
+}
+
+func TestMoneyString(t *testing.T) {
+	tests := []struct {
+		money Money
+		want  string
+	}{
+		{money: 0, want: "0 $"},
+		{money: 50, want: "0,50 $"},
+		{money: 5, want: "0,05 $"},
+		{money: 2500, want: "25 $"},
+		{money: 100000, want: "1.000 $"},
+		{money: 123456789, want: "1.234.567,89 $"},
+		{money: -2250, want: "-22,50 $"},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.want, func(t *testing.T) {
+			testHelpers.Equal(t, testCase.money.String(), testCase.want)
+		})
+	}
+}
+
+func TestRunningBalance(t *testing.T) {
+	entries := []LedgerEntry{
+		{Description: "Zugfahrt", Amount: -550},
+		{Description: "Honorar", Amount: 2000},
+		{Description: "Revolver", Amount: -2500},
+	}
+
+	balance := RunningBalance(1500, entries)
+
+	testHelpers.Equal(t, balance, Money(450))
+	testHelpers.Equal(t, entries[0].Balance, Money(950))
+	testHelpers.Equal(t, entries[1].Balance, Money(2950))
+	testHelpers.Equal(t, entries[2].Balance, Money(450))
+	testHelpers.Equal(t, entries[2].IsPurchase(), true)
+}
@@ -0,0 +1,125 @@
+package core
+
+import (
+	"errors"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/justinian/dice"
+)
+
+var ErrInvalidDamage = errors.New("core: invalid damage expression")
+
+// a term of a damage expression: dice, a flat amount or the (half) damage bonus
+var damageTerm = regexp.MustCompile(`([+-]?)(½DB|DB|\d+[dD]\d+|\d+)`)
+
+type Weapon struct {
+	ID          int
+	Name        string
+	Skill       string //the fighting or firearms skill attacks are rolled against
+	Damage      string //e.g. 1d6+DB, thrown weapons only add half the damage bonus: 1d4+½DB
+	Range       string
+	Attacks     string //per round
+	Ammo        int    //0 for weapons without ammunition
+	Malfunction int    //0 for weapons that can't malfunction
+}
+
+func (w Weapon) AddsDamageBonus() bool {
+	return strings.Contains(w.Damage, "DB")
+}
+
+func (w Weapon) IsFirearm() bool {
+	return w.Ammo > 0
+}
+
+// an attack roll at or above the malfunction number jams the weapon
+func (w Weapon) Malfunctions(result int) bool {
+	return w.Malfunction > 0 && result >= w.Malfunction
+}
+
+type WeaponDamage struct {
+	Weapon      Weapon
+	DamageBonus string
+	Dice        []int //every die rolled, including those of the damage bonus
+	Total       int
+}
+
+// rolls the weapon's damage, adding the given damage bonus where the expression asks for it
+func (w Weapon) RollDamage(damageBonus string) (WeaponDamage, error) {
+	damage := WeaponDamage{Weapon: w, DamageBonus: damageBonus}
+	total, err := rollDamage(w.Damage, damageBonus, &damage.Dice)
+	if err != nil {
+		return WeaponDamage{}, err
+	}
+	damage.Total = max(total, 0)
+	return damage, nil
+}
+
+// sums up the terms of the expression, the damage bonus is itself an expression without a damage bonus
+func rollDamage(expression, damageBonus string, rolled *[]int) (int, error) {
+	expression = strings.ReplaceAll(expression, " ", "")
+	matches := damageTerm.FindAllStringSubmatch(expression, -1)
+	if matches == nil {
+		return 0, ErrInvalidDamage
+	}
+
+	total := 0
+	var parsed strings.Builder
+	for i, match := range matches {
+		if i > 0 && match[1] == "" {
+			return 0, ErrInvalidDamage
+		}
+		parsed.WriteString(match[0])
+
+		var value int
+		switch term := match[2]; {
+		case term == "DB" || term == "½DB":
+			if damageBonus == "" {
+				return 0, ErrInvalidDamage
+			}
+			bonus, err := rollDamage(damageBonus, "", rolled)
+			if err != nil {
+				return 0, err
+			}
+			value = bonus
+			if term == "½DB" {
+				value = bonus / 2
+			}
+		case strings.ContainsAny(term, "dD"):
+			res, _, err := dice.Roll(strings.ToLower(term))
+			if err != nil {
+				return 0, ErrInvalidDamage
+			}
+			if std, ok := res.(dice.StdResult); ok {
+				*rolled = append(*rolled, std.Rolls...)
+			}
+			value = res.Int()
+		default:
+			var err error
+			value, err = strconv.Atoi(term)
+			if err != nil {
+				return 0, ErrInvalidDamage
+			}
+		}
+
+		if match[1] == "-" {
+			value = -value
+		}
+		total += value
+	}
+
+	if parsed.String() != expression {
+		return 0, ErrInvalidDamage
+	}
+	return total, nil
+}
+
+func (character Character) CarriedWeapon(weaponId int) (Weapon, bool) {
+	for _, weapon := range character.Weapons {
+		if weapon.ID == weaponId {
+			return weapon, true
+		}
+	}
+	return Weapon{}, false
+}
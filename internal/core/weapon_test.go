@@ -0,0 +1,188 @@
+package core
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/winik100/NoPenNoPaper/internal/testHelpers"
+)
+
+// dice with a single side keep the results predictable
+func TestRollDamage(t *testing.T) {
+	tests := []struct {
+		name        string
+		damage      string
+		damageBonus string
+		wantTotal   int
+		wantDice    int
+		wantErr     error
+	}{
+		{
+			name:      "Flat",
+			damage:    "3",
+			wantTotal: 3,
+		},
+		{
+			name:      "Dice",
+			damage:    "2d1",
+			wantTotal: 2,
+			wantDice:  2,
+		},
+		{
+			name:      "Upper Case Dice And Spaces",
+			damage:    "1D1 + 2",
+			wantTotal: 3,
+			wantDice:  1,
+		},
+		{
+			name:      "Subtracted Term",
+			damage:    "1d1+4-2",
+			wantTotal: 3,
+			wantDice:  1,
+		},
+		{
+			name:        "Damage Bonus Dice",
+			damage:      "1d1+DB",
+			damageBonus: "+1d1",
+			wantTotal:   2,
+			wantDice:    2,
+		},
+		{
+			name:        "Damage Bonus 0",
+			damage:      "1d1+DB",
+			damageBonus: "0",
+			wantTotal:   1,
+			wantDice:    1,
+		},
+		{
+			name:        "Negative Damage Bonus",
+			damage:      "3+DB",
+			damageBonus: "-2",
+			wantTotal:   1,
+		},
+		{
+			name:        "Total Never Below 0",
+			damage:      "1d1+DB",
+			damageBonus: "-2",
+			wantTotal:   0,
+			wantDice:    1,
+		},
+		{
+			name:        "Half Damage Bonus",
+			damage:      "2+½DB",
+			damageBonus: "+2d1",
+			wantTotal:   3,
+			wantDice:    2,
+		},
+		{
+			name:        "Half Of A Negative Damage Bonus",
+			damage:      "4+½DB",
+			damageBonus: "-2",
+			wantTotal:   3,
+		},
+		{
+			name:        "Half Of -1 Rounds Towards 0",
+			damage:      "4+½DB",
+			damageBonus: "-1",
+			wantTotal:   4,
+		},
+		{
+			name:    "Damage Bonus Missing",
+			damage:  "1d6+DB",
+			wantErr: ErrInvalidDamage,
+		},
+		{
+			name:        "Damage Bonus Invalid",
+			damage:      "1d6+DB",
+			damageBonus: "viel",
+			wantErr:     ErrInvalidDamage,
+		},
+		{
+			name:    "Trailing Operator",
+			damage:  "1d6+",
+			wantErr: ErrInvalidDamage,
+		},
+		{
+			name:    "Missing Operator",
+			damage:  "1d6DB",
+			wantErr: ErrInvalidDamage,
+		},
+		{
+			name:    "Unknown Operator",
+			damage:  "1d6*2",
+			wantErr: ErrInvalidDamage,
+		},
+		{
+			name:    "Empty",
+			damage:  "",
+			wantErr: ErrInvalidDamage,
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			weapon := Weapon{Name: "Messer", Damage: testCase.damage}
+			damage, err := weapon.RollDamage(testCase.damageBonus)
+			if testCase.wantErr != nil {
+				testHelpers.Equal(t, errors.Is(err, testCase.wantErr), true)
+				return
+			}
+			testHelpers.NilError(t, err)
+			testHelpers.Equal(t, damage.Total, testCase.wantTotal)
+			testHelpers.Equal(t, len(damage.Dice), testCase.wantDice)
+			testHelpers.Equal(t, damage.DamageBonus, testCase.damageBonus)
+		})
+	}
+}
+
+func TestRollDamageRange(t *testing.T) {
+	weapon := Weapon{Name: "Messer", Damage: "1d4+DB"}
+	for range 100 {
+		damage, err := weapon.RollDamage("+1d6")
+		testHelpers.NilError(t, err)
+		if damage.Total < 2 || damage.Total > 10 {
+			t.Fatalf("1d4+1d6 rolled %d", damage.Total)
+		}
+		testHelpers.Equal(t, len(damage.Dice), 2)
+	}
+}
+
+func TestMalfunctions(t *testing.T) {
+	tests := []struct {
+		name   string
+		weapon Weapon
+		result int
+		want   bool
+	}{
+		{
+			name:   "Below Malfunction Number",
+			weapon: Weapon{Malfunction: 98},
+			result: 97,
+			want:   false,
+		},
+		{
+			name:   "At Malfunction Number",
+			weapon: Weapon{Malfunction: 98},
+			result: 98,
+			want:   true,
+		},
+		{
+			name:   "100",
+			weapon: Weapon{Malfunction: 98},
+			result: 100,
+			want:   true,
+		},
+		{
+			name:   "Weapon Without Malfunction Number",
+			weapon: Weapon{},
+			result: 100,
+			want:   false,
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			testHelpers.Equal(t, testCase.weapon.Malfunctions(testCase.result), testCase.want)
+		})
+	}
+}
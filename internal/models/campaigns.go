@@ -0,0 +1,568 @@
+package models
+
+import (
+	"crypto/rand"
+	"database/sql"
+	"encoding/base64"
+	"errors"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/winik100/NoPenNoPaper/internal/core"
+)
+
+type CampaignModelInterface interface {
+	Insert(title string, createdBy int) (int, error)
+	Get(campaignId int) (core.Campaign, error)
+	SetEra(campaignId int, era core.Era) error
+	GetAllFrom(userId int) ([]core.Campaign, error)
+	GetAllFor(userId int) ([]core.Campaign, error)
+	Delete(campaignId int) error
+	AddMember(campaignId, userId int) error
+	RemoveMember(campaignId, userId int) error
+	AddCharacter(campaignId, characterId int) error
+	RemoveCharacter(campaignId, characterId int) error
+	CreateInvite(campaignId int, expires time.Time, singleUse bool) (string, error)
+	GetInvite(code string) (core.Invite, error)
+	GetInvites(campaignId int) ([]core.Invite, error)
+	RevokeInvite(campaignId int, code string) error
+	Join(code string, userId int, characterIds []int) (int, error)
+	SetTable(campaignId int, characterIds []int) error
+	RevealHandout(campaignId, materialId, userId int) error
+	HideHandout(campaignId, handoutId int) error
+	GetHandouts(campaignId int) ([]core.Handout, error)
+	GetHandoutsFor(userId int) ([]core.Handout, error)
+}
+
+type CampaignModel struct {
+	DB *sql.DB
+}
+
+func (c *CampaignModel) Insert(title string, createdBy int) (int, error) {
+	stmt := "INSERT INTO campaigns (title, created_by) VALUES (?,?);"
+	res, err := c.DB.Exec(stmt, title, createdBy)
+	if err != nil {
+		return 0, err
+	}
+	id, err := res.LastInsertId()
+	if err != nil {
+		return 0, err
+	}
+	return int(id), nil
+}
+
+func (c *CampaignModel) Get(campaignId int) (core.Campaign, error) {
+	campaign := core.Campaign{ID: campaignId}
+
+	stmt := "SELECT title, created_by, era FROM campaigns WHERE id=?;"
+	err := c.DB.QueryRow(stmt, campaignId).Scan(&campaign.Title, &campaign.CreatedBy, &campaign.Era)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return core.Campaign{}, ErrNoRecord
+		}
+		return core.Campaign{}, err
+	}
+
+	stmt = "SELECT u.id, u.name FROM campaign_members AS m JOIN users AS u ON m.user_id = u.id WHERE m.campaign_id=?;"
+	rows, err := c.DB.Query(stmt, campaignId)
+	if err != nil {
+		return core.Campaign{}, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var member core.User
+		err = rows.Scan(&member.ID, &member.Name)
+		if err != nil {
+			return core.Campaign{}, err
+		}
+		campaign.Members = append(campaign.Members, member)
+	}
+	if err = rows.Err(); err != nil {
+		return core.Campaign{}, err
+	}
+
+	stmt = "SELECT character_id FROM campaign_table WHERE campaign_id=?;"
+	rows, err = c.DB.Query(stmt, campaignId)
+	if err != nil {
+		return core.Campaign{}, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var characterId int
+		err = rows.Scan(&characterId)
+		if err != nil {
+			return core.Campaign{}, err
+		}
+		campaign.Table = append(campaign.Table, characterId)
+	}
+	if err = rows.Err(); err != nil {
+		return core.Campaign{}, err
+	}
+
+	return campaign, nil
+}
+
+// the era decides the price level, e.g. for the wealth of the campaign's characters
+func (c *CampaignModel) SetEra(campaignId int, era core.Era) error {
+	stmt := "UPDATE campaigns SET era=? WHERE id=?;"
+	_, err := c.DB.Exec(stmt, era, campaignId)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// campaigns run by the given user
+func (c *CampaignModel) GetAllFrom(userId int) ([]core.Campaign, error) {
+	stmt := "SELECT id FROM campaigns WHERE created_by=?;"
+	return c.getAll(stmt, userId)
+}
+
+// campaigns the given user takes part in as a player
+func (c *CampaignModel) GetAllFor(userId int) ([]core.Campaign, error) {
+	stmt := "SELECT campaign_id FROM campaign_members WHERE user_id=?;"
+	return c.getAll(stmt, userId)
+}
+
+func (c *CampaignModel) getAll(stmt string, userId int) ([]core.Campaign, error) {
+	rows, err := c.DB.Query(stmt, userId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var campaignIds []int
+	for rows.Next() {
+		var id int
+		err := rows.Scan(&id)
+		if err != nil {
+			return nil, err
+		}
+		campaignIds = append(campaignIds, id)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	var campaigns []core.Campaign
+	for _, id := range campaignIds {
+		campaign, err := c.Get(id)
+		if err != nil {
+			return nil, err
+		}
+		campaigns = append(campaigns, campaign)
+	}
+
+	return campaigns, nil
+}
+
+func (c *CampaignModel) Delete(campaignId int) error {
+	stmt := "DELETE FROM campaigns WHERE id=?;"
+	_, err := c.DB.Exec(stmt, campaignId)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (c *CampaignModel) AddMember(campaignId, userId int) error {
+	stmt := "INSERT INTO campaign_members (campaign_id, user_id) VALUES (?,?);"
+	_, err := c.DB.Exec(stmt, campaignId, userId)
+	var mysqlErr *mysql.MySQLError
+	if err != nil {
+		if errors.As(err, &mysqlErr) && mysqlErr.Number == 1062 {
+			return ErrAlreadyMember
+		}
+		return err
+	}
+	return nil
+}
+
+func (c *CampaignModel) RemoveMember(campaignId, userId int) error {
+	tx, err := c.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	stmt := "DELETE FROM campaign_table WHERE campaign_id=? AND character_id IN (SELECT id FROM characters WHERE created_by=?);"
+	_, err = tx.Exec(stmt, campaignId, userId)
+	if err != nil {
+		return err
+	}
+
+	stmt = "UPDATE characters SET campaign_id=NULL WHERE campaign_id=? AND created_by=?;"
+	_, err = tx.Exec(stmt, campaignId, userId)
+	if err != nil {
+		return err
+	}
+
+	stmt = "DELETE FROM handouts WHERE campaign_id=? AND revealed_to=?;"
+	_, err = tx.Exec(stmt, campaignId, userId)
+	if err != nil {
+		return err
+	}
+
+	stmt = "DELETE FROM campaign_members WHERE campaign_id=? AND user_id=?;"
+	_, err = tx.Exec(stmt, campaignId, userId)
+	if err != nil {
+		return err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (c *CampaignModel) AddCharacter(campaignId, characterId int) error {
+	tx, err := c.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var isMember bool
+	stmt := `SELECT EXISTS(SELECT true FROM characters AS c JOIN campaign_members AS m ON c.created_by = m.user_id
+				WHERE c.id=? AND m.campaign_id=?);`
+	err = tx.QueryRow(stmt, characterId, campaignId).Scan(&isMember)
+	if err != nil {
+		return err
+	}
+
+	if !isMember {
+		return ErrNotAMember
+	}
+
+	stmt = "UPDATE characters SET campaign_id=? WHERE id=?;"
+	_, err = tx.Exec(stmt, campaignId, characterId)
+	if err != nil {
+		return err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (c *CampaignModel) RemoveCharacter(campaignId, characterId int) error {
+	tx, err := c.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	stmt := "DELETE FROM campaign_table WHERE campaign_id=? AND character_id=?;"
+	_, err = tx.Exec(stmt, campaignId, characterId)
+	if err != nil {
+		return err
+	}
+
+	stmt = "UPDATE characters SET campaign_id=NULL WHERE id=? AND campaign_id=?;"
+	_, err = tx.Exec(stmt, characterId, campaignId)
+	if err != nil {
+		return err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// expires may be the zero value for invites which never expire
+func (c *CampaignModel) CreateInvite(campaignId int, expires time.Time, singleUse bool) (string, error) {
+	code, err := generateInviteCode()
+	if err != nil {
+		return "", err
+	}
+
+	var expiry sql.NullTime
+	if !expires.IsZero() {
+		expiry = sql.NullTime{Time: expires.UTC(), Valid: true}
+	}
+
+	stmt := "INSERT INTO campaign_invites (code, campaign_id, expires, single_use) VALUES (?,?,?,?);"
+	_, err = c.DB.Exec(stmt, code, campaignId, expiry, singleUse)
+	if err != nil {
+		return "", err
+	}
+	return code, nil
+}
+
+func (c *CampaignModel) GetInvite(code string) (core.Invite, error) {
+	invite := core.Invite{Code: code}
+	var expiry sql.NullTime
+
+	stmt := `SELECT i.campaign_id, c.title, i.expires, i.single_use, i.used FROM campaign_invites AS i
+				JOIN campaigns AS c ON i.campaign_id = c.id WHERE i.code=?;`
+	err := c.DB.QueryRow(stmt, code).Scan(&invite.CampaignID, &invite.CampaignTitle, &expiry, &invite.SingleUse, &invite.Used)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return core.Invite{}, ErrNoRecord
+		}
+		return core.Invite{}, err
+	}
+	invite.Expires = expiry.Time
+
+	return invite, nil
+}
+
+func (c *CampaignModel) GetInvites(campaignId int) ([]core.Invite, error) {
+	stmt := `SELECT i.code, c.title, i.expires, i.single_use, i.used FROM campaign_invites AS i
+				JOIN campaigns AS c ON i.campaign_id = c.id WHERE i.campaign_id=?;`
+	rows, err := c.DB.Query(stmt, campaignId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var invites []core.Invite
+	for rows.Next() {
+		invite := core.Invite{CampaignID: campaignId}
+		var expiry sql.NullTime
+		err = rows.Scan(&invite.Code, &invite.CampaignTitle, &expiry, &invite.SingleUse, &invite.Used)
+		if err != nil {
+			return nil, err
+		}
+		invite.Expires = expiry.Time
+		invites = append(invites, invite)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return invites, nil
+}
+
+func (c *CampaignModel) RevokeInvite(campaignId int, code string) error {
+	stmt := "DELETE FROM campaign_invites WHERE code=? AND campaign_id=?;"
+	_, err := c.DB.Exec(stmt, code, campaignId)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// adds the user to the campaign the invite belongs to and brings along the given characters of that user.
+// returns the id of the joined campaign.
+func (c *CampaignModel) Join(code string, userId int, characterIds []int) (int, error) {
+	tx, err := c.DB.Begin()
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	invite := core.Invite{Code: code}
+	var expiry sql.NullTime
+	stmt := "SELECT campaign_id, expires, single_use, used FROM campaign_invites WHERE code=? FOR UPDATE;"
+	err = tx.QueryRow(stmt, code).Scan(&invite.CampaignID, &expiry, &invite.SingleUse, &invite.Used)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, ErrInvalidInvite
+		}
+		return 0, err
+	}
+	invite.Expires = expiry.Time
+
+	if !invite.Usable(time.Now()) {
+		return 0, ErrInvalidInvite
+	}
+
+	var isMember bool
+	stmt = "SELECT EXISTS(SELECT true FROM campaign_members WHERE campaign_id=? AND user_id=?);"
+	err = tx.QueryRow(stmt, invite.CampaignID, userId).Scan(&isMember)
+	if err != nil {
+		return 0, err
+	}
+
+	if !isMember {
+		stmt = "INSERT INTO campaign_members (campaign_id, user_id) VALUES (?,?);"
+		_, err = tx.Exec(stmt, invite.CampaignID, userId)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	if invite.SingleUse {
+		stmt = "UPDATE campaign_invites SET used=TRUE WHERE code=?;"
+		_, err = tx.Exec(stmt, code)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	// characters already playing in another campaign stay there
+	for _, characterId := range characterIds {
+		stmt = "UPDATE characters SET campaign_id=? WHERE id=? AND created_by=? AND campaign_id IS NULL;"
+		_, err = tx.Exec(stmt, invite.CampaignID, characterId, userId)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return 0, err
+	}
+	return invite.CampaignID, nil
+}
+
+// replaces the characters currently at the gaming table, ignoring those not part of the campaign
+func (c *CampaignModel) SetTable(campaignId int, characterIds []int) error {
+	tx, err := c.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	stmt := "DELETE FROM campaign_table WHERE campaign_id=?;"
+	_, err = tx.Exec(stmt, campaignId)
+	if err != nil {
+		return err
+	}
+
+	for _, characterId := range characterIds {
+		stmt = "INSERT INTO campaign_table (campaign_id, character_id) SELECT campaign_id, id FROM characters WHERE id=? AND campaign_id=?;"
+		_, err = tx.Exec(stmt, characterId, campaignId)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// reveals one of the GM's materials to a single member of the campaign, or to all members if userId is 0
+func (c *CampaignModel) RevealHandout(campaignId, materialId, userId int) error {
+	tx, err := c.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var exists bool
+	if userId == 0 {
+		stmt := "SELECT EXISTS(SELECT true FROM handouts WHERE campaign_id=? AND material_id=? AND revealed_to IS NULL);"
+		err = tx.QueryRow(stmt, campaignId, materialId).Scan(&exists)
+	} else {
+		var isMember bool
+		stmt := "SELECT EXISTS(SELECT true FROM campaign_members WHERE campaign_id=? AND user_id=?);"
+		err = tx.QueryRow(stmt, campaignId, userId).Scan(&isMember)
+		if err != nil {
+			return err
+		}
+		if !isMember {
+			return ErrNotAMember
+		}
+
+		stmt = "SELECT EXISTS(SELECT true FROM handouts WHERE campaign_id=? AND material_id=? AND (revealed_to IS NULL OR revealed_to=?));"
+		err = tx.QueryRow(stmt, campaignId, materialId, userId).Scan(&exists)
+	}
+	if err != nil {
+		return err
+	}
+	if exists {
+		return nil
+	}
+
+	revealedTo := sql.NullInt64{Int64: int64(userId), Valid: userId != 0}
+	if !revealedTo.Valid {
+		// revealing to everyone supersedes any individual reveals
+		stmt := "DELETE FROM handouts WHERE campaign_id=? AND material_id=?;"
+		_, err = tx.Exec(stmt, campaignId, materialId)
+		if err != nil {
+			return err
+		}
+	}
+
+	stmt := `INSERT INTO handouts (material_id, campaign_id, revealed_to) 
+	SELECT m.id, c.id, ? FROM materials AS m JOIN campaigns AS c ON m.uploaded_by = c.created_by WHERE m.id=? AND c.id=?;`
+	res, err := tx.Exec(stmt, revealedTo, materialId, campaignId)
+	if err != nil {
+		return err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrNoRecord
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (c *CampaignModel) HideHandout(campaignId, handoutId int) error {
+	stmt := "DELETE FROM handouts WHERE id=? AND campaign_id=?;"
+	_, err := c.DB.Exec(stmt, handoutId, campaignId)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (c *CampaignModel) GetHandouts(campaignId int) ([]core.Handout, error) {
+	stmt := `SELECT h.id, h.material_id, h.campaign_id, m.title, m.file_name, m.uploaded_by, h.revealed_to, u.name 
+	FROM handouts AS h JOIN materials AS m ON h.material_id = m.id LEFT JOIN users AS u ON h.revealed_to = u.id 
+	WHERE h.campaign_id=? ORDER BY h.id;`
+	return c.getHandouts(stmt, campaignId)
+}
+
+// all handouts revealed to the given user in any campaign they are a member of
+func (c *CampaignModel) GetHandoutsFor(userId int) ([]core.Handout, error) {
+	stmt := `SELECT h.id, h.material_id, h.campaign_id, m.title, m.file_name, m.uploaded_by, h.revealed_to, u.name 
+	FROM handouts AS h JOIN materials AS m ON h.material_id = m.id LEFT JOIN users AS u ON h.revealed_to = u.id 
+	JOIN campaign_members AS cm ON h.campaign_id = cm.campaign_id 
+	WHERE cm.user_id=? AND (h.revealed_to IS NULL OR h.revealed_to = cm.user_id) ORDER BY h.id;`
+	return c.getHandouts(stmt, userId)
+}
+
+func (c *CampaignModel) getHandouts(stmt string, id int) ([]core.Handout, error) {
+	rows, err := c.DB.Query(stmt, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var handouts []core.Handout
+	for rows.Next() {
+		var handout core.Handout
+		var revealedTo sql.NullInt64
+		var revealedToName sql.NullString
+		err = rows.Scan(&handout.ID, &handout.MaterialID, &handout.CampaignID, &handout.Title, &handout.FileName, &handout.UploadedBy, &revealedTo, &revealedToName)
+		if err != nil {
+			return nil, err
+		}
+		handout.RevealedTo = int(revealedTo.Int64)
+		handout.RevealedToName = revealedToName.String
+		handouts = append(handouts, handout)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return handouts, nil
+}
+
+func generateInviteCode() (string, error) {
+	b := make([]byte, 16)
+	_, err := rand.Read(b)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
@@ -0,0 +1,361 @@
+package models
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/winik100/NoPenNoPaper/internal/core"
+	"github.com/winik100/NoPenNoPaper/internal/testHelpers"
+)
+
+func TestCampaignInsertAndGet(t *testing.T) {
+	db := newTestDB(t)
+
+	c := CampaignModel{db}
+
+	id, err := c.Insert("Der Tanz der Drachen", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testHelpers.Equal(t, id, 1)
+
+	campaign, err := c.Get(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testHelpers.Equal(t, campaign.Title, "Der Tanz der Drachen")
+	testHelpers.Equal(t, campaign.IsRunBy(1), true)
+	testHelpers.Equal(t, campaign.Era, core.Classic)
+
+	err = c.SetEra(id, core.Modern)
+	if err != nil {
+		t.Fatal(err)
+	}
+	campaign, err = c.Get(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testHelpers.Equal(t, campaign.Era, core.Modern)
+
+	_, err = c.Get(69)
+	testHelpers.Equal(t, errors.Is(err, ErrNoRecord), true)
+}
+
+func TestCampaignMembers(t *testing.T) {
+	db := newTestDB(t)
+
+	c := CampaignModel{db}
+	u := UserModel{db}
+
+	campaignId, err := c.Insert("Der Tanz der Drachen", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	playerId, err := u.Insert("test", "testpw")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		userId  int
+		wantErr error
+	}{
+		{
+			name:    "New Member",
+			userId:  playerId,
+			wantErr: nil,
+		},
+		{
+			name:    "Already Member",
+			userId:  playerId,
+			wantErr: ErrAlreadyMember,
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := c.AddMember(campaignId, testCase.userId)
+			testHelpers.Equal(t, errors.Is(err, testCase.wantErr), true)
+
+			campaign, err := c.Get(campaignId)
+			if err != nil {
+				t.Fatal(err)
+			}
+			testHelpers.Equal(t, campaign.HasMember(testCase.userId), true)
+		})
+	}
+
+	campaigns, err := c.GetAllFor(playerId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testHelpers.Equal(t, len(campaigns), 1)
+
+	err = c.RemoveMember(campaignId, playerId)
+	testHelpers.NilError(t, err)
+
+	campaign, err := c.Get(campaignId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testHelpers.Equal(t, campaign.HasMember(playerId), false)
+}
+
+func TestCampaignJoin(t *testing.T) {
+	db := newTestDB(t)
+
+	c := CampaignModel{db}
+	u := UserModel{db}
+	ch := CharacterModel{db}
+
+	campaignId, err := c.Insert("Der Tanz der Drachen", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	playerId, err := u.Insert("test", "testpw")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	singleUse, err := c.CreateInvite(campaignId, time.Time{}, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expired, err := c.CreateInvite(campaignId, time.Now().Add(-time.Hour), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		code    string
+		wantErr error
+	}{
+		{
+			name:    "Valid Invite",
+			code:    singleUse,
+			wantErr: nil,
+		},
+		{
+			name:    "Single Use Invite already used",
+			code:    singleUse,
+			wantErr: ErrInvalidInvite,
+		},
+		{
+			name:    "Expired Invite",
+			code:    expired,
+			wantErr: ErrInvalidInvite,
+		},
+		{
+			name:    "Nonexistent Invite",
+			code:    "gibtsnicht",
+			wantErr: ErrInvalidInvite,
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			id, err := c.Join(testCase.code, playerId, nil)
+			testHelpers.Equal(t, errors.Is(err, testCase.wantErr), true)
+			if testCase.wantErr == nil {
+				testHelpers.Equal(t, id, campaignId)
+			}
+		})
+	}
+
+	campaign, err := c.Get(campaignId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testHelpers.Equal(t, campaign.HasMember(playerId), true)
+
+	otherCampaignId, err := c.Insert("Die Saat der Könige", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assignedId, err := ch.Insert(core.Character{CampaignID: otherCampaignId, Info: core.CharacterInfo{Name: "Otto Hightower"}}, playerId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	unassignedId, err := ch.Insert(core.Character{Info: core.CharacterInfo{Name: "Alicent Hightower"}}, playerId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	multiUse, err := c.CreateInvite(campaignId, time.Time{}, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = c.Join(multiUse, playerId, []int{assignedId, unassignedId})
+	testHelpers.NilError(t, err)
+
+	assigned, err := ch.Get(assignedId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testHelpers.Equal(t, assigned.CampaignID, otherCampaignId)
+	unassigned, err := ch.Get(unassignedId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testHelpers.Equal(t, unassigned.CampaignID, campaignId)
+
+	err = c.RevokeInvite(campaignId, expired)
+	testHelpers.NilError(t, err)
+
+	invites, err := c.GetInvites(campaignId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testHelpers.Equal(t, len(invites), 2)
+}
+
+func TestCampaignTable(t *testing.T) {
+	db := newTestDB(t)
+
+	c := CampaignModel{db}
+	ch := CharacterModel{db}
+
+	campaignId, err := c.Insert("Der Tanz der Drachen", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherCampaignId, err := c.Insert("Die Saat der Könige", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ottoId, err := ch.Insert(core.Character{CampaignID: campaignId, Info: core.CharacterInfo{Name: "Otto Hightower"}}, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	viserysId, err := ch.Insert(core.Character{CampaignID: campaignId, Info: core.CharacterInfo{Name: "Viserys Targaryen"}}, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	outsiderId, err := ch.Insert(core.Character{CampaignID: otherCampaignId, Info: core.CharacterInfo{Name: "Alicent Hightower"}}, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name         string
+		characterIds []int
+		wantTable    []int
+	}{
+		{
+			name:         "Whole Party",
+			characterIds: []int{ottoId, viserysId},
+			wantTable:    []int{ottoId, viserysId},
+		},
+		{
+			name:         "Replaces Previous Table",
+			characterIds: []int{viserysId},
+			wantTable:    []int{viserysId},
+		},
+		{
+			name:         "Ignores Other Campaigns",
+			characterIds: []int{ottoId, outsiderId},
+			wantTable:    []int{ottoId},
+		},
+		{
+			name:         "Empty Table",
+			characterIds: nil,
+			wantTable:    nil,
+		},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := c.SetTable(campaignId, testCase.characterIds)
+			testHelpers.NilError(t, err)
+
+			campaign, err := c.Get(campaignId)
+			if err != nil {
+				t.Fatal(err)
+			}
+			testHelpers.Equal(t, len(campaign.Table), len(testCase.wantTable))
+			for _, characterId := range testCase.wantTable {
+				testHelpers.Equal(t, campaign.AtTable(characterId), true)
+			}
+		})
+	}
+}
+
+func TestCampaignHandouts(t *testing.T) {
+	db := newTestDB(t)
+
+	c := CampaignModel{db}
+	u := UserModel{db}
+
+	campaignId, err := c.Insert("Der Tanz der Drachen", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	playerId, err := u.Insert("test", "testpw")
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherId, err := u.Insert("test2", "testpw")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, userId := range []int{playerId, otherId} {
+		err = c.AddMember(campaignId, userId)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	err = u.AddMaterial("Karte", "karte.png", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = u.AddMaterial("Brief", "brief.png", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = u.AddMaterial("Fremdes Material", "fremd.png", playerId)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = c.RevealHandout(campaignId, 1, playerId)
+	testHelpers.NilError(t, err)
+	err = c.RevealHandout(campaignId, 2, 0)
+	testHelpers.NilError(t, err)
+
+	err = c.RevealHandout(campaignId, 3, 0)
+	testHelpers.Equal(t, errors.Is(err, ErrNoRecord), true)
+	err = c.RevealHandout(campaignId, 1, 69)
+	testHelpers.Equal(t, errors.Is(err, ErrNotAMember), true)
+
+	handouts, err := c.GetHandoutsFor(playerId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testHelpers.Equal(t, len(handouts), 2)
+
+	handouts, err = c.GetHandoutsFor(otherId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testHelpers.Equal(t, len(handouts), 1)
+	testHelpers.Equal(t, handouts[0].RevealedToAll(), true)
+
+	handouts, err = c.GetHandouts(campaignId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testHelpers.Equal(t, len(handouts), 2)
+	testHelpers.Equal(t, handouts[0].RevealedToName, "test")
+
+	err = c.HideHandout(campaignId, handouts[0].ID)
+	testHelpers.NilError(t, err)
+
+	handouts, err = c.GetHandoutsFor(playerId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testHelpers.Equal(t, len(handouts), 1)
+}
@@ -12,6 +12,9 @@ type CharacterModelInterface interface {
 	Get(characterId int) (core.Character, error)
 	GetAllFrom(userId int) ([]core.Character, error)
 	GetAll() ([]core.Character, error)
+	GetAllInCampaign(campaignId int) ([]core.Character, error)
+	GetNPCsFrom(userId int) ([]core.Character, error)
+	GetNPCsInCampaign(campaignId int) ([]core.Character, error)
 	Delete(characterId int) error
 	GetAvailableSkills() (core.Skills, error)
 	AddSkill(characterId int, skill string, value int) error
@@ -23,14 +26,38 @@ type CharacterModelInterface interface {
 	DeleteItem(itemId int) error
 	AddNote(characterId int, text string) (int, error)
 	DeleteNote(noteId int) error
+	AddBackstoryEntry(characterId int, section core.BackstorySection, text string) (int, error)
+	DeleteBackstoryEntry(characterId, entryId int) error
+	SetKeyConnection(characterId, entryId int) error
 	IncrementStat(characterId int, stat string) (int, error)
 	DecrementStat(characterId int, stat string) (int, error)
+	ApplyDamage(characterId, amount int) (core.Damage, error)
+	TreatWounds(characterId int) error
+	GainLuck(characterId, gain int) (int, error)
+	SetSkillTick(characterId int, skill string, used bool) error
+	Develop(characterId int, improvements []core.Improvement) error
+	CapSanity(characterId, maxSanity int) error
+	EditAttribute(characterId int, attribute string, value int) (core.CharacterAttributes, error)
 }
 
 type CharacterModel struct {
 	DB *sql.DB
 }
 
+const statsColumns = `maxtp, tp, maxsta, sta, maxmp, mp, maxluck, luck, san_loss_today, temp_insane, indef_insane,
+	major_wound, unconscious, dying, dead`
+
+func scanStats(row rowScanner) (core.CharacterStats, error) {
+	var stats core.CharacterStats
+	err := row.Scan(&stats.MaxTP, &stats.TP, &stats.MaxSTA, &stats.STA, &stats.MaxMP, &stats.MP, &stats.MaxLUCK, &stats.LUCK,
+		&stats.SanityLossToday, &stats.TemporaryInsanity, &stats.IndefiniteInsanity,
+		&stats.MajorWound, &stats.Unconscious, &stats.Dying, &stats.Dead)
+	if err != nil {
+		return core.CharacterStats{}, err
+	}
+	return stats, nil
+}
+
 func (c *CharacterModel) Insert(character core.Character, created_by int) (int, error) {
 	tx, err := c.DB.Begin()
 	if err != nil {
@@ -38,8 +65,15 @@ func (c *CharacterModel) Insert(character core.Character, created_by int) (int,
 	}
 	defer tx.Rollback()
 
-	stmt := "INSERT INTO characters (created_by) VALUES (?);"
-	result, err := tx.Exec(stmt, created_by)
+	if character.Kind != core.KindNPC {
+		character.Kind = core.KindInvestigator
+		character.TrackTP = true
+		character.TrackSTA = true
+	}
+	campaignId := sql.NullInt64{Int64: int64(character.CampaignID), Valid: character.CampaignID != 0}
+
+	stmt := "INSERT INTO characters (created_by, campaign_id, kind, track_tp, track_sta) VALUES (?,?,?,?,?);"
+	result, err := tx.Exec(stmt, created_by, campaignId, character.Kind, character.TrackTP, character.TrackSTA)
 	if err != nil {
 		return 0, err
 	}
@@ -55,10 +89,10 @@ func (c *CharacterModel) Insert(character core.Character, created_by int) (int,
 		return 0, err
 	}
 
-	stmt = "INSERT INTO character_attributes (character_id, st, ge, ma, ko, er, bi, gr, i, bw) VALUES (?,?,?,?,?,?,?,?,?,?);"
-	_, err = tx.Exec(stmt, id, character.Attributes.ST, character.Attributes.GE, character.Attributes.MA,
-		character.Attributes.KO, character.Attributes.ER, character.Attributes.BI,
-		character.Attributes.GR, character.Attributes.IN, character.Attributes.BW)
+	attributes := character.DeriveAttributes()
+	stmt = "INSERT INTO character_attributes (character_id, st, ge, ma, ko, er, bi, gr, i, bw, db, build) VALUES (?,?,?,?,?,?,?,?,?,?,?,?);"
+	_, err = tx.Exec(stmt, id, attributes.ST, attributes.GE, attributes.MA, attributes.KO, attributes.ER, attributes.BI,
+		attributes.GR, attributes.IN, attributes.BW, attributes.DB, attributes.Build)
 	if err != nil {
 		return 0, err
 	}
@@ -116,10 +150,14 @@ func (c *CharacterModel) Get(characterId int) (core.Character, error) {
 	var customSkills core.CustomSkills
 	var items core.Items
 	var notes core.Notes
+	var createdBy int
+	var campaignId sql.NullInt64
+	var kind string
+	var trackTP, trackSTA bool
 
-	stmt := "SELECT name, profession, age, gender, residence, birthplace FROM character_info WHERE character_id=?;"
+	stmt := "SELECT created_by, campaign_id, kind, track_tp, track_sta FROM characters WHERE id=?;"
 	result := c.DB.QueryRow(stmt, characterId)
-	err := result.Scan(&info.Name, &info.Profession, &info.Age, &info.Gender, &info.Residence, &info.Birthplace)
+	err := result.Scan(&createdBy, &campaignId, &kind, &trackTP, &trackSTA)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return core.Character{}, ErrNoRecord
@@ -127,9 +165,9 @@ func (c *CharacterModel) Get(characterId int) (core.Character, error) {
 		return core.Character{}, err
 	}
 
-	stmt = "SELECT st, ge, ma, ko, er, bi, gr, i, bw FROM character_attributes WHERE character_id=?;"
+	stmt = "SELECT name, profession, age, gender, residence, birthplace FROM character_info WHERE character_id=?;"
 	result = c.DB.QueryRow(stmt, characterId)
-	err = result.Scan(&attr.ST, &attr.GE, &attr.MA, &attr.KO, &attr.ER, &attr.BI, &attr.GR, &attr.IN, &attr.BW)
+	err = result.Scan(&info.Name, &info.Profession, &info.Age, &info.Gender, &info.Residence, &info.Birthplace)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return core.Character{}, ErrNoRecord
@@ -137,9 +175,18 @@ func (c *CharacterModel) Get(characterId int) (core.Character, error) {
 		return core.Character{}, err
 	}
 
-	stmt = "SELECT maxtp, tp, maxsta, sta, maxmp, mp, maxluck, luck FROM character_stats WHERE character_id=?;"
+	stmt = "SELECT st, ge, ma, ko, er, bi, gr, i, bw, db, build FROM character_attributes WHERE character_id=?;"
 	result = c.DB.QueryRow(stmt, characterId)
-	err = result.Scan(&stats.MaxTP, &stats.TP, &stats.MaxSTA, &stats.STA, &stats.MaxMP, &stats.MP, &stats.MaxLUCK, &stats.LUCK)
+	err = result.Scan(&attr.ST, &attr.GE, &attr.MA, &attr.KO, &attr.ER, &attr.BI, &attr.GR, &attr.IN, &attr.BW, &attr.DB, &attr.Build)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return core.Character{}, ErrNoRecord
+		}
+		return core.Character{}, err
+	}
+
+	stmt = "SELECT " + statsColumns + " FROM character_stats WHERE character_id=?;"
+	stats, err = scanStats(c.DB.QueryRow(stmt, characterId))
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return core.Character{}, ErrNoRecord
@@ -147,7 +194,8 @@ func (c *CharacterModel) Get(characterId int) (core.Character, error) {
 		return core.Character{}, err
 	}
 
-	stmt = "SELECT skill_name, value FROM character_skills WHERE character_id=?;"
+	var ticks []string
+	stmt = "SELECT skill_name, value, used FROM character_skills WHERE character_id=?;"
 	rows, err := c.DB.Query(stmt, characterId)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -161,18 +209,22 @@ func (c *CharacterModel) Get(characterId int) (core.Character, error) {
 	for rows.Next() {
 		var name string
 		var value int
+		var used bool
 
-		err = rows.Scan(&name, &value)
+		err = rows.Scan(&name, &value, &used)
 		if err != nil {
 			return core.Character{}, err
 		}
 		skillsName = append(skillsName, name)
 		skillsValue = append(skillsValue, value)
+		if used {
+			ticks = append(ticks, name)
+		}
 	}
 	skills.Name = skillsName
 	skills.Value = skillsValue
 
-	stmt = "SELECT custom_skill_name, value FROM character_custom_skills WHERE character_id=?;"
+	stmt = "SELECT custom_skill_name, value, used FROM character_custom_skills WHERE character_id=?;"
 	rows, err = c.DB.Query(stmt, characterId)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -185,13 +237,17 @@ func (c *CharacterModel) Get(characterId int) (core.Character, error) {
 	for rows.Next() {
 		var name string
 		var value int
+		var used bool
 
-		err = rows.Scan(&name, &value)
+		err = rows.Scan(&name, &value, &used)
 		if err != nil {
 			return core.Character{}, err
 		}
 		customSkillsName = append(customSkillsName, name)
 		customSkillsValue = append(customSkillsValue, value)
+		if used {
+			ticks = append(ticks, name)
+		}
 	}
 	customSkills.Name = customSkillsName
 	customSkills.Value = customSkillsValue
@@ -236,7 +292,27 @@ func (c *CharacterModel) Get(characterId int) (core.Character, error) {
 		notes.Text = append(notes.Text, text)
 	}
 
-	return core.Character{ID: characterId, Info: info, Attributes: attr, Stats: stats, Skills: skills, CustomSkills: customSkills, Items: items, Notes: notes}, nil
+	weapons, err := getCarriedWeapons(c.DB, characterId)
+	if err != nil {
+		return core.Character{}, err
+	}
+
+	spells, err := getKnownSpells(c.DB, characterId)
+	if err != nil {
+		return core.Character{}, err
+	}
+
+	tomes, err := getOwnedTomes(c.DB, characterId)
+	if err != nil {
+		return core.Character{}, err
+	}
+
+	backstory, err := c.getBackstory(characterId)
+	if err != nil {
+		return core.Character{}, err
+	}
+
+	return core.Character{ID: characterId, CreatedBy: createdBy, CampaignID: int(campaignId.Int64), Kind: kind, TrackTP: trackTP, TrackSTA: trackSTA, Info: info, Backstory: backstory, Attributes: attr, Stats: stats, Skills: skills, CustomSkills: customSkills, Ticks: ticks, Items: items, Weapons: weapons, Spells: spells, Tomes: tomes, Notes: notes}, nil
 }
 
 func (c *CharacterModel) Delete(characterId int) error {
@@ -249,7 +325,7 @@ func (c *CharacterModel) Delete(characterId int) error {
 }
 
 func (c *CharacterModel) GetAllFrom(userId int) ([]core.Character, error) {
-	stmt := "SELECT id FROM characters WHERE created_by=?;"
+	stmt := "SELECT id FROM characters WHERE created_by=? AND kind='investigator';"
 	rows, err := c.DB.Query(stmt, userId)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -320,6 +396,85 @@ func (c *CharacterModel) GetAll() ([]core.Character, error) {
 	return characters, nil
 }
 
+func (c *CharacterModel) GetAllInCampaign(campaignId int) ([]core.Character, error) {
+	stmt := "SELECT id FROM characters WHERE campaign_id=? AND kind='investigator';"
+	rows, err := c.DB.Query(stmt, campaignId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNoRecord
+		}
+		return nil, err
+	}
+	defer rows.Close()
+
+	var characterIds []int
+	for rows.Next() {
+		var id int
+		err := rows.Scan(&id)
+		if err != nil {
+			return nil, err
+		}
+		characterIds = append(characterIds, id)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	var characters []core.Character
+	for _, id := range characterIds {
+		character, err := c.Get(id)
+		if err != nil {
+			return nil, err
+		}
+		characters = append(characters, character)
+	}
+
+	return characters, nil
+}
+
+func (c *CharacterModel) GetNPCsFrom(userId int) ([]core.Character, error) {
+	stmt := "SELECT id FROM characters WHERE created_by=? AND kind='npc';"
+	return c.getAllWhere(stmt, userId)
+}
+
+func (c *CharacterModel) GetNPCsInCampaign(campaignId int) ([]core.Character, error) {
+	stmt := "SELECT id FROM characters WHERE campaign_id=? AND kind='npc';"
+	return c.getAllWhere(stmt, campaignId)
+}
+
+// loads every character whose id is returned by the given statement
+func (c *CharacterModel) getAllWhere(stmt string, arg int) ([]core.Character, error) {
+	rows, err := c.DB.Query(stmt, arg)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var characterIds []int
+	for rows.Next() {
+		var id int
+		err := rows.Scan(&id)
+		if err != nil {
+			return nil, err
+		}
+		characterIds = append(characterIds, id)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	var characters []core.Character
+	for _, id := range characterIds {
+		character, err := c.Get(id)
+		if err != nil {
+			return nil, err
+		}
+		characters = append(characters, character)
+	}
+
+	return characters, nil
+}
+
 func (c *CharacterModel) GetAvailableSkills() (core.Skills, error) {
 	var skillsName []string
 	var skillsValue []int
@@ -364,6 +519,110 @@ func (c *CharacterModel) EditSkill(characterId int, skill string, newValue int)
 	return nil
 }
 
+// marks a skill or custom skill for improvement in the next development phase
+func (c *CharacterModel) SetSkillTick(characterId int, skill string, used bool) error {
+	tx, err := c.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var found bool
+	for _, stmt := range []string{
+		"SELECT EXISTS(SELECT true FROM character_skills WHERE character_id=? AND skill_name=?);",
+		"SELECT EXISTS(SELECT true FROM character_custom_skills WHERE character_id=? AND custom_skill_name=?);",
+	} {
+		var exists bool
+		err = tx.QueryRow(stmt, characterId, skill).Scan(&exists)
+		if err != nil {
+			return err
+		}
+		found = found || exists
+	}
+	if !found {
+		return ErrNoRecord
+	}
+
+	stmt := "UPDATE character_skills SET used=? WHERE character_id=? AND skill_name=?;"
+	_, err = tx.Exec(stmt, used, characterId, skill)
+	if err != nil {
+		return err
+	}
+	stmt = "UPDATE character_custom_skills SET used=? WHERE character_id=? AND custom_skill_name=?;"
+	_, err = tx.Exec(stmt, used, characterId, skill)
+	if err != nil {
+		return err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// applies the outcome of a development phase and clears all ticks
+func (c *CharacterModel) Develop(characterId int, improvements []core.Improvement) error {
+	tx, err := c.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var sanityGain int
+	for _, improvement := range improvements {
+		if !improvement.Improved() {
+			continue
+		}
+		stmt := "UPDATE character_skills SET value=? WHERE character_id=? AND skill_name=?;"
+		_, err = tx.Exec(stmt, improvement.New, characterId, improvement.Skill)
+		if err != nil {
+			return err
+		}
+		stmt = "UPDATE character_custom_skills SET value=? WHERE character_id=? AND custom_skill_name=?;"
+		_, err = tx.Exec(stmt, improvement.New, characterId, improvement.Skill)
+		if err != nil {
+			return err
+		}
+		sanityGain += improvement.SanityGain
+	}
+
+	if sanityGain > 0 {
+		stmt := "UPDATE character_stats SET sta=LEAST(sta+?, maxsta) WHERE character_id=?;"
+		_, err = tx.Exec(stmt, sanityGain, characterId)
+		if err != nil {
+			return err
+		}
+	}
+
+	stmt := "UPDATE character_skills SET used=false WHERE character_id=?;"
+	_, err = tx.Exec(stmt, characterId)
+	if err != nil {
+		return err
+	}
+	stmt = "UPDATE character_custom_skills SET used=false WHERE character_id=?;"
+	_, err = tx.Exec(stmt, characterId)
+	if err != nil {
+		return err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// lowers maximum and current sanity to the given maximum, if they exceed it
+func (c *CharacterModel) CapSanity(characterId, maxSanity int) error {
+	stmt := "UPDATE character_stats SET maxsta=LEAST(maxsta, ?), sta=LEAST(sta, ?) WHERE character_id=?;"
+	_, err := c.DB.Exec(stmt, maxSanity, maxSanity, characterId)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
 func (c *CharacterModel) AddCustomSkill(characterId int, customSkill string, category string, value int) error {
 	tx, err := c.DB.Begin()
 	if err != nil {
@@ -467,6 +726,107 @@ func (c *CharacterModel) DeleteNote(noteId int) error {
 	return nil
 }
 
+// entries ordered by the time they were added
+func (c *CharacterModel) getBackstory(characterId int) (core.Backstory, error) {
+	stmt := "SELECT id, section, text, key_connection FROM backstory WHERE character_id=? ORDER BY id;"
+	rows, err := c.DB.Query(stmt, characterId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var backstory core.Backstory
+	for rows.Next() {
+		var entry core.BackstoryEntry
+		err = rows.Scan(&entry.ID, &entry.Section, &entry.Text, &entry.KeyConnection)
+		if err != nil {
+			return nil, err
+		}
+		backstory = append(backstory, entry)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return backstory, nil
+}
+
+func (c *CharacterModel) AddBackstoryEntry(characterId int, section core.BackstorySection, text string) (int, error) {
+	stmt := "INSERT INTO backstory (character_id, section, text) VALUES (?,?,?);"
+	res, err := c.DB.Exec(stmt, characterId, section, text)
+	if err != nil {
+		return 0, err
+	}
+	id, err := res.LastInsertId()
+	if err != nil {
+		return 0, err
+	}
+	return int(id), nil
+}
+
+// entries of other characters are left alone
+func (c *CharacterModel) DeleteBackstoryEntry(characterId, entryId int) error {
+	stmt := "DELETE FROM backstory WHERE id=? AND character_id=?;"
+	_, err := c.DB.Exec(stmt, entryId, characterId)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// makes the entry the character's only key connection
+func (c *CharacterModel) SetKeyConnection(characterId, entryId int) error {
+	tx, err := c.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var exists bool
+	stmt := "SELECT EXISTS(SELECT true FROM backstory WHERE id=? AND character_id=?);"
+	err = tx.QueryRow(stmt, entryId, characterId).Scan(&exists)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return ErrNoRecord
+	}
+
+	stmt = "UPDATE backstory SET key_connection=(id=?) WHERE character_id=?;"
+	_, err = tx.Exec(stmt, entryId, characterId)
+	if err != nil {
+		return err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// changes a single attribute and recomputes damage bonus, build and movement rate. returns all attributes.
+func (c *CharacterModel) EditAttribute(characterId int, attribute string, value int) (core.CharacterAttributes, error) {
+	character, err := c.Get(characterId)
+	if err != nil {
+		return core.CharacterAttributes{}, err
+	}
+
+	err = character.Attributes.Set(attribute, value)
+	if err != nil {
+		return core.CharacterAttributes{}, err
+	}
+	attributes := character.DeriveAttributes()
+
+	stmt := `UPDATE character_attributes SET st=?, ge=?, ma=?, ko=?, er=?, bi=?, gr=?, i=?, bw=?, db=?, build=?
+		WHERE character_id=?;`
+	_, err = c.DB.Exec(stmt, attributes.ST, attributes.GE, attributes.MA, attributes.KO, attributes.ER, attributes.BI,
+		attributes.GR, attributes.IN, attributes.BW, attributes.DB, attributes.Build, characterId)
+	if err != nil {
+		return core.CharacterAttributes{}, err
+	}
+	return attributes, nil
+}
+
 func (c *CharacterModel) IncrementStat(characterId int, stat string) (int, error) {
 	character, err := c.Get(characterId)
 	if err != nil {
@@ -477,7 +837,8 @@ func (c *CharacterModel) IncrementStat(characterId int, stat string) (int, error
 	var stmt string
 	switch stat {
 	case "TP":
-		stmt = "UPDATE character_stats SET tp=? WHERE character_id=?;"
+		// regaining hit points wakes the character up and ends dying
+		stmt = "UPDATE character_stats SET tp=?, unconscious=false, dying=false WHERE character_id=?;"
 		_, err := c.DB.Exec(stmt, character.Stats.TP+1, character.ID)
 		if err != nil {
 			return -1, err
@@ -557,6 +918,80 @@ func (c *CharacterModel) DecrementStat(characterId int, stat string) (int, error
 	return updated, nil
 }
 
+// lowers the hit points by the given damage, but never below 0, and records major wounds,
+// unconsciousness, dying and death as they result from the hit.
+func (c *CharacterModel) ApplyDamage(characterId, amount int) (core.Damage, error) {
+	tx, err := c.DB.Begin()
+	if err != nil {
+		return core.Damage{}, err
+	}
+	defer tx.Rollback()
+
+	stmt := "SELECT " + statsColumns + " FROM character_stats WHERE character_id=? FOR UPDATE;"
+	stats, err := scanStats(tx.QueryRow(stmt, characterId))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return core.Damage{}, ErrNoRecord
+		}
+		return core.Damage{}, err
+	}
+
+	damage := stats.TakeDamage(amount)
+	stats = stats.Apply(damage)
+	stmt = "UPDATE character_stats SET tp=?, major_wound=?, unconscious=?, dying=?, dead=? WHERE character_id=?;"
+	_, err = tx.Exec(stmt, stats.TP, stats.MajorWound, stats.Unconscious, stats.Dying, stats.Dead, characterId)
+	if err != nil {
+		return core.Damage{}, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return core.Damage{}, err
+	}
+	return damage, nil
+}
+
+// first aid and medicine: ends a major wound, and unconsciousness or dying once there are hit points again
+func (c *CharacterModel) TreatWounds(characterId int) error {
+	stmt := "UPDATE character_stats SET major_wound=false, unconscious=(unconscious AND tp=0), dying=(dying AND tp=0) WHERE character_id=?;"
+	_, err := c.DB.Exec(stmt, characterId)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// raises luck by the given amount, but never above its maximum. returns the new luck.
+func (c *CharacterModel) GainLuck(characterId, gain int) (int, error) {
+	tx, err := c.DB.Begin()
+	if err != nil {
+		return -1, err
+	}
+	defer tx.Rollback()
+
+	stmt := "UPDATE character_stats SET luck=LEAST(luck+?, maxluck) WHERE character_id=?;"
+	_, err = tx.Exec(stmt, gain, characterId)
+	if err != nil {
+		return -1, err
+	}
+
+	var luck int
+	stmt = "SELECT luck FROM character_stats WHERE character_id=?;"
+	err = tx.QueryRow(stmt, characterId).Scan(&luck)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return -1, ErrNoRecord
+		}
+		return -1, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return -1, err
+	}
+	return luck, nil
+}
+
 func DefaultForCategory(category string) int {
 	switch category {
 	case "Muttersprache":
@@ -0,0 +1,83 @@
+package models
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/winik100/NoPenNoPaper/internal/core"
+	"github.com/winik100/NoPenNoPaper/internal/testHelpers"
+)
+
+func TestBackstory(t *testing.T) {
+	db := newTestDB(t)
+
+	ch := CharacterModel{db}
+
+	characterId, err := ch.Insert(core.Character{Info: core.CharacterInfo{Name: "Otto Hightower"}}, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherId, err := ch.Insert(core.Character{Info: core.CharacterInfo{Name: "Viserys Targaryen"}}, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ideologyId, err := ch.AddBackstoryEntry(characterId, core.Ideology, "Das Haus Hightower muss herrschen.")
+	if err != nil {
+		t.Fatal(err)
+	}
+	daughterId, err := ch.AddBackstoryEntry(characterId, core.People, "Alicent, meine Tochter")
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherEntryId, err := ch.AddBackstoryEntry(otherId, core.Possessions, "Modell von Alt-Valyria")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = ch.SetKeyConnection(characterId, ideologyId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = ch.SetKeyConnection(characterId, daughterId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = ch.SetKeyConnection(characterId, otherEntryId)
+	testHelpers.Equal(t, errors.Is(err, ErrNoRecord), true)
+
+	character, err := ch.Get(characterId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testHelpers.Equal(t, len(character.Backstory), 2)
+	testHelpers.Equal(t, len(character.Backstory.Section(core.People)), 1)
+	keyConnection, ok := character.Backstory.KeyConnection()
+	testHelpers.Equal(t, ok, true)
+	testHelpers.Equal(t, keyConnection.ID, daughterId)
+	testHelpers.Equal(t, keyConnection.Text, "Alicent, meine Tochter")
+
+	// someone else's entry stays
+	err = ch.DeleteBackstoryEntry(characterId, otherEntryId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = ch.DeleteBackstoryEntry(characterId, daughterId)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	character, err = ch.Get(characterId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testHelpers.Equal(t, len(character.Backstory), 1)
+	_, ok = character.Backstory.KeyConnection()
+	testHelpers.Equal(t, ok, false)
+
+	other, err := ch.Get(otherId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testHelpers.Equal(t, len(other.Backstory), 1)
+}
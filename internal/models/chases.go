@@ -0,0 +1,175 @@
+package models
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/winik100/NoPenNoPaper/internal/core"
+)
+
+type ChaseModelInterface interface {
+	Start(chase core.Chase) error
+	Get(campaignId int) (core.Chase, error)
+	SetLocation(campaignId int, location core.Location) error
+	SaveProgress(chase core.Chase) error
+	End(campaignId int) error
+}
+
+type ChaseModel struct {
+	DB *sql.DB
+}
+
+// replaces a running chase of the campaign, participants outside of it are silently skipped
+func (c *ChaseModel) Start(chase core.Chase) error {
+	tx, err := c.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	stmt := "DELETE FROM chases WHERE campaign_id=?;"
+	_, err = tx.Exec(stmt, chase.CampaignID)
+	if err != nil {
+		return err
+	}
+
+	stmt = "INSERT INTO chases (campaign_id, round) VALUES (?, ?);"
+	_, err = tx.Exec(stmt, chase.CampaignID, chase.Round)
+	if err != nil {
+		return err
+	}
+
+	for _, chaser := range chase.Participants {
+		stmt = `INSERT INTO chase_participants (campaign_id, character_id, role, mov, position, actions)
+		SELECT ?, id, ?, ?, ?, ? FROM characters WHERE id=? AND campaign_id=?;`
+		_, err = tx.Exec(stmt, chase.CampaignID, chaser.Role, chaser.MOV, chaser.Position, chaser.Actions,
+			chaser.CharacterID, chase.CampaignID)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, location := range chase.Locations {
+		err = setLocation(tx, chase.CampaignID, location)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// participants are ordered by position, the furthest ahead first
+func (c *ChaseModel) Get(campaignId int) (core.Chase, error) {
+	chase := core.Chase{CampaignID: campaignId}
+
+	stmt := "SELECT round FROM chases WHERE campaign_id=?;"
+	err := c.DB.QueryRow(stmt, campaignId).Scan(&chase.Round)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return core.Chase{}, ErrNoRecord
+		}
+		return core.Chase{}, err
+	}
+
+	stmt = `SELECT cp.character_id, ci.name, c.kind, cp.role, cp.mov, cp.position, cp.actions
+	FROM chase_participants AS cp JOIN characters AS c ON cp.character_id = c.id
+	JOIN character_info AS ci ON cp.character_id = ci.character_id
+	WHERE cp.campaign_id=? ORDER BY cp.position DESC, cp.character_id;`
+	rows, err := c.DB.Query(stmt, campaignId)
+	if err != nil {
+		return core.Chase{}, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var chaser core.Chaser
+		var kind string
+		err = rows.Scan(&chaser.CharacterID, &chaser.Name, &kind, &chaser.Role, &chaser.MOV, &chaser.Position, &chaser.Actions)
+		if err != nil {
+			return core.Chase{}, err
+		}
+		chaser.IsNPC = kind == core.KindNPC
+		chase.Participants = append(chase.Participants, chaser)
+	}
+	if err = rows.Err(); err != nil {
+		return core.Chase{}, err
+	}
+
+	stmt = "SELECT position, name, obstacle, skill FROM chase_locations WHERE campaign_id=? ORDER BY position;"
+	rows, err = c.DB.Query(stmt, campaignId)
+	if err != nil {
+		return core.Chase{}, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var location core.Location
+		err = rows.Scan(&location.Position, &location.Name, &location.Obstacle, &location.Skill)
+		if err != nil {
+			return core.Chase{}, err
+		}
+		chase.Locations = append(chase.Locations, location)
+	}
+	if err = rows.Err(); err != nil {
+		return core.Chase{}, err
+	}
+	return chase, nil
+}
+
+// describes the location at the given position, replacing any earlier description
+func (c *ChaseModel) SetLocation(campaignId int, location core.Location) error {
+	return setLocation(c.DB, campaignId, location)
+}
+
+func setLocation(db execer, campaignId int, location core.Location) error {
+	stmt := `INSERT INTO chase_locations (campaign_id, position, name, obstacle, skill) VALUES (?, ?, ?, ?, ?)
+	ON DUPLICATE KEY UPDATE name=VALUES(name), obstacle=VALUES(obstacle), skill=VALUES(skill);`
+	_, err := db.Exec(stmt, campaignId, location.Position, location.Name, location.Obstacle, location.Skill)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// stores the round and everyone's position and remaining movement actions
+func (c *ChaseModel) SaveProgress(chase core.Chase) error {
+	tx, err := c.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	stmt := "UPDATE chases SET round=? WHERE campaign_id=?;"
+	_, err = tx.Exec(stmt, chase.Round, chase.CampaignID)
+	if err != nil {
+		return err
+	}
+
+	for _, chaser := range chase.Participants {
+		stmt = "UPDATE chase_participants SET position=?, actions=? WHERE campaign_id=? AND character_id=?;"
+		_, err = tx.Exec(stmt, chaser.Position, chaser.Actions, chase.CampaignID, chaser.CharacterID)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (c *ChaseModel) End(campaignId int) error {
+	stmt := "DELETE FROM chases WHERE campaign_id=?;"
+	_, err := c.DB.Exec(stmt, campaignId)
+	if err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,124 @@
+package models
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/winik100/NoPenNoPaper/internal/core"
+	"github.com/winik100/NoPenNoPaper/internal/testHelpers"
+)
+
+func TestChase(t *testing.T) {
+	db := newTestDB(t)
+
+	c := CampaignModel{db}
+	ch := CharacterModel{db}
+	cs := ChaseModel{db}
+
+	campaignId, err := c.Insert("Der Tanz der Drachen", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ottoId, err := ch.Insert(core.Character{
+		CampaignID: campaignId,
+		Info:       core.CharacterInfo{Name: "Otto Hightower"},
+	}, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	larysId, err := ch.Insert(core.Character{
+		CampaignID: campaignId,
+		Kind:       core.KindNPC,
+		Info:       core.CharacterInfo{Name: "Larys Strong"},
+	}, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	strangerId, err := ch.Insert(core.Character{Info: core.CharacterInfo{Name: "Daemon Targaryen"}}, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = cs.Get(campaignId)
+	testHelpers.Equal(t, errors.Is(err, ErrNoRecord), true)
+
+	chase := core.Chase{
+		CampaignID: campaignId,
+		Participants: []core.Chaser{
+			{CharacterID: ottoId, Role: core.Pursuer, MOV: 6},
+			{CharacterID: larysId, Role: core.Quarry, MOV: 8, Position: 2},
+			{CharacterID: strangerId, Role: core.Pursuer, MOV: 9},
+		},
+		Locations: []core.Location{{Position: 1, Name: "Fischmarkt", Obstacle: core.Hazard, Skill: core.Dodge}},
+	}
+	chase.NextRound()
+	err = cs.Start(chase)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	chase, err = cs.Get(campaignId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testHelpers.Equal(t, chase.Round, 1)
+	testHelpers.Equal(t, len(chase.Participants), 2)
+	testHelpers.Equal(t, chase.Participants[0].CharacterID, larysId)
+	testHelpers.Equal(t, chase.Participants[0].IsNPC, true)
+	testHelpers.Equal(t, chase.Participants[0].Role, core.Quarry)
+	testHelpers.Equal(t, chase.Participants[0].Actions, 3)
+	testHelpers.Equal(t, chase.Location(1).Obstacle, core.Hazard)
+	testHelpers.Equal(t, chase.Escapes(chase.Participants[0]), true)
+
+	// a successful roll gets otto past the hazard
+	roll := core.Roll{Level: core.Regular}
+	move, err := chase.Move(ottoId, &roll)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testHelpers.Equal(t, move.Moved, true)
+	testHelpers.Equal(t, move.Lost, 0)
+	_, err = chase.Move(ottoId, nil)
+	testHelpers.Equal(t, errors.Is(err, core.ErrNoMovementActions), true)
+
+	err = cs.SetLocation(campaignId, core.Location{Position: 1, Name: "Fischmarkt"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = cs.SetLocation(campaignId, core.Location{Position: 3, Name: "Stadttor", Obstacle: core.Barrier, Skill: "Klettern"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	chase.NextRound()
+	err = cs.SaveProgress(chase)
+	if err != nil {
+		t.Fatal(err)
+	}
+	chase, err = cs.Get(campaignId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testHelpers.Equal(t, chase.Round, 2)
+	testHelpers.Equal(t, chase.Participants[1].Position, 1)
+	testHelpers.Equal(t, chase.Participants[1].Actions, 1)
+	testHelpers.Equal(t, chase.Location(1).Obstacle, core.NoObstacle)
+	testHelpers.Equal(t, len(chase.Track()), 4)
+
+	// failing the barrier keeps larys in place
+	roll = core.Roll{Level: core.Failure}
+	move, err = chase.Move(larysId, &roll)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testHelpers.Equal(t, move.Moved, false)
+	testHelpers.Equal(t, move.Chaser.Position, 2)
+
+	err = cs.End(campaignId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = cs.Get(campaignId)
+	testHelpers.Equal(t, errors.Is(err, ErrNoRecord), true)
+}
@@ -0,0 +1,241 @@
+package models
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/winik100/NoPenNoPaper/internal/core"
+)
+
+type ChronicleModelInterface interface {
+	Insert(entry core.ChronicleEntry, characterIds, handoutIds, noteIds []int) (int, error)
+	Update(entry core.ChronicleEntry, characterIds, handoutIds, noteIds []int) error
+	Get(entryId int) (core.ChronicleEntry, error)
+	GetAll(campaignId int) ([]core.ChronicleEntry, error)
+	Delete(campaignId, entryId int) error
+}
+
+type ChronicleModel struct {
+	DB *sql.DB
+}
+
+func (c *ChronicleModel) Insert(entry core.ChronicleEntry, characterIds, handoutIds, noteIds []int) (int, error) {
+	tx, err := c.DB.Begin()
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	stmt := "INSERT INTO chronicle_entries (campaign_id, played_on, title, body) VALUES (?,?,?,?);"
+	res, err := tx.Exec(stmt, entry.CampaignID, entry.Date, entry.Title, entry.Body)
+	if err != nil {
+		return 0, err
+	}
+	entryId, err := res.LastInsertId()
+	if err != nil {
+		return 0, err
+	}
+
+	err = insertChronicleLinks(tx, int(entryId), entry.CampaignID, characterIds, handoutIds, noteIds)
+	if err != nil {
+		return 0, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return 0, err
+	}
+	return int(entryId), nil
+}
+
+// replaces the entry's contents and all of its links
+func (c *ChronicleModel) Update(entry core.ChronicleEntry, characterIds, handoutIds, noteIds []int) error {
+	tx, err := c.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	stmt := "UPDATE chronicle_entries SET played_on=?, title=?, body=? WHERE id=? AND campaign_id=?;"
+	res, err := tx.Exec(stmt, entry.Date, entry.Title, entry.Body, entry.ID, entry.CampaignID)
+	if err != nil {
+		return err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		// an unchanged entry also reports 0 affected rows
+		var exists bool
+		stmt = "SELECT EXISTS(SELECT true FROM chronicle_entries WHERE id=? AND campaign_id=?);"
+		err = tx.QueryRow(stmt, entry.ID, entry.CampaignID).Scan(&exists)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return ErrNoRecord
+		}
+	}
+
+	for _, table := range []string{"chronicle_characters", "chronicle_handouts", "chronicle_notes"} {
+		stmt = "DELETE FROM " + table + " WHERE entry_id=?;"
+		_, err = tx.Exec(stmt, entry.ID)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = insertChronicleLinks(tx, entry.ID, entry.CampaignID, characterIds, handoutIds, noteIds)
+	if err != nil {
+		return err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// links belonging to another campaign are silently skipped
+func insertChronicleLinks(tx *sql.Tx, entryId, campaignId int, characterIds, handoutIds, noteIds []int) error {
+	for _, characterId := range characterIds {
+		stmt := "INSERT INTO chronicle_characters (entry_id, character_id) SELECT ?, id FROM characters WHERE id=? AND campaign_id=?;"
+		_, err := tx.Exec(stmt, entryId, characterId, campaignId)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, handoutId := range handoutIds {
+		stmt := "INSERT INTO chronicle_handouts (entry_id, handout_id) SELECT ?, id FROM handouts WHERE id=? AND campaign_id=?;"
+		_, err := tx.Exec(stmt, entryId, handoutId, campaignId)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, noteId := range noteIds {
+		stmt := `INSERT INTO chronicle_notes (entry_id, note_id)
+		SELECT ?, n.note_id FROM notes AS n JOIN characters AS c ON n.character_id = c.id WHERE n.note_id=? AND c.campaign_id=?;`
+		_, err := tx.Exec(stmt, entryId, noteId, campaignId)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *ChronicleModel) Get(entryId int) (core.ChronicleEntry, error) {
+	var entry core.ChronicleEntry
+
+	stmt := "SELECT id, campaign_id, played_on, title, body FROM chronicle_entries WHERE id=?;"
+	err := c.DB.QueryRow(stmt, entryId).Scan(&entry.ID, &entry.CampaignID, &entry.Date, &entry.Title, &entry.Body)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return core.ChronicleEntry{}, ErrNoRecord
+		}
+		return core.ChronicleEntry{}, err
+	}
+
+	stmt = `SELECT ci.character_id, ci.name FROM chronicle_characters AS cc
+	JOIN character_info AS ci ON cc.character_id = ci.character_id WHERE cc.entry_id=? ORDER BY ci.name;`
+	rows, err := c.DB.Query(stmt, entryId)
+	if err != nil {
+		return core.ChronicleEntry{}, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var character core.Character
+		err = rows.Scan(&character.ID, &character.Info.Name)
+		if err != nil {
+			return core.ChronicleEntry{}, err
+		}
+		entry.Characters = append(entry.Characters, character)
+	}
+	if err = rows.Err(); err != nil {
+		return core.ChronicleEntry{}, err
+	}
+
+	stmt = `SELECT h.id, h.material_id, h.campaign_id, m.title, m.file_name, m.uploaded_by, h.revealed_to
+	FROM chronicle_handouts AS ch JOIN handouts AS h ON ch.handout_id = h.id JOIN materials AS m ON h.material_id = m.id
+	WHERE ch.entry_id=? ORDER BY h.id;`
+	rows, err = c.DB.Query(stmt, entryId)
+	if err != nil {
+		return core.ChronicleEntry{}, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var handout core.Handout
+		var revealedTo sql.NullInt64
+		err = rows.Scan(&handout.ID, &handout.MaterialID, &handout.CampaignID, &handout.Title, &handout.FileName, &handout.UploadedBy, &revealedTo)
+		if err != nil {
+			return core.ChronicleEntry{}, err
+		}
+		handout.RevealedTo = int(revealedTo.Int64)
+		entry.Handouts = append(entry.Handouts, handout)
+	}
+	if err = rows.Err(); err != nil {
+		return core.ChronicleEntry{}, err
+	}
+
+	stmt = `SELECT n.note_id, n.text FROM chronicle_notes AS cn JOIN notes AS n ON cn.note_id = n.note_id
+	WHERE cn.entry_id=? ORDER BY n.note_id;`
+	rows, err = c.DB.Query(stmt, entryId)
+	if err != nil {
+		return core.ChronicleEntry{}, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var noteId int
+		var text string
+		err = rows.Scan(&noteId, &text)
+		if err != nil {
+			return core.ChronicleEntry{}, err
+		}
+		entry.Notes.ID = append(entry.Notes.ID, noteId)
+		entry.Notes.Text = append(entry.Notes.Text, text)
+	}
+	if err = rows.Err(); err != nil {
+		return core.ChronicleEntry{}, err
+	}
+
+	return entry, nil
+}
+
+// entries of a campaign, newest first and without their links
+func (c *ChronicleModel) GetAll(campaignId int) ([]core.ChronicleEntry, error) {
+	stmt := "SELECT id, campaign_id, played_on, title, body FROM chronicle_entries WHERE campaign_id=? ORDER BY played_on DESC, id DESC;"
+	rows, err := c.DB.Query(stmt, campaignId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var entries []core.ChronicleEntry
+	for rows.Next() {
+		var entry core.ChronicleEntry
+		err = rows.Scan(&entry.ID, &entry.CampaignID, &entry.Date, &entry.Title, &entry.Body)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+func (c *ChronicleModel) Delete(campaignId, entryId int) error {
+	stmt := "DELETE FROM chronicle_entries WHERE id=? AND campaign_id=?;"
+	_, err := c.DB.Exec(stmt, entryId, campaignId)
+	if err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+package models
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/winik100/NoPenNoPaper/internal/core"
+	"github.com/winik100/NoPenNoPaper/internal/testHelpers"
+)
+
+func TestChronicle(t *testing.T) {
+	db := newTestDB(t)
+
+	c := CampaignModel{db}
+	ch := CharacterModel{db}
+	chr := ChronicleModel{db}
+
+	campaignId, err := c.Insert("Der Tanz der Drachen", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherCampaignId, err := c.Insert("Die Eroberung", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = c.AddMember(campaignId, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	characterId, err := ch.Insert(core.Character{Info: core.CharacterInfo{Name: "Otto Hightower"}}, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = c.AddCharacter(campaignId, characterId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	noteId, err := ch.AddNote(characterId, "Der König ist tot.")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	entry := core.ChronicleEntry{
+		CampaignID: campaignId,
+		Date:       time.Date(2024, 7, 12, 0, 0, 0, 0, time.UTC),
+		Title:      "Die Schlacht am Gottesauge",
+		Body:       "Die Drachen *kämpfen*.",
+	}
+	entryId, err := chr.Insert(entry, []int{characterId, 69}, nil, []int{noteId})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := chr.Get(entryId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testHelpers.Equal(t, got.Title, entry.Title)
+	testHelpers.Equal(t, got.Date.Equal(entry.Date), true)
+	testHelpers.Equal(t, len(got.Characters), 1)
+	testHelpers.Equal(t, got.Characters[0].Info.Name, "Otto Hightower")
+	testHelpers.Equal(t, got.HasNote(noteId), true)
+
+	entry.ID = entryId
+	entry.Title = "Tod am Gottesauge"
+	err = chr.Update(entry, nil, nil, nil)
+	testHelpers.NilError(t, err)
+
+	got, err = chr.Get(entryId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testHelpers.Equal(t, got.Title, "Tod am Gottesauge")
+	testHelpers.Equal(t, len(got.Characters), 0)
+	testHelpers.Equal(t, len(got.Notes.ID), 0)
+
+	entry.CampaignID = otherCampaignId
+	err = chr.Update(entry, nil, nil, nil)
+	testHelpers.Equal(t, errors.Is(err, ErrNoRecord), true)
+
+	entries, err := chr.GetAll(campaignId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testHelpers.Equal(t, len(entries), 1)
+
+	err = chr.Delete(campaignId, entryId)
+	testHelpers.NilError(t, err)
+
+	_, err = chr.Get(entryId)
+	testHelpers.Equal(t, errors.Is(err, ErrNoRecord), true)
+}
@@ -0,0 +1,168 @@
+package models
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/winik100/NoPenNoPaper/internal/core"
+)
+
+type CombatModelInterface interface {
+	Start(campaignId int, combatants []core.Combatant) error
+	Get(campaignId int) (core.Combat, error)
+	SetReadied(campaignId, characterId int, readied bool) error
+	SaveProgress(combat core.Combat) error
+	RecordDefense(campaignId, characterId, round int) error
+	End(campaignId int) error
+}
+
+type CombatModel struct {
+	DB *sql.DB
+}
+
+// replaces a running combat of the campaign, combatants outside of it are silently skipped
+func (c *CombatModel) Start(campaignId int, combatants []core.Combatant) error {
+	tx, err := c.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	stmt := "DELETE FROM combats WHERE campaign_id=?;"
+	_, err = tx.Exec(stmt, campaignId)
+	if err != nil {
+		return err
+	}
+
+	stmt = "INSERT INTO combats (campaign_id, round) VALUES (?, 1);"
+	_, err = tx.Exec(stmt, campaignId)
+	if err != nil {
+		return err
+	}
+
+	for _, combatant := range combatants {
+		stmt = `INSERT INTO combat_participants (campaign_id, character_id, readied_firearm, readied_next_round, tie_break)
+		SELECT ?, id, ?, ?, ? FROM characters WHERE id=? AND campaign_id=?;`
+		_, err = tx.Exec(stmt, campaignId, combatant.ReadiedFirearm, combatant.ReadiedFirearm, combatant.TieBreak, combatant.CharacterID, campaignId)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// participants are returned in initiative order, the first one starts if no turn was taken yet
+func (c *CombatModel) Get(campaignId int) (core.Combat, error) {
+	combat := core.Combat{CampaignID: campaignId}
+	var currentId, targetId sql.NullInt64
+
+	stmt := "SELECT round, current_id, target_id FROM combats WHERE campaign_id=?;"
+	err := c.DB.QueryRow(stmt, campaignId).Scan(&combat.Round, &currentId, &targetId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return core.Combat{}, ErrNoRecord
+		}
+		return core.Combat{}, err
+	}
+	combat.CurrentID = int(currentId.Int64)
+	combat.TargetID = int(targetId.Int64)
+
+	stmt = `SELECT cp.character_id, ci.name, c.kind, ca.ge, cp.readied_firearm, cp.readied_next_round, cp.tie_break, cp.defended_round, cs.tp, cs.maxtp
+	FROM combat_participants AS cp JOIN characters AS c ON cp.character_id = c.id
+	JOIN character_info AS ci ON cp.character_id = ci.character_id
+	JOIN character_attributes AS ca ON cp.character_id = ca.character_id
+	JOIN character_stats AS cs ON cp.character_id = cs.character_id
+	WHERE cp.campaign_id=?;`
+	rows, err := c.DB.Query(stmt, campaignId)
+	if err != nil {
+		return core.Combat{}, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var combatant core.Combatant
+		var kind string
+		err = rows.Scan(&combatant.CharacterID, &combatant.Name, &kind, &combatant.GE, &combatant.ReadiedFirearm,
+			&combatant.ReadiedNextRound, &combatant.TieBreak, &combatant.DefendedRound, &combatant.TP, &combatant.MaxTP)
+		if err != nil {
+			return core.Combat{}, err
+		}
+		combatant.IsNPC = kind == core.KindNPC
+		combat.Participants = append(combat.Participants, combatant)
+	}
+	if err = rows.Err(); err != nil {
+		return core.Combat{}, err
+	}
+
+	core.SortByInitiative(combat.Participants)
+	if _, ok := combat.Current(); !ok && len(combat.Participants) > 0 {
+		combat.CurrentID = combat.Participants[0].CharacterID
+	}
+	return combat, nil
+}
+
+// takes effect when the next round starts, the order of the current round stays as it is
+func (c *CombatModel) SetReadied(campaignId, characterId int, readied bool) error {
+	stmt := "UPDATE combat_participants SET readied_next_round=? WHERE campaign_id=? AND character_id=?;"
+	_, err := c.DB.Exec(stmt, readied, campaignId, characterId)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// stores round, current combatant and target as well as the firearms readied for the round
+func (c *CombatModel) SaveProgress(combat core.Combat) error {
+	currentId := sql.NullInt64{Int64: int64(combat.CurrentID), Valid: combat.CurrentID != 0}
+	targetId := sql.NullInt64{Int64: int64(combat.TargetID), Valid: combat.TargetID != 0}
+
+	tx, err := c.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	stmt := "UPDATE combats SET round=?, current_id=?, target_id=? WHERE campaign_id=?;"
+	_, err = tx.Exec(stmt, combat.Round, currentId, targetId, combat.CampaignID)
+	if err != nil {
+		return err
+	}
+
+	for _, combatant := range combat.Participants {
+		stmt = "UPDATE combat_participants SET readied_firearm=? WHERE campaign_id=? AND character_id=?;"
+		_, err = tx.Exec(stmt, combatant.ReadiedFirearm, combat.CampaignID, combatant.CharacterID)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// marks the combatant as having dodged or fought back in the given round
+func (c *CombatModel) RecordDefense(campaignId, characterId, round int) error {
+	stmt := "UPDATE combat_participants SET defended_round=? WHERE campaign_id=? AND character_id=?;"
+	_, err := c.DB.Exec(stmt, round, campaignId, characterId)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (c *CombatModel) End(campaignId int) error {
+	stmt := "DELETE FROM combats WHERE campaign_id=?;"
+	_, err := c.DB.Exec(stmt, campaignId)
+	if err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,170 @@
+package models
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/winik100/NoPenNoPaper/internal/core"
+	"github.com/winik100/NoPenNoPaper/internal/testHelpers"
+)
+
+func TestCombat(t *testing.T) {
+	db := newTestDB(t)
+
+	c := CampaignModel{db}
+	ch := CharacterModel{db}
+	co := CombatModel{db}
+
+	campaignId, err := c.Insert("Der Tanz der Drachen", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ottoId, err := ch.Insert(core.Character{
+		CampaignID: campaignId,
+		Info:       core.CharacterInfo{Name: "Otto Hightower"},
+		Attributes: core.CharacterAttributes{GE: 50, KO: 50, GR: 60},
+	}, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	larysId, err := ch.Insert(core.Character{
+		CampaignID: campaignId,
+		Kind:       core.KindNPC,
+		Info:       core.CharacterInfo{Name: "Larys Strong"},
+		Attributes: core.CharacterAttributes{GE: 70},
+	}, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	strangerId, err := ch.Insert(core.Character{Info: core.CharacterInfo{Name: "Daemon Targaryen"}}, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = co.Get(campaignId)
+	testHelpers.Equal(t, errors.Is(err, ErrNoRecord), true)
+
+	err = co.Start(campaignId, []core.Combatant{
+		{CharacterID: ottoId, TieBreak: 20},
+		{CharacterID: larysId, TieBreak: 80},
+		{CharacterID: strangerId, TieBreak: 50},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	combat, err := co.Get(campaignId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testHelpers.Equal(t, len(combat.Participants), 2)
+	testHelpers.Equal(t, combat.Round, 1)
+	testHelpers.Equal(t, combat.CurrentID, larysId)
+	testHelpers.Equal(t, combat.Participants[1].IsNPC, false)
+
+	combat.Advance()
+	testHelpers.Equal(t, combat.CurrentID, ottoId)
+	err = co.SaveProgress(combat)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// a firearm readied mid-round leaves the order of the round alone
+	err = co.SetReadied(campaignId, ottoId, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	combat, err = co.Get(campaignId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testHelpers.Equal(t, combat.Participants[0].CharacterID, larysId)
+	testHelpers.Equal(t, combat.Participants[1].ReadiedNextRound, true)
+	testHelpers.Equal(t, combat.CurrentID, ottoId)
+
+	// and moves otto ahead once the next round starts
+	combat.Advance()
+	testHelpers.Equal(t, combat.Round, 2)
+	testHelpers.Equal(t, combat.CurrentID, ottoId)
+	testHelpers.Equal(t, combat.Participants[0].Initiative(), 100)
+	combat.Advance()
+	testHelpers.Equal(t, combat.CurrentID, larysId)
+
+	combat.TargetID = ottoId
+	err = co.SaveProgress(combat)
+	if err != nil {
+		t.Fatal(err)
+	}
+	combat, err = co.Get(campaignId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testHelpers.Equal(t, combat.Round, 2)
+	testHelpers.Equal(t, combat.TargetID, ottoId)
+	testHelpers.Equal(t, combat.Participants[0].CharacterID, ottoId)
+
+	// dodging once leaves otto outnumbered for the rest of the round
+	testHelpers.Equal(t, combat.HasDefended(ottoId), false)
+	err = co.RecordDefense(campaignId, ottoId, combat.Round)
+	if err != nil {
+		t.Fatal(err)
+	}
+	combat, err = co.Get(campaignId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testHelpers.Equal(t, combat.HasDefended(ottoId), true)
+	testHelpers.Equal(t, combat.HasDefended(larysId), false)
+	combat.Advance()
+	combat.Advance()
+	testHelpers.Equal(t, combat.HasDefended(ottoId), false)
+
+	damage, err := ch.ApplyDamage(ottoId, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testHelpers.Equal(t, damage.TP, 7)
+	testHelpers.Equal(t, damage.MajorWound, false)
+	damage, err = ch.ApplyDamage(ottoId, 6)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testHelpers.Equal(t, damage.TP, 1)
+	testHelpers.Equal(t, damage.MajorWound, true)
+	damage, err = ch.ApplyDamage(ottoId, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testHelpers.Equal(t, damage.Dying, true)
+
+	err = ch.TreatWounds(ottoId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	otto, err := ch.Get(ottoId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testHelpers.Equal(t, otto.Stats.MajorWound, false)
+	testHelpers.Equal(t, otto.Stats.Dying, true)
+
+	damage, err = ch.ApplyDamage(ottoId, 20)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testHelpers.Equal(t, damage.TP, 0)
+	otto, err = ch.Get(ottoId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testHelpers.Equal(t, otto.Stats.Dead, true)
+	testHelpers.Equal(t, otto.Stats.Condition(), "Tot")
+
+	err = co.End(campaignId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = co.Get(campaignId)
+	testHelpers.Equal(t, errors.Is(err, ErrNoRecord), true)
+}
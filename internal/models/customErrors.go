@@ -11,3 +11,25 @@ var ErrAlreadyHasSkill = errors.New("models: character already has that custom s
 var ErrDuplicateFileName = errors.New("models: file of that name already exists")
 
 var ErrNameTaken = errors.New("models: a user with that name already exists")
+
+var ErrAlreadyMember = errors.New("models: user is already a member of that campaign")
+
+var ErrNotAMember = errors.New("models: user is not a member of that campaign")
+
+var ErrInvalidInvite = errors.New("models: invite does not exist, has expired or was already used")
+
+var ErrNotEnoughLuck = errors.New("models: character does not have enough luck left")
+
+var ErrLuckNotAllowed = errors.New("models: luck can not be spent on that roll")
+
+var ErrPushNotAllowed = errors.New("models: that roll can not be pushed")
+
+var ErrAlreadyCarried = errors.New("models: character already carries that weapon")
+
+var ErrAlreadyKnown = errors.New("models: character already knows that spell")
+
+var ErrNotEnoughMagic = errors.New("models: character does not have enough magic points left")
+
+var ErrAlreadyOwned = errors.New("models: character already owns that tome")
+
+var ErrAlreadyStudied = errors.New("models: character already studied that tome")
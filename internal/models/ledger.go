@@ -0,0 +1,100 @@
+package models
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/winik100/NoPenNoPaper/internal/core"
+)
+
+type LedgerModelInterface interface {
+	Insert(entry core.LedgerEntry, opening core.Money) (int, error)
+	GetOpening(characterId int) (core.Money, error)
+	GetAll(characterId int) ([]core.LedgerEntry, error)
+	Delete(characterId, entryId int) error
+}
+
+type LedgerModel struct {
+	DB *sql.DB
+}
+
+// the first entry of a character also records the opening cash the ledger starts from. it is kept from
+// then on, so raising the credit rating or changing the era doesn't rewrite past balances.
+func (l *LedgerModel) Insert(entry core.LedgerEntry, opening core.Money) (int, error) {
+	tx, err := l.DB.Begin()
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	stmt := "INSERT IGNORE INTO cash_openings (character_id, amount) VALUES (?,?);"
+	_, err = tx.Exec(stmt, entry.CharacterID, opening)
+	if err != nil {
+		return 0, err
+	}
+
+	stmt = "INSERT INTO cash_ledger (character_id, description, amount, booked_at) VALUES (?,?,?,?);"
+	res, err := tx.Exec(stmt, entry.CharacterID, entry.Description, entry.Amount, entry.BookedAt.UTC())
+	if err != nil {
+		return 0, err
+	}
+	id, err := res.LastInsertId()
+	if err != nil {
+		return 0, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return 0, err
+	}
+	return int(id), nil
+}
+
+// the cash the character's ledger started from, ErrNoRecord if nothing was booked yet
+func (l *LedgerModel) GetOpening(characterId int) (core.Money, error) {
+	var opening core.Money
+	stmt := "SELECT amount FROM cash_openings WHERE character_id=?;"
+	err := l.DB.QueryRow(stmt, characterId).Scan(&opening)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, ErrNoRecord
+		}
+		return 0, err
+	}
+	return opening, nil
+}
+
+// every entry of the character's ledger, oldest first
+func (l *LedgerModel) GetAll(characterId int) ([]core.LedgerEntry, error) {
+	stmt := `SELECT id, character_id, description, amount, booked_at FROM cash_ledger
+	WHERE character_id=? ORDER BY booked_at, id;`
+	rows, err := l.DB.Query(stmt, characterId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var entries []core.LedgerEntry
+	for rows.Next() {
+		var entry core.LedgerEntry
+		err = rows.Scan(&entry.ID, &entry.CharacterID, &entry.Description, &entry.Amount, &entry.BookedAt)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// entries of other characters are left alone
+func (l *LedgerModel) Delete(characterId, entryId int) error {
+	stmt := "DELETE FROM cash_ledger WHERE id=? AND character_id=?;"
+	_, err := l.DB.Exec(stmt, entryId, characterId)
+	if err != nil {
+		return err
+	}
+	return nil
+}
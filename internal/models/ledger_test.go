@@ -0,0 +1,99 @@
+package models
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/winik100/NoPenNoPaper/internal/core"
+	"github.com/winik100/NoPenNoPaper/internal/testHelpers"
+)
+
+func TestLedger(t *testing.T) {
+	db := newTestDB(t)
+
+	ch := CharacterModel{db}
+	l := LedgerModel{db}
+
+	// 30 credit rating: average, 60 $ cash in the 1920s
+	characterId, err := ch.Insert(core.Character{
+		Info:   core.CharacterInfo{Name: "Otto Hightower"},
+		Skills: core.Skills{Name: []string{core.CreditRating}, Value: []int{30}},
+	}, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherId, err := ch.Insert(core.Character{Info: core.CharacterInfo{Name: "Viserys Targaryen"}}, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bookedAt := time.Date(1925, 3, 2, 12, 0, 0, 0, time.UTC)
+	entries := []core.LedgerEntry{
+		{CharacterID: characterId, Description: "Zugfahrt nach Arkham", Amount: -450, BookedAt: bookedAt},
+		{CharacterID: characterId, Description: "Honorar", Amount: 2500, BookedAt: bookedAt.Add(time.Hour)},
+		{CharacterID: otherId, Description: "Krone", Amount: -100000, BookedAt: bookedAt},
+	}
+	_, err = l.GetOpening(characterId)
+	testHelpers.Equal(t, errors.Is(err, ErrNoRecord), true)
+
+	character, err := ch.Get(characterId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// a later credit rating doesn't move the opening cash recorded with the first entry
+	openings := []core.Money{character.Wealth(core.Classic).Cash, 99900, 100}
+	var ids []int
+	for i, entry := range entries {
+		id, err := l.Insert(entry, openings[i])
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, id)
+	}
+
+	opening, err := l.GetOpening(characterId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testHelpers.Equal(t, opening, core.Money(6000))
+	otherOpening, err := l.GetOpening(otherId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testHelpers.Equal(t, otherOpening, core.Money(100))
+
+	ledger, err := l.GetAll(characterId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testHelpers.Equal(t, len(ledger), 2)
+	testHelpers.Equal(t, ledger[0].Description, "Zugfahrt nach Arkham")
+	testHelpers.Equal(t, ledger[0].IsPurchase(), true)
+
+	balance := core.RunningBalance(opening, ledger)
+	testHelpers.Equal(t, ledger[0].Balance, core.Money(5550))
+	testHelpers.Equal(t, balance, core.Money(8050))
+	testHelpers.Equal(t, balance.String(), "80,50 $")
+
+	// someone else's entry stays
+	err = l.Delete(characterId, ids[2])
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = l.Delete(characterId, ids[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ledger, err = l.GetAll(characterId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testHelpers.Equal(t, len(ledger), 1)
+	ledger, err = l.GetAll(otherId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testHelpers.Equal(t, len(ledger), 1)
+}
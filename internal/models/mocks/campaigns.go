@@ -0,0 +1,154 @@
+package mocks
+
+import (
+	"time"
+
+	"github.com/winik100/NoPenNoPaper/internal/core"
+	"github.com/winik100/NoPenNoPaper/internal/models"
+)
+
+var MockCampaign = core.Campaign{
+	ID:        1,
+	Title:     "Der Tanz der Drachen",
+	CreatedBy: MockGM.ID,
+	Era:       core.Classic,
+	Members:   []core.User{{ID: MockPlayer.ID, Name: MockPlayer.Name}},
+	Table:     []int{1},
+}
+
+var MockInvite = core.Invite{
+	Code:          "drachenlied",
+	CampaignID:    1,
+	CampaignTitle: "Der Tanz der Drachen",
+}
+
+var MockExpiredInvite = core.Invite{
+	Code:          "abgelaufen",
+	CampaignID:    1,
+	CampaignTitle: "Der Tanz der Drachen",
+	Expires:       time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+}
+
+var MockHandout = core.Handout{
+	ID:         1,
+	MaterialID: 1,
+	CampaignID: 1,
+	Title:      "Karte von Westeros",
+	FileName:   "westeros.png",
+	UploadedBy: MockGM.ID,
+}
+
+type CampaignModel struct{}
+
+func (m *CampaignModel) Insert(title string, createdBy int) (int, error) {
+	return 1, nil
+}
+
+func (m *CampaignModel) Get(campaignId int) (core.Campaign, error) {
+	if campaignId == 1 {
+		return MockCampaign, nil
+	}
+	return core.Campaign{}, models.ErrNoRecord
+}
+
+func (m *CampaignModel) SetEra(campaignId int, era core.Era) error {
+	return nil
+}
+
+func (m *CampaignModel) GetAllFrom(userId int) ([]core.Campaign, error) {
+	if userId == MockGM.ID {
+		return []core.Campaign{MockCampaign}, nil
+	}
+	return nil, nil
+}
+
+func (m *CampaignModel) GetAllFor(userId int) ([]core.Campaign, error) {
+	if userId == MockPlayer.ID {
+		return []core.Campaign{MockCampaign}, nil
+	}
+	return nil, nil
+}
+
+func (m *CampaignModel) Delete(campaignId int) error {
+	return nil
+}
+
+func (m *CampaignModel) AddMember(campaignId, userId int) error {
+	if userId == MockPlayer.ID {
+		return models.ErrAlreadyMember
+	}
+	return nil
+}
+
+func (m *CampaignModel) RemoveMember(campaignId, userId int) error {
+	return nil
+}
+
+func (m *CampaignModel) AddCharacter(campaignId, characterId int) error {
+	return nil
+}
+
+func (m *CampaignModel) RemoveCharacter(campaignId, characterId int) error {
+	return nil
+}
+
+func (m *CampaignModel) CreateInvite(campaignId int, expires time.Time, singleUse bool) (string, error) {
+	return MockInvite.Code, nil
+}
+
+func (m *CampaignModel) GetInvite(code string) (core.Invite, error) {
+	switch code {
+	case MockInvite.Code:
+		return MockInvite, nil
+	case MockExpiredInvite.Code:
+		return MockExpiredInvite, nil
+	}
+	return core.Invite{}, models.ErrNoRecord
+}
+
+func (m *CampaignModel) GetInvites(campaignId int) ([]core.Invite, error) {
+	if campaignId == 1 {
+		return []core.Invite{MockInvite, MockExpiredInvite}, nil
+	}
+	return nil, nil
+}
+
+func (m *CampaignModel) RevokeInvite(campaignId int, code string) error {
+	return nil
+}
+
+func (m *CampaignModel) Join(code string, userId int, characterIds []int) (int, error) {
+	if code == MockInvite.Code {
+		return MockInvite.CampaignID, nil
+	}
+	return 0, models.ErrInvalidInvite
+}
+
+func (m *CampaignModel) SetTable(campaignId int, characterIds []int) error {
+	return nil
+}
+
+func (m *CampaignModel) RevealHandout(campaignId, materialId, userId int) error {
+	if userId != 0 && userId != MockPlayer.ID {
+		return models.ErrNotAMember
+	}
+	return nil
+}
+
+func (m *CampaignModel) HideHandout(campaignId, handoutId int) error {
+	return nil
+}
+
+func (m *CampaignModel) GetHandouts(campaignId int) ([]core.Handout, error) {
+	if campaignId == MockCampaign.ID {
+		return []core.Handout{MockHandout}, nil
+	}
+	return nil, nil
+}
+
+func (m *CampaignModel) GetHandoutsFor(userId int) ([]core.Handout, error) {
+	if userId == MockPlayer.ID {
+		return []core.Handout{MockHandout}, nil
+	}
+	return nil, nil
+}
@@ -7,17 +7,39 @@ import (
 
 var MockCharacterOtto = core.Character{
 	ID:           1,
+	CreatedBy:    1,
+	CampaignID:   1,
 	Info:         mockInfo,
+	Backstory:    mockBackstory,
 	Attributes:   mockAttributes,
+	Stats:        mockStats,
 	Skills:       mockSkills,
 	CustomSkills: mockCustomSkills,
+	Ticks:        []string{"Intrige"},
 	Items:        mockItems,
+	Weapons:      []core.Weapon{MockWeaponDagger},
+	Spells:       []core.Spell{MockSpellDominate, MockSpellStarVampire},
+	Tomes:        []core.Tome{MockTomeNecronomicon, mockTomePnakoticStudied},
 	Notes:        mockNotes,
 }
 
 var MockCharacterViserys = core.Character{
-	ID:   2,
-	Info: mockInfo2,
+	ID:         2,
+	CreatedBy:  1,
+	CampaignID: 1,
+	Info:       mockInfo2,
+}
+
+var MockNPC = core.Character{
+	ID:         3,
+	CreatedBy:  2,
+	CampaignID: 1,
+	Kind:       core.KindNPC,
+	TrackTP:    true,
+	TrackSTA:   false,
+	Info:       core.CharacterInfo{Name: "Larys Strong", Profession: "Meister der Flüsterer", Age: "0"},
+	Attributes: core.CharacterAttributes{ST: 45, GE: 55, MA: 75, KO: 50, ER: 40, BI: 80, GR: 55, IN: 90, BW: 7, DB: "0", Build: 0},
+	Stats:      core.CharacterStats{MaxTP: 10, TP: 10, MaxSTA: 75, STA: 75, MaxMP: 15, MP: 15, MaxLUCK: 50, LUCK: 50},
 }
 
 var mockInfo = core.CharacterInfo{
@@ -29,6 +51,12 @@ var mockInfo = core.CharacterInfo{
 	Birthplace: "Oldtown",
 }
 
+var mockBackstory = core.Backstory{
+	{ID: 1, Section: core.Ideology, Text: "Das Haus Hightower muss herrschen."},
+	{ID: 2, Section: core.People, Text: "Alicent, meine Tochter", KeyConnection: true},
+	{ID: 3, Section: core.Injuries, Text: "Gichtige Knie"},
+}
+
 var mockInfo2 = core.CharacterInfo{
 	Name:       "Viserys Targaryen",
 	Profession: "König von Westeros",
@@ -39,17 +67,21 @@ var mockInfo2 = core.CharacterInfo{
 }
 
 var mockAttributes = core.CharacterAttributes{
-	ST: 40,
-	GE: 50,
-	MA: 50,
-	KO: 50,
-	ER: 70,
-	BI: 60,
-	GR: 60,
-	IN: 80,
-	BW: 6,
+	ST:    40,
+	GE:    50,
+	MA:    50,
+	KO:    50,
+	ER:    70,
+	BI:    60,
+	GR:    60,
+	IN:    80,
+	BW:    4,
+	DB:    "0",
+	Build: 0,
 }
 
+var mockStats = core.CharacterStats{MaxTP: 11, TP: 11, MaxSTA: 50, STA: 50, MaxMP: 10, MP: 10, MaxLUCK: 55, LUCK: 40}
+
 var mockSkills = core.Skills{
 	Name:  []string{"Politik", "Intrige", "Manipulation"},
 	Value: []int{70, 60, 60},
@@ -80,6 +112,9 @@ func (m *CharacterModel) Get(characterId int) (core.Character, error) {
 	if characterId == 2 {
 		return MockCharacterViserys, nil
 	}
+	if characterId == MockNPC.ID {
+		return MockNPC, nil
+	}
 	return core.Character{}, models.ErrNoRecord
 }
 
@@ -107,6 +142,27 @@ func (m *CharacterModel) GetAll() ([]core.Character, error) {
 	return []core.Character{MockCharacterOtto, MockCharacterViserys}, nil
 }
 
+func (m *CharacterModel) GetAllInCampaign(campaignId int) ([]core.Character, error) {
+	if campaignId == 1 {
+		return []core.Character{MockCharacterOtto, MockCharacterViserys}, nil
+	}
+	return nil, nil
+}
+
+func (m *CharacterModel) GetNPCsFrom(userId int) ([]core.Character, error) {
+	if userId == MockGM.ID {
+		return []core.Character{MockNPC}, nil
+	}
+	return nil, nil
+}
+
+func (m *CharacterModel) GetNPCsInCampaign(campaignId int) ([]core.Character, error) {
+	if campaignId == MockNPC.CampaignID {
+		return []core.Character{MockNPC}, nil
+	}
+	return nil, nil
+}
+
 func (m *CharacterModel) GetAvailableSkills() (core.Skills, error) {
 	skills := core.Skills{Name: []string{"Politik", "Intrige", "Manipulation", "Schwertkampf", "Singen", "Tanzen"},
 		Value: []int{10, 5, 5, 10, 20, 20}}
@@ -161,6 +217,23 @@ func (m *CharacterModel) DeleteNote(noteId int) error {
 	return nil
 }
 
+func (m *CharacterModel) AddBackstoryEntry(characterId int, section core.BackstorySection, text string) (int, error) {
+	return 4, nil
+}
+
+func (m *CharacterModel) DeleteBackstoryEntry(characterId, entryId int) error {
+	return nil
+}
+
+func (m *CharacterModel) SetKeyConnection(characterId, entryId int) error {
+	if characterId == MockCharacterOtto.ID {
+		if _, ok := MockCharacterOtto.Backstory.Entry(entryId); ok {
+			return nil
+		}
+	}
+	return models.ErrNoRecord
+}
+
 func (m *CharacterModel) IncrementStat(characterId int, stat string) (int, error) {
 	character := MockCharacterOtto
 
@@ -202,3 +275,46 @@ func (m *CharacterModel) DecrementStat(characterId int, stat string) (int, error
 	}
 	return updated, nil
 }
+
+func (m *CharacterModel) ApplyDamage(characterId, amount int) (core.Damage, error) {
+	character, err := m.Get(characterId)
+	if err != nil {
+		return core.Damage{}, err
+	}
+	return character.Stats.TakeDamage(amount), nil
+}
+
+func (m *CharacterModel) TreatWounds(characterId int) error {
+	return nil
+}
+
+func (m *CharacterModel) GainLuck(characterId, gain int) (int, error) {
+	return min(MockCharacterOtto.Stats.LUCK+gain, MockCharacterOtto.Stats.MaxLUCK), nil
+}
+
+func (m *CharacterModel) SetSkillTick(characterId int, skill string, used bool) error {
+	if characterId != MockCharacterOtto.ID || !MockCharacterOtto.HasSkill(skill) {
+		return models.ErrNoRecord
+	}
+	return nil
+}
+
+func (m *CharacterModel) Develop(characterId int, improvements []core.Improvement) error {
+	return nil
+}
+
+func (m *CharacterModel) CapSanity(characterId, maxSanity int) error {
+	return nil
+}
+
+func (m *CharacterModel) EditAttribute(characterId int, attribute string, value int) (core.CharacterAttributes, error) {
+	character, err := m.Get(characterId)
+	if err != nil {
+		return core.CharacterAttributes{}, err
+	}
+	err = character.Attributes.Set(attribute, value)
+	if err != nil {
+		return core.CharacterAttributes{}, err
+	}
+	return character.DeriveAttributes(), nil
+}
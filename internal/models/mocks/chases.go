@@ -0,0 +1,50 @@
+package mocks
+
+import (
+	"slices"
+
+	"github.com/winik100/NoPenNoPaper/internal/core"
+	"github.com/winik100/NoPenNoPaper/internal/models"
+)
+
+var MockChase = core.Chase{
+	CampaignID: 1,
+	Round:      1,
+	Participants: []core.Chaser{
+		{CharacterID: 3, Name: "Larys Strong", IsNPC: true, Role: core.Quarry, MOV: 7, Position: 2, Actions: 3},
+		{CharacterID: 1, Name: "Otto Hightower", Role: core.Pursuer, MOV: 5, Position: 0, Actions: 1},
+		{CharacterID: 2, Name: "Viserys Targaryen", Role: core.Pursuer, MOV: 7, Position: 1, Actions: 0},
+	},
+	Locations: []core.Location{
+		{Position: 1, Name: "Fischmarkt", Obstacle: core.Hazard, Skill: core.Dodge},
+		{Position: 3, Name: "Stadttor", Obstacle: core.Barrier, Skill: "Intrige"},
+	},
+}
+
+type ChaseModel struct{}
+
+func (m *ChaseModel) Start(chase core.Chase) error {
+	return nil
+}
+
+func (m *ChaseModel) Get(campaignId int) (core.Chase, error) {
+	if campaignId == MockChase.CampaignID {
+		// moves change the participants in place
+		chase := MockChase
+		chase.Participants = slices.Clone(MockChase.Participants)
+		return chase, nil
+	}
+	return core.Chase{}, models.ErrNoRecord
+}
+
+func (m *ChaseModel) SetLocation(campaignId int, location core.Location) error {
+	return nil
+}
+
+func (m *ChaseModel) SaveProgress(chase core.Chase) error {
+	return nil
+}
+
+func (m *ChaseModel) End(campaignId int) error {
+	return nil
+}
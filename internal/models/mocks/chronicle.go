@@ -0,0 +1,49 @@
+package mocks
+
+import (
+	"time"
+
+	"github.com/winik100/NoPenNoPaper/internal/core"
+	"github.com/winik100/NoPenNoPaper/internal/models"
+)
+
+var MockChronicleEntry = core.ChronicleEntry{
+	ID:         1,
+	CampaignID: 1,
+	Date:       time.Date(2024, 7, 12, 0, 0, 0, 0, time.UTC),
+	Title:      "Die Schlacht am Gottesauge",
+	Body:       "Die Drachen **kämpfen** über dem See.",
+	Characters: []core.Character{{ID: 1, Info: core.CharacterInfo{Name: "Otto Hightower"}}},
+	Handouts:   []core.Handout{MockHandout},
+}
+
+type ChronicleModel struct{}
+
+func (m *ChronicleModel) Insert(entry core.ChronicleEntry, characterIds, handoutIds, noteIds []int) (int, error) {
+	return 2, nil
+}
+
+func (m *ChronicleModel) Update(entry core.ChronicleEntry, characterIds, handoutIds, noteIds []int) error {
+	if entry.ID != MockChronicleEntry.ID || entry.CampaignID != MockChronicleEntry.CampaignID {
+		return models.ErrNoRecord
+	}
+	return nil
+}
+
+func (m *ChronicleModel) Get(entryId int) (core.ChronicleEntry, error) {
+	if entryId == MockChronicleEntry.ID {
+		return MockChronicleEntry, nil
+	}
+	return core.ChronicleEntry{}, models.ErrNoRecord
+}
+
+func (m *ChronicleModel) GetAll(campaignId int) ([]core.ChronicleEntry, error) {
+	if campaignId == MockChronicleEntry.CampaignID {
+		return []core.ChronicleEntry{MockChronicleEntry}, nil
+	}
+	return nil, nil
+}
+
+func (m *ChronicleModel) Delete(campaignId, entryId int) error {
+	return nil
+}
@@ -0,0 +1,46 @@
+package mocks
+
+import (
+	"github.com/winik100/NoPenNoPaper/internal/core"
+	"github.com/winik100/NoPenNoPaper/internal/models"
+)
+
+var MockCombat = core.Combat{
+	CampaignID: 1,
+	Round:      2,
+	CurrentID:  3,
+	TargetID:   1,
+	Participants: []core.Combatant{
+		{CharacterID: 3, Name: "Larys Strong", IsNPC: true, GE: 55, TieBreak: 12, TP: 10, MaxTP: 10},
+		{CharacterID: 1, Name: "Otto Hightower", GE: 50, TieBreak: 87, DefendedRound: 2, TP: 10, MaxTP: 11},
+	},
+}
+
+type CombatModel struct{}
+
+func (m *CombatModel) Start(campaignId int, combatants []core.Combatant) error {
+	return nil
+}
+
+func (m *CombatModel) Get(campaignId int) (core.Combat, error) {
+	if campaignId == MockCombat.CampaignID {
+		return MockCombat, nil
+	}
+	return core.Combat{}, models.ErrNoRecord
+}
+
+func (m *CombatModel) SetReadied(campaignId, characterId int, readied bool) error {
+	return nil
+}
+
+func (m *CombatModel) SaveProgress(combat core.Combat) error {
+	return nil
+}
+
+func (m *CombatModel) RecordDefense(campaignId, characterId, round int) error {
+	return nil
+}
+
+func (m *CombatModel) End(campaignId int) error {
+	return nil
+}
@@ -0,0 +1,51 @@
+package mocks
+
+import (
+	"time"
+
+	"github.com/winik100/NoPenNoPaper/internal/core"
+	"github.com/winik100/NoPenNoPaper/internal/models"
+)
+
+var MockLedgerIncome = core.LedgerEntry{
+	ID:          1,
+	CharacterID: 1,
+	Description: "Pacht aus Oldtown",
+	Amount:      1000,
+	BookedAt:    time.Date(2024, 7, 12, 20, 0, 0, 0, time.UTC),
+}
+
+var MockLedgerPurchase = core.LedgerEntry{
+	ID:          2,
+	CharacterID: 1,
+	Description: "Arbor-Gold",
+	Amount:      -250,
+	BookedAt:    time.Date(2024, 7, 12, 21, 0, 0, 0, time.UTC),
+}
+
+// recorded back when Otto's credit rating was still counted
+const MockLedgerOpening core.Money = 1500
+
+type LedgerModel struct{}
+
+func (m *LedgerModel) Insert(entry core.LedgerEntry, opening core.Money) (int, error) {
+	return 3, nil
+}
+
+func (m *LedgerModel) GetOpening(characterId int) (core.Money, error) {
+	if characterId == MockCharacterOtto.ID {
+		return MockLedgerOpening, nil
+	}
+	return 0, models.ErrNoRecord
+}
+
+func (m *LedgerModel) GetAll(characterId int) ([]core.LedgerEntry, error) {
+	if characterId == MockCharacterOtto.ID {
+		return []core.LedgerEntry{MockLedgerIncome, MockLedgerPurchase}, nil
+	}
+	return nil, nil
+}
+
+func (m *LedgerModel) Delete(characterId, entryId int) error {
+	return nil
+}
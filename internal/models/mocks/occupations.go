@@ -0,0 +1,27 @@
+package mocks
+
+import (
+	"github.com/winik100/NoPenNoPaper/internal/core"
+	"github.com/winik100/NoPenNoPaper/internal/models"
+)
+
+var MockOccupation = core.Occupation{
+	ID:          1,
+	Name:        "Lord",
+	SkillPoints: "BI×4",
+	Skills:      []string{"Politik", "Intrige", "Manipulation", "Muttersprache"},
+	Credit:      core.CreditRange{Min: 0, Max: 99},
+}
+
+type OccupationModel struct{}
+
+func (m *OccupationModel) Get(occupationId int) (core.Occupation, error) {
+	if occupationId == MockOccupation.ID {
+		return MockOccupation, nil
+	}
+	return core.Occupation{}, models.ErrNoRecord
+}
+
+func (m *OccupationModel) GetAll() ([]core.Occupation, error) {
+	return []core.Occupation{MockOccupation}, nil
+}
@@ -0,0 +1,132 @@
+package mocks
+
+import (
+	"time"
+
+	"github.com/winik100/NoPenNoPaper/internal/core"
+	"github.com/winik100/NoPenNoPaper/internal/models"
+)
+
+var MockRoll = core.Roll{
+	ID:          1,
+	CharacterID: 1,
+	Name:        "Intrige",
+	Value:       60,
+	Result:      12,
+	Level:       core.Extreme,
+	RolledAt:    time.Date(2024, 7, 12, 20, 15, 0, 0, time.UTC),
+	Source:      core.SourceSheet,
+}
+
+var MockFailedRoll = core.Roll{
+	ID:          2,
+	CharacterID: 1,
+	Name:        "Politik",
+	Value:       70,
+	Result:      78,
+	Level:       core.Failure,
+	RolledAt:    time.Date(2024, 7, 12, 20, 20, 0, 0, time.UTC),
+	Source:      core.SourceSheet,
+}
+
+var MockPushedRoll = core.Roll{
+	ID:          5,
+	CharacterID: 1,
+	Name:        "Manipulation",
+	Value:       60,
+	Result:      75,
+	Level:       core.Failure,
+	RolledAt:    time.Date(2024, 7, 12, 20, 25, 0, 0, time.UTC),
+	Source:      core.SourceSheet,
+	Pushed:      true,
+}
+
+var MockFailedPush = core.Roll{
+	ID:            6,
+	CharacterID:   1,
+	Name:          "Manipulation",
+	Value:         60,
+	Result:        88,
+	Level:         core.Failure,
+	RolledAt:      time.Date(2024, 7, 12, 20, 26, 0, 0, time.UTC),
+	Source:        core.SourceSheet,
+	PushedFrom:    5,
+	Justification: "Otto droht mit dem Zorn des Königs.",
+}
+
+var MockAttackRoll = core.Roll{
+	ID:          7,
+	CharacterID: 1,
+	Name:        "Nahkampf (Handgemenge)",
+	Value:       25,
+	Result:      60,
+	Level:       core.Failure,
+	RolledAt:    time.Date(2024, 7, 12, 20, 30, 0, 0, time.UTC),
+	Source:      core.SourceAttack,
+}
+
+type RollModel struct{}
+
+func (m *RollModel) Insert(roll core.Roll) (int, error) {
+	return 3, nil
+}
+
+func (m *RollModel) Get(characterId, rollId int) (core.Roll, error) {
+	switch {
+	case characterId == MockRoll.CharacterID && rollId == MockRoll.ID:
+		return MockRoll, nil
+	case characterId == MockFailedRoll.CharacterID && rollId == MockFailedRoll.ID:
+		return MockFailedRoll, nil
+	case characterId == MockPushedRoll.CharacterID && rollId == MockPushedRoll.ID:
+		return MockPushedRoll, nil
+	case characterId == MockFailedPush.CharacterID && rollId == MockFailedPush.ID:
+		return MockFailedPush, nil
+	case characterId == MockAttackRoll.CharacterID && rollId == MockAttackRoll.ID:
+		return MockAttackRoll, nil
+	}
+	return core.Roll{}, models.ErrNoRecord
+}
+
+func (m *RollModel) GetHistory(characterId, limit int) ([]core.Roll, error) {
+	if characterId == MockRoll.CharacterID {
+		return []core.Roll{MockFailedPush, MockPushedRoll, MockFailedRoll, MockRoll}, nil
+	}
+	return nil, nil
+}
+
+func (m *RollModel) SpendLuck(characterId, rollId int) (core.Roll, error) {
+	roll, err := m.Get(characterId, rollId)
+	if err != nil {
+		return core.Roll{}, err
+	}
+	if !roll.CanSpendLuck() {
+		return core.Roll{}, models.ErrLuckNotAllowed
+	}
+	roll.SpendLuck()
+	return roll, nil
+}
+
+func (m *RollModel) Push(push core.Roll) (int, error) {
+	original, err := m.Get(push.CharacterID, push.PushedFrom)
+	if err != nil {
+		return 0, err
+	}
+	if !original.CanPush() {
+		return 0, models.ErrPushNotAllowed
+	}
+	return 3, nil
+}
+
+func (m *RollModel) GetUnresolvedPushes(campaignId int) ([]core.PushedRoll, error) {
+	if campaignId == MockCharacterOtto.CampaignID {
+		return []core.PushedRoll{{CharacterName: MockCharacterOtto.Info.Name, Original: MockPushedRoll, Push: MockFailedPush}}, nil
+	}
+	return nil, nil
+}
+
+func (m *RollModel) SetConsequence(campaignId, rollId int, consequence string) error {
+	if campaignId == MockCharacterOtto.CampaignID && rollId == MockFailedPush.ID {
+		return nil
+	}
+	return models.ErrNoRecord
+}
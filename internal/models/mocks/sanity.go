@@ -0,0 +1,42 @@
+package mocks
+
+import (
+	"time"
+
+	"github.com/winik100/NoPenNoPaper/internal/core"
+)
+
+var MockSanityCheck = core.SanityCheck{
+	ID:          1,
+	CharacterID: 1,
+	Source:      "Drachenfeuer über King's Landing",
+	Loss:        core.SanityLoss{Success: "1", Failure: "1d6"},
+	Value:       55,
+	Result:      72,
+	Level:       core.Failure,
+	Lost:        5,
+	Temporary:   true,
+	Bout:        "Ohnmacht: bricht bewusstlos zusammen (3 Runden)",
+	CheckedAt:   time.Date(2024, 7, 12, 21, 0, 0, 0, time.UTC),
+}
+
+type SanityModel struct{}
+
+func (m *SanityModel) Check(check core.SanityCheck) (int, error) {
+	return 2, nil
+}
+
+func (m *SanityModel) GetHistory(characterId, limit int) ([]core.SanityCheck, error) {
+	if characterId == MockSanityCheck.CharacterID {
+		return []core.SanityCheck{MockSanityCheck}, nil
+	}
+	return nil, nil
+}
+
+func (m *SanityModel) Recover(characterId int) error {
+	return nil
+}
+
+func (m *SanityModel) NewDay(campaignId int) error {
+	return nil
+}
@@ -0,0 +1,62 @@
+package mocks
+
+import (
+	"github.com/winik100/NoPenNoPaper/internal/core"
+	"github.com/winik100/NoPenNoPaper/internal/models"
+)
+
+var MockSpellDominate = core.Spell{
+	ID:          1,
+	Name:        "Dominieren",
+	MPCost:      1,
+	SANCost:     "1d4",
+	CastingTime: "sofort",
+}
+
+var MockSpellElderSign = core.Spell{
+	ID:          2,
+	Name:        "Älteres Zeichen erschaffen",
+	MPCost:      10,
+	SANCost:     "1d10",
+	CastingTime: "1 Tag",
+}
+
+// costs more magic points than Otto has
+var MockSpellStarVampire = core.Spell{
+	ID:          3,
+	Name:        "Sternenvampir herbeirufen",
+	MPCost:      12,
+	SANCost:     "1d10",
+	CastingTime: "5 Runden",
+}
+
+type SpellModel struct{}
+
+func (m *SpellModel) Get(spellId int) (core.Spell, error) {
+	for _, spell := range []core.Spell{MockSpellDominate, MockSpellElderSign, MockSpellStarVampire} {
+		if spell.ID == spellId {
+			return spell, nil
+		}
+	}
+	return core.Spell{}, models.ErrNoRecord
+}
+
+func (m *SpellModel) GetAll() ([]core.Spell, error) {
+	return []core.Spell{MockSpellDominate, MockSpellStarVampire, MockSpellElderSign}, nil
+}
+
+func (m *SpellModel) Learn(characterId, spellId int) error {
+	if characterId == MockCharacterOtto.ID {
+		if _, ok := MockCharacterOtto.KnownSpell(spellId); ok {
+			return models.ErrAlreadyKnown
+		}
+	}
+	return nil
+}
+
+func (m *SpellModel) Cast(casting core.SpellCasting) error {
+	if casting.CharacterID == MockCharacterOtto.ID && casting.Spell.MPCost > MockCharacterOtto.Stats.MP {
+		return models.ErrNotEnoughMagic
+	}
+	return nil
+}
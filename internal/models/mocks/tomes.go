@@ -0,0 +1,77 @@
+package mocks
+
+import (
+	"github.com/winik100/NoPenNoPaper/internal/core"
+	"github.com/winik100/NoPenNoPaper/internal/models"
+)
+
+var MockTomeNecronomicon = core.Tome{
+	ID:         1,
+	Name:       "Necronomicon (lateinisch)",
+	Language:   "Latein",
+	MythosGain: 12,
+	SANLoss:    "2d10",
+	StudyWeeks: 66,
+}
+
+var MockTomePnakotic = core.Tome{
+	ID:         2,
+	Name:       "Pnakotische Manuskripte",
+	Language:   "Englisch",
+	MythosGain: 8,
+	SANLoss:    "1d10",
+	StudyWeeks: 45,
+}
+
+var MockTomeVermis = core.Tome{
+	ID:         3,
+	Name:       "De Vermis Mysteriis",
+	Language:   "Latein",
+	MythosGain: 10,
+	SANLoss:    "2d6",
+	StudyWeeks: 48,
+}
+
+var mockTomePnakoticStudied = func() core.Tome {
+	tome := MockTomePnakotic
+	tome.Studied = true
+	return tome
+}()
+
+type TomeModel struct{}
+
+func (m *TomeModel) Get(tomeId int) (core.Tome, error) {
+	for _, tome := range []core.Tome{MockTomeNecronomicon, MockTomePnakotic, MockTomeVermis} {
+		if tome.ID == tomeId {
+			return tome, nil
+		}
+	}
+	return core.Tome{}, models.ErrNoRecord
+}
+
+func (m *TomeModel) GetAll() ([]core.Tome, error) {
+	return []core.Tome{MockTomeVermis, MockTomeNecronomicon, MockTomePnakotic}, nil
+}
+
+func (m *TomeModel) Acquire(characterId, tomeId int) error {
+	if characterId == MockCharacterOtto.ID {
+		if _, ok := MockCharacterOtto.OwnedTome(tomeId); ok {
+			return models.ErrAlreadyOwned
+		}
+	}
+	return nil
+}
+
+// Otto has no Cthulhu-Mythos yet, so the gain is all there is
+func (m *TomeModel) Study(study core.TomeStudy) (int, error) {
+	if study.CharacterID == MockCharacterOtto.ID {
+		tome, ok := MockCharacterOtto.OwnedTome(study.Tome.ID)
+		if !ok {
+			return 0, models.ErrNoRecord
+		}
+		if tome.Studied {
+			return 0, models.ErrAlreadyStudied
+		}
+	}
+	return study.Tome.MythosGain, nil
+}
@@ -17,6 +17,11 @@ var MockGM = core.User{
 	Name:           "Test-GM",
 	HashedPassword: "$2a$12$uK5Qivao7pieZMOZWtRTGubxPV3PgBf6ljFr3ACYtGPYZOrinx3ie",
 	Role:           "gm",
+	Materials: core.Materials{
+		ID:       []int{1},
+		Title:    []string{"Karte von Westeros"},
+		FileName: []string{"westeros.png"},
+	},
 }
 
 type UserModel struct{}
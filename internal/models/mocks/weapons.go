@@ -0,0 +1,53 @@
+package mocks
+
+import (
+	"github.com/winik100/NoPenNoPaper/internal/core"
+	"github.com/winik100/NoPenNoPaper/internal/models"
+)
+
+var MockWeaponDagger = core.Weapon{
+	ID:      1,
+	Name:    "Dolch",
+	Skill:   "Schwertkampf",
+	Damage:  "1d4+DB",
+	Range:   "Berührung",
+	Attacks: "1",
+}
+
+var MockWeaponCrossbow = core.Weapon{
+	ID:          2,
+	Name:        "Armbrust",
+	Skill:       "Armbrust",
+	Damage:      "1d8+2",
+	Range:       "50 m",
+	Attacks:     "1/2",
+	Ammo:        1,
+	Malfunction: 96,
+}
+
+type WeaponModel struct{}
+
+func (m *WeaponModel) Get(weaponId int) (core.Weapon, error) {
+	switch weaponId {
+	case MockWeaponDagger.ID:
+		return MockWeaponDagger, nil
+	case MockWeaponCrossbow.ID:
+		return MockWeaponCrossbow, nil
+	}
+	return core.Weapon{}, models.ErrNoRecord
+}
+
+func (m *WeaponModel) GetAll() ([]core.Weapon, error) {
+	return []core.Weapon{MockWeaponCrossbow, MockWeaponDagger}, nil
+}
+
+func (m *WeaponModel) Carry(characterId, weaponId int) error {
+	if characterId == MockCharacterOtto.ID && weaponId == MockWeaponDagger.ID {
+		return models.ErrAlreadyCarried
+	}
+	return nil
+}
+
+func (m *WeaponModel) Drop(characterId, weaponId int) error {
+	return nil
+}
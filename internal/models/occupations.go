@@ -0,0 +1,84 @@
+package models
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/winik100/NoPenNoPaper/internal/core"
+)
+
+type OccupationModelInterface interface {
+	Get(occupationId int) (core.Occupation, error)
+	GetAll() ([]core.Occupation, error)
+}
+
+type OccupationModel struct {
+	DB *sql.DB
+}
+
+func (o *OccupationModel) Get(occupationId int) (core.Occupation, error) {
+	var occupation core.Occupation
+
+	stmt := "SELECT id, name, skill_points, credit_min, credit_max FROM occupations WHERE id=?;"
+	err := o.DB.QueryRow(stmt, occupationId).Scan(&occupation.ID, &occupation.Name, &occupation.SkillPoints,
+		&occupation.Credit.Min, &occupation.Credit.Max)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return core.Occupation{}, ErrNoRecord
+		}
+		return core.Occupation{}, err
+	}
+
+	stmt = "SELECT skill FROM occupation_skills WHERE occupation_id=? ORDER BY skill;"
+	rows, err := o.DB.Query(stmt, occupationId)
+	if err != nil {
+		return core.Occupation{}, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var skill string
+		err = rows.Scan(&skill)
+		if err != nil {
+			return core.Occupation{}, err
+		}
+		occupation.Skills = append(occupation.Skills, skill)
+	}
+	if err = rows.Err(); err != nil {
+		return core.Occupation{}, err
+	}
+	return occupation, nil
+}
+
+// all occupations ordered by name, including their skills
+func (o *OccupationModel) GetAll() ([]core.Occupation, error) {
+	stmt := `SELECT o.id, o.name, o.skill_points, o.credit_min, o.credit_max, os.skill FROM occupations AS o
+	LEFT JOIN occupation_skills AS os ON o.id = os.occupation_id ORDER BY o.name, os.skill;`
+	rows, err := o.DB.Query(stmt)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var occupations []core.Occupation
+	for rows.Next() {
+		var occupation core.Occupation
+		var skill sql.NullString
+		err = rows.Scan(&occupation.ID, &occupation.Name, &occupation.SkillPoints, &occupation.Credit.Min, &occupation.Credit.Max, &skill)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(occupations) == 0 || occupations[len(occupations)-1].ID != occupation.ID {
+			occupations = append(occupations, occupation)
+		}
+		if skill.Valid {
+			last := &occupations[len(occupations)-1]
+			last.Skills = append(last.Skills, skill.String)
+		}
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return occupations, nil
+}
@@ -0,0 +1,41 @@
+package models
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/winik100/NoPenNoPaper/internal/core"
+	"github.com/winik100/NoPenNoPaper/internal/testHelpers"
+)
+
+func TestOccupations(t *testing.T) {
+	db := newTestDB(t)
+	o := OccupationModel{db}
+
+	occupation, err := o.Get(2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testHelpers.Equal(t, occupation.Name, "Polizist")
+	testHelpers.Equal(t, occupation.SkillPoints, "BI×2+(GE|ST)×2")
+	testHelpers.Equal(t, occupation.Credit, core.CreditRange{Min: 9, Max: 30})
+	testHelpers.Equal(t, len(occupation.Skills), 7)
+
+	points, err := occupation.OccupationPoints(core.CharacterAttributes{BI: 60, GE: 50, ST: 70})
+	if err != nil {
+		t.Fatal(err)
+	}
+	testHelpers.Equal(t, points, 260)
+
+	_, err = o.Get(99)
+	testHelpers.Equal(t, errors.Is(err, ErrNoRecord), true)
+
+	occupations, err := o.GetAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	testHelpers.Equal(t, len(occupations), 2)
+	testHelpers.Equal(t, occupations[0].Name, "Antiquar")
+	testHelpers.Equal(t, len(occupations[0].Skills), 8)
+	testHelpers.Equal(t, len(occupations[1].Skills), len(occupation.Skills))
+}
@@ -0,0 +1,277 @@
+package models
+
+import (
+	"database/sql"
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/winik100/NoPenNoPaper/internal/core"
+)
+
+type RollModelInterface interface {
+	Insert(roll core.Roll) (int, error)
+	Get(characterId, rollId int) (core.Roll, error)
+	GetHistory(characterId, limit int) ([]core.Roll, error)
+	SpendLuck(characterId, rollId int) (core.Roll, error)
+	Push(push core.Roll) (int, error)
+	GetUnresolvedPushes(campaignId int) ([]core.PushedRoll, error)
+	SetConsequence(campaignId, rollId int, consequence string) error
+}
+
+const rollColumns = `id, character_id, name, value, bonus, tens, result, level, luck_spent, rolled_at, source,
+	pushed_from, justification, consequence, EXISTS(SELECT 1 FROM rolls AS p WHERE p.pushed_from = rolls.id)`
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+// either the database itself or a transaction
+type execer interface {
+	Exec(query string, args ...any) (sql.Result, error)
+}
+
+func scanRoll(row rowScanner) (core.Roll, error) {
+	var roll core.Roll
+	var tens string
+	var pushedFrom sql.NullInt64
+	err := row.Scan(&roll.ID, &roll.CharacterID, &roll.Name, &roll.Value, &roll.Bonus, &tens, &roll.Result, &roll.Level, &roll.LuckSpent, &roll.RolledAt, &roll.Source,
+		&pushedFrom, &roll.Justification, &roll.Consequence, &roll.Pushed)
+	if err != nil {
+		return core.Roll{}, err
+	}
+	roll.PushedFrom = int(pushedFrom.Int64)
+	roll.Tens, err = splitInts(tens)
+	if err != nil {
+		return core.Roll{}, err
+	}
+	return roll, nil
+}
+
+type RollModel struct {
+	DB *sql.DB
+}
+
+func (r *RollModel) Insert(roll core.Roll) (int, error) {
+	return insertRoll(r.DB, roll)
+}
+
+func insertRoll(db execer, roll core.Roll) (int, error) {
+	pushedFrom := sql.NullInt64{Int64: int64(roll.PushedFrom), Valid: roll.IsPush()}
+	stmt := `INSERT INTO rolls (character_id, name, value, bonus, tens, result, level, rolled_at, source, pushed_from, justification)
+	VALUES (?,?,?,?,?,?,?,?,?,?,?);`
+	res, err := db.Exec(stmt, roll.CharacterID, roll.Name, roll.Value, roll.Bonus, joinInts(roll.Tens), roll.Result, roll.Level, roll.RolledAt.UTC(),
+		roll.Source, pushedFrom, roll.Justification)
+	if err != nil {
+		return 0, err
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return 0, err
+	}
+	return int(id), nil
+}
+
+// rolls of other characters are treated as nonexistent
+func (r *RollModel) Get(characterId, rollId int) (core.Roll, error) {
+	stmt := "SELECT " + rollColumns + " FROM rolls WHERE id=? AND character_id=?;"
+	roll, err := scanRoll(r.DB.QueryRow(stmt, rollId, characterId))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return core.Roll{}, ErrNoRecord
+		}
+		return core.Roll{}, err
+	}
+	return roll, nil
+}
+
+// the latest rolls of a character, newest first
+func (r *RollModel) GetHistory(characterId, limit int) ([]core.Roll, error) {
+	stmt := "SELECT " + rollColumns + " FROM rolls WHERE character_id=? ORDER BY rolled_at DESC, id DESC LIMIT ?;"
+	rows, err := r.DB.Query(stmt, characterId, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var rolls []core.Roll
+	for rows.Next() {
+		roll, err := scanRoll(rows)
+		if err != nil {
+			return nil, err
+		}
+		rolls = append(rolls, roll)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return rolls, nil
+}
+
+// turns a failed roll into a success, deducting the luck needed from the character in the same transaction
+func (r *RollModel) SpendLuck(characterId, rollId int) (core.Roll, error) {
+	tx, err := r.DB.Begin()
+	if err != nil {
+		return core.Roll{}, err
+	}
+	defer tx.Rollback()
+
+	stmt := "SELECT " + rollColumns + " FROM rolls WHERE id=? AND character_id=? FOR UPDATE;"
+	roll, err := scanRoll(tx.QueryRow(stmt, rollId, characterId))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return core.Roll{}, ErrNoRecord
+		}
+		return core.Roll{}, err
+	}
+	if !roll.CanSpendLuck() {
+		return core.Roll{}, ErrLuckNotAllowed
+	}
+
+	spent := roll.SpendLuck()
+	stmt = "UPDATE character_stats SET luck=luck-? WHERE character_id=? AND luck>=?;"
+	res, err := tx.Exec(stmt, spent, characterId, spent)
+	if err != nil {
+		return core.Roll{}, err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return core.Roll{}, err
+	}
+	if affected == 0 {
+		return core.Roll{}, ErrNotEnoughLuck
+	}
+
+	stmt = "UPDATE rolls SET result=?, level=?, luck_spent=? WHERE id=?;"
+	_, err = tx.Exec(stmt, roll.Result, roll.Level, roll.LuckSpent, roll.ID)
+	if err != nil {
+		return core.Roll{}, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return core.Roll{}, err
+	}
+	return roll, nil
+}
+
+// stores the push of a failed roll, as long as that roll wasn't pushed or had luck spent on it in the meantime
+func (r *RollModel) Push(push core.Roll) (int, error) {
+	tx, err := r.DB.Begin()
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	stmt := "SELECT " + rollColumns + " FROM rolls WHERE id=? AND character_id=? FOR UPDATE;"
+	original, err := scanRoll(tx.QueryRow(stmt, push.PushedFrom, push.CharacterID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, ErrNoRecord
+		}
+		return 0, err
+	}
+	if !original.CanPush() {
+		return 0, ErrPushNotAllowed
+	}
+
+	id, err := insertRoll(tx, push)
+	if err != nil {
+		return 0, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return 0, err
+	}
+	return id, nil
+}
+
+// failed pushes of the campaign's characters the GM has yet to decide the consequences of, oldest first
+func (r *RollModel) GetUnresolvedPushes(campaignId int) ([]core.PushedRoll, error) {
+	stmt := `SELECT r.id, r.character_id, r.pushed_from, ci.name FROM rolls AS r
+	JOIN characters AS c ON r.character_id = c.id
+	JOIN character_info AS ci ON r.character_id = ci.character_id
+	WHERE c.campaign_id=? AND r.pushed_from IS NOT NULL AND r.level<? AND r.consequence=''
+	ORDER BY r.rolled_at, r.id;`
+	rows, err := r.DB.Query(stmt, campaignId, core.Regular)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	type pushRef struct {
+		id, characterId, pushedFrom int
+		name                        string
+	}
+	var refs []pushRef
+	for rows.Next() {
+		var ref pushRef
+		err = rows.Scan(&ref.id, &ref.characterId, &ref.pushedFrom, &ref.name)
+		if err != nil {
+			return nil, err
+		}
+		refs = append(refs, ref)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	var pushes []core.PushedRoll
+	for _, ref := range refs {
+		push, err := r.Get(ref.characterId, ref.id)
+		if err != nil {
+			return nil, err
+		}
+		original, err := r.Get(ref.characterId, ref.pushedFrom)
+		if err != nil {
+			return nil, err
+		}
+		pushes = append(pushes, core.PushedRoll{CharacterName: ref.name, Original: original, Push: push})
+	}
+	return pushes, nil
+}
+
+// the consequence of a failed push is written once, by the GM of the character's campaign
+func (r *RollModel) SetConsequence(campaignId, rollId int, consequence string) error {
+	stmt := `UPDATE rolls AS r JOIN characters AS c ON r.character_id = c.id SET r.consequence=?
+	WHERE r.id=? AND c.campaign_id=? AND r.pushed_from IS NOT NULL AND r.level<? AND r.consequence='';`
+	res, err := r.DB.Exec(stmt, consequence, rollId, campaignId, core.Regular)
+	if err != nil {
+		return err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrNoRecord
+	}
+	return nil
+}
+
+// tens dice are stored as a comma separated list
+func joinInts(values []int) string {
+	strs := make([]string, len(values))
+	for i, value := range values {
+		strs[i] = strconv.Itoa(value)
+	}
+	return strings.Join(strs, ",")
+}
+
+func splitInts(s string) ([]int, error) {
+	if s == "" {
+		return nil, nil
+	}
+
+	var values []int
+	for _, str := range strings.Split(s, ",") {
+		value, err := strconv.Atoi(str)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+	}
+	return values, nil
+}
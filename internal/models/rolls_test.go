@@ -0,0 +1,239 @@
+package models
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/winik100/NoPenNoPaper/internal/core"
+	"github.com/winik100/NoPenNoPaper/internal/testHelpers"
+)
+
+func TestRolls(t *testing.T) {
+	db := newTestDB(t)
+
+	ch := CharacterModel{db}
+	r := RollModel{db}
+
+	characterId, err := ch.Insert(core.Character{Info: core.CharacterInfo{Name: "Otto Hightower"}}, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rolledAt := time.Date(2024, 7, 12, 20, 15, 0, 0, time.UTC)
+	for i, result := range []int{12, 55, 98} {
+		_, err = r.Insert(core.Roll{
+			CharacterID: characterId,
+			Name:        "Intrige",
+			Value:       60,
+			Result:      result,
+			Level:       core.Classify(result, 60),
+			RolledAt:    rolledAt.Add(time.Duration(i) * time.Minute),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	rolls, err := r.GetHistory(characterId, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testHelpers.Equal(t, len(rolls), 2)
+	testHelpers.Equal(t, rolls[0].Result, 98)
+	testHelpers.Equal(t, rolls[0].Level, core.Failure)
+	testHelpers.Equal(t, rolls[1].Level, core.Regular)
+	testHelpers.Equal(t, rolls[1].RolledAt.Equal(rolledAt.Add(time.Minute)), true)
+
+	percentile := core.PickPercentile([]int{30, 0, 90}, 0, -2)
+	testHelpers.Equal(t, percentile.Result, 100)
+	_, err = r.Insert(core.Roll{
+		CharacterID: characterId,
+		Name:        "LUCK",
+		Value:       50,
+		Bonus:       -2,
+		Tens:        percentile.Tens,
+		Result:      percentile.Result,
+		Level:       core.Classify(percentile.Result, 50),
+		RolledAt:    rolledAt.Add(time.Hour),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rolls, err = r.GetHistory(characterId, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testHelpers.Equal(t, rolls[0].Bonus, -2)
+	testHelpers.Equal(t, len(rolls[0].Tens), 3)
+	testHelpers.Equal(t, rolls[0].Tens[2], 90)
+	testHelpers.Equal(t, rolls[0].Level, core.Fumble)
+
+	// luck is rolled on creation, fix it for the test
+	_, err = db.Exec("UPDATE character_stats SET luck=80, maxluck=90 WHERE character_id=?;", characterId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	failedId, err := r.Insert(core.Roll{
+		CharacterID: characterId,
+		Name:        "Intrige",
+		Value:       20,
+		Result:      75,
+		Level:       core.Failure,
+		RolledAt:    rolledAt.Add(2 * time.Hour),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = r.SpendLuck(characterId+1, failedId)
+	testHelpers.Equal(t, errors.Is(err, ErrNoRecord), true)
+
+	roll, err := r.SpendLuck(characterId, failedId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testHelpers.Equal(t, roll.Result, 20)
+	testHelpers.Equal(t, roll.Level, core.Regular)
+	testHelpers.Equal(t, roll.LuckSpent, 55)
+
+	roll, err = r.Get(characterId, failedId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testHelpers.Equal(t, roll.LuckSpent, 55)
+	character, err := ch.Get(characterId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testHelpers.Equal(t, character.Stats.LUCK, 25)
+
+	_, err = r.SpendLuck(characterId, failedId)
+	testHelpers.Equal(t, errors.Is(err, ErrLuckNotAllowed), true)
+
+	expensiveId, err := r.Insert(core.Roll{
+		CharacterID: characterId,
+		Name:        "Politik",
+		Value:       10,
+		Result:      90,
+		Level:       core.Failure,
+		RolledAt:    rolledAt.Add(3 * time.Hour),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = r.SpendLuck(characterId, expensiveId)
+	testHelpers.Equal(t, errors.Is(err, ErrNotEnoughLuck), true)
+
+	luck, err := ch.GainLuck(characterId, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testHelpers.Equal(t, luck, 90)
+}
+
+func TestPushedRolls(t *testing.T) {
+	db := newTestDB(t)
+
+	c := CampaignModel{db}
+	ch := CharacterModel{db}
+	r := RollModel{db}
+
+	campaignId, err := c.Insert("Der Tanz der Drachen", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	characterId, err := ch.Insert(core.Character{CampaignID: campaignId, Info: core.CharacterInfo{Name: "Otto Hightower"}}, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	failed := core.Roll{
+		CharacterID: characterId,
+		Name:        "Intrige",
+		Value:       60,
+		Result:      75,
+		Level:       core.Failure,
+		RolledAt:    time.Date(2024, 7, 12, 20, 15, 0, 0, time.UTC),
+		Source:      core.SourceSheet,
+	}
+	failed.ID, err = r.Insert(failed)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	push := failed.Push("Otto erinnert an seine Verdienste.")
+	push.Result = 91
+	push.Level = core.Failure
+	push.ID, err = r.Push(push)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// a roll is pushed only once and pushes themselves can't be pushed
+	_, err = r.Push(failed.Push("Noch einmal."))
+	testHelpers.Equal(t, errors.Is(err, ErrPushNotAllowed), true)
+	_, err = r.Push(push.Push("Und noch einmal."))
+	testHelpers.Equal(t, errors.Is(err, ErrPushNotAllowed), true)
+
+	// the outcome of an attack is settled, so it can't be pushed from the sheet
+	attack := failed
+	attack.Name = "Nahkampf (Handgemenge)"
+	attack.Source = core.SourceAttack
+	attack.ID, err = r.Insert(attack)
+	if err != nil {
+		t.Fatal(err)
+	}
+	attack, err = r.Get(characterId, attack.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testHelpers.Equal(t, attack.Source, core.SourceAttack)
+	_, err = r.Push(attack.Push("Noch ein Schlag."))
+	testHelpers.Equal(t, errors.Is(err, ErrPushNotAllowed), true)
+
+	failed, err = r.Get(characterId, failed.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testHelpers.Equal(t, failed.Pushed, true)
+	testHelpers.Equal(t, failed.CanPush(), false)
+
+	// luck on the original would dodge the consequence of the failed push
+	_, err = r.SpendLuck(characterId, failed.ID)
+	testHelpers.Equal(t, errors.Is(err, ErrLuckNotAllowed), true)
+
+	pushes, err := r.GetUnresolvedPushes(campaignId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testHelpers.Equal(t, len(pushes), 1)
+	testHelpers.Equal(t, pushes[0].CharacterName, "Otto Hightower")
+	testHelpers.Equal(t, pushes[0].Original.ID, failed.ID)
+	testHelpers.Equal(t, pushes[0].Push.PushedFrom, failed.ID)
+	testHelpers.Equal(t, pushes[0].Push.Justification, "Otto erinnert an seine Verdienste.")
+	testHelpers.Equal(t, pushes[0].Push.CanSpendLuck(), false)
+
+	err = r.SetConsequence(campaignId+1, push.ID, "Der König ist verstimmt.")
+	testHelpers.Equal(t, errors.Is(err, ErrNoRecord), true)
+	err = r.SetConsequence(campaignId, push.ID, "Der König ist verstimmt.")
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = r.SetConsequence(campaignId, push.ID, "Der König verbannt Otto.")
+	testHelpers.Equal(t, errors.Is(err, ErrNoRecord), true)
+
+	pushes, err = r.GetUnresolvedPushes(campaignId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testHelpers.Equal(t, len(pushes), 0)
+
+	rolls, err := r.GetHistory(characterId, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testHelpers.Equal(t, rolls[0].Consequence, "Der König ist verstimmt.")
+	testHelpers.Equal(t, rolls[0].AwaitsConsequence(), false)
+}
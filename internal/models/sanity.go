@@ -0,0 +1,103 @@
+package models
+
+import (
+	"database/sql"
+
+	"github.com/winik100/NoPenNoPaper/internal/core"
+)
+
+type SanityModelInterface interface {
+	Check(check core.SanityCheck) (int, error)
+	GetHistory(characterId, limit int) ([]core.SanityCheck, error)
+	Recover(characterId int) error
+	NewDay(campaignId int) error
+}
+
+type SanityModel struct {
+	DB *sql.DB
+}
+
+// deducts the sanity lost in the check, records any insanity and adds the check to the history
+func (s *SanityModel) Check(check core.SanityCheck) (int, error) {
+	tx, err := s.DB.Begin()
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	stmt := `UPDATE character_stats SET sta=GREATEST(sta-?, 0), san_loss_today=san_loss_today+?,
+		temp_insane=(temp_insane OR ?), indef_insane=(indef_insane OR ?) WHERE character_id=?;`
+	_, err = tx.Exec(stmt, check.Lost, check.Lost, check.Temporary, check.Indefinite, check.CharacterID)
+	if err != nil {
+		return 0, err
+	}
+
+	stmt = `INSERT INTO sanity_checks (character_id, source, loss, value, result, level, lost, temporary, indefinite, bout, checked_at)
+		VALUES (?,?,?,?,?,?,?,?,?,?,?);`
+	res, err := tx.Exec(stmt, check.CharacterID, check.Source, check.Loss.String(), check.Value, check.Result, check.Level,
+		check.Lost, check.Temporary, check.Indefinite, check.Bout, check.CheckedAt.UTC())
+	if err != nil {
+		return 0, err
+	}
+	id, err := res.LastInsertId()
+	if err != nil {
+		return 0, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return 0, err
+	}
+	return int(id), nil
+}
+
+// the latest sanity checks of a character, newest first
+func (s *SanityModel) GetHistory(characterId, limit int) ([]core.SanityCheck, error) {
+	stmt := `SELECT id, character_id, source, loss, value, result, level, lost, temporary, indefinite, bout, checked_at
+		FROM sanity_checks WHERE character_id=? ORDER BY checked_at DESC, id DESC LIMIT ?;`
+	rows, err := s.DB.Query(stmt, characterId, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var checks []core.SanityCheck
+	for rows.Next() {
+		var check core.SanityCheck
+		var loss string
+		err = rows.Scan(&check.ID, &check.CharacterID, &check.Source, &loss, &check.Value, &check.Result, &check.Level,
+			&check.Lost, &check.Temporary, &check.Indefinite, &check.Bout, &check.CheckedAt)
+		if err != nil {
+			return nil, err
+		}
+		check.Loss, err = core.ParseSanityLoss(loss)
+		if err != nil {
+			return nil, err
+		}
+		checks = append(checks, check)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return checks, nil
+}
+
+// ends temporary and indefinite insanity of the character
+func (s *SanityModel) Recover(characterId int) error {
+	stmt := "UPDATE character_stats SET temp_insane=false, indef_insane=false WHERE character_id=?;"
+	_, err := s.DB.Exec(stmt, characterId)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// starts a new in-game day for every character of the campaign, resetting their daily sanity loss
+func (s *SanityModel) NewDay(campaignId int) error {
+	stmt := "UPDATE character_stats SET san_loss_today=0 WHERE character_id IN (SELECT id FROM characters WHERE campaign_id=?);"
+	_, err := s.DB.Exec(stmt, campaignId)
+	if err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,109 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/winik100/NoPenNoPaper/internal/core"
+	"github.com/winik100/NoPenNoPaper/internal/testHelpers"
+)
+
+func TestSanity(t *testing.T) {
+	db := newTestDB(t)
+
+	c := CampaignModel{db}
+	ch := CharacterModel{db}
+	s := SanityModel{db}
+
+	campaignId, err := c.Insert("Der Tanz der Drachen", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	characterId, err := ch.Insert(core.Character{
+		CampaignID: campaignId,
+		Info:       core.CharacterInfo{Name: "Otto Hightower"},
+		Attributes: core.CharacterAttributes{MA: 50},
+	}, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	character, err := ch.Get(characterId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sanity := character.Stats.STA
+
+	checkedAt := time.Date(2024, 7, 12, 21, 0, 0, 0, time.UTC)
+	_, err = s.Check(core.SanityCheck{
+		CharacterID: characterId,
+		Source:      "Drachenfeuer",
+		Loss:        core.SanityLoss{Success: "1", Failure: "1d6"},
+		Value:       sanity,
+		Result:      80,
+		Level:       core.Failure,
+		Lost:        5,
+		Temporary:   true,
+		Bout:        "Ohnmacht",
+		CheckedAt:   checkedAt,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = s.Check(core.SanityCheck{
+		CharacterID: characterId,
+		Source:      "Leiche",
+		Loss:        core.SanityLoss{Success: "0", Failure: "1d3"},
+		Value:       sanity - 5,
+		Result:      20,
+		Level:       core.Regular,
+		CheckedAt:   checkedAt.Add(time.Minute),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	character, err = ch.Get(characterId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testHelpers.Equal(t, character.Stats.STA, sanity-5)
+	testHelpers.Equal(t, character.Stats.SanityLossToday, 5)
+	testHelpers.Equal(t, character.Stats.TemporaryInsanity, true)
+	testHelpers.Equal(t, character.Stats.IndefiniteInsanity, false)
+
+	checks, err := s.GetHistory(characterId, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testHelpers.Equal(t, len(checks), 2)
+	testHelpers.Equal(t, checks[0].Source, "Leiche")
+	testHelpers.Equal(t, checks[1].Loss.String(), "1/1d6")
+	testHelpers.Equal(t, checks[1].Temporary, true)
+	testHelpers.Equal(t, checks[1].Bout, "Ohnmacht")
+
+	err = s.Recover(characterId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = s.NewDay(campaignId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	character, err = ch.Get(characterId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testHelpers.Equal(t, character.Stats.TemporaryInsanity, false)
+	testHelpers.Equal(t, character.Stats.SanityLossToday, 0)
+
+	err = ch.CapSanity(characterId, 30)
+	if err != nil {
+		t.Fatal(err)
+	}
+	character, err = ch.Get(characterId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testHelpers.Equal(t, character.Stats.MaxSTA, 30)
+	testHelpers.Equal(t, character.Stats.STA, 30)
+}
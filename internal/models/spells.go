@@ -0,0 +1,137 @@
+package models
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/winik100/NoPenNoPaper/internal/core"
+)
+
+type SpellModelInterface interface {
+	Get(spellId int) (core.Spell, error)
+	GetAll() ([]core.Spell, error)
+	Learn(characterId, spellId int) error
+	Cast(casting core.SpellCasting) error
+}
+
+const spellColumns = "s.id, s.name, s.mp_cost, s.san_cost, s.casting_time"
+
+func scanSpell(row rowScanner) (core.Spell, error) {
+	var spell core.Spell
+	err := row.Scan(&spell.ID, &spell.Name, &spell.MPCost, &spell.SANCost, &spell.CastingTime)
+	if err != nil {
+		return core.Spell{}, err
+	}
+	return spell, nil
+}
+
+func scanSpells(rows *sql.Rows) ([]core.Spell, error) {
+	defer rows.Close()
+
+	var spells []core.Spell
+	for rows.Next() {
+		spell, err := scanSpell(rows)
+		if err != nil {
+			return nil, err
+		}
+		spells = append(spells, spell)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return spells, nil
+}
+
+// the spells known by a character, ordered by name
+func getKnownSpells(db *sql.DB, characterId int) ([]core.Spell, error) {
+	stmt := "SELECT " + spellColumns + ` FROM character_spells AS cs JOIN spells AS s ON cs.spell_id = s.id
+	WHERE cs.character_id=? ORDER BY s.name;`
+	rows, err := db.Query(stmt, characterId)
+	if err != nil {
+		return nil, err
+	}
+	return scanSpells(rows)
+}
+
+type SpellModel struct {
+	DB *sql.DB
+}
+
+func (s *SpellModel) Get(spellId int) (core.Spell, error) {
+	stmt := "SELECT " + spellColumns + " FROM spells AS s WHERE s.id=?;"
+	spell, err := scanSpell(s.DB.QueryRow(stmt, spellId))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return core.Spell{}, ErrNoRecord
+		}
+		return core.Spell{}, err
+	}
+	return spell, nil
+}
+
+// the whole catalog, ordered by name
+func (s *SpellModel) GetAll() ([]core.Spell, error) {
+	stmt := "SELECT " + spellColumns + " FROM spells AS s ORDER BY s.name;"
+	rows, err := s.DB.Query(stmt)
+	if err != nil {
+		return nil, err
+	}
+	return scanSpells(rows)
+}
+
+func (s *SpellModel) Learn(characterId, spellId int) error {
+	var exists bool
+	stmt := "SELECT EXISTS(SELECT true FROM character_spells WHERE character_id=? AND spell_id=?);"
+	err := s.DB.QueryRow(stmt, characterId, spellId).Scan(&exists)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return ErrAlreadyKnown
+	}
+
+	stmt = "INSERT INTO character_spells (character_id, spell_id) VALUES (?,?);"
+	_, err = s.DB.Exec(stmt, characterId, spellId)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// deducts the magic points and sanity of the casting in one go. the spell has to be known and
+// the magic points left at the time of casting have to cover its cost.
+func (s *SpellModel) Cast(casting core.SpellCasting) error {
+	tx, err := s.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var mp int
+	stmt := `SELECT st.mp FROM character_stats AS st JOIN character_spells AS cs ON st.character_id = cs.character_id
+	WHERE st.character_id=? AND cs.spell_id=? FOR UPDATE;`
+	err = tx.QueryRow(stmt, casting.CharacterID, casting.Spell.ID).Scan(&mp)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrNoRecord
+		}
+		return err
+	}
+	if mp < casting.Spell.MPCost {
+		return ErrNotEnoughMagic
+	}
+
+	stmt = `UPDATE character_stats SET mp=mp-?, sta=GREATEST(sta-?, 0), san_loss_today=san_loss_today+?,
+		temp_insane=(temp_insane OR ?), indef_insane=(indef_insane OR ?) WHERE character_id=?;`
+	_, err = tx.Exec(stmt, casting.Spell.MPCost, casting.Sanity.Lost, casting.Sanity.Lost,
+		casting.Sanity.Temporary, casting.Sanity.Indefinite, casting.CharacterID)
+	if err != nil {
+		return err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return err
+	}
+	return nil
+}
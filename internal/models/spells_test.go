@@ -0,0 +1,78 @@
+package models
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/winik100/NoPenNoPaper/internal/core"
+	"github.com/winik100/NoPenNoPaper/internal/testHelpers"
+)
+
+func TestSpells(t *testing.T) {
+	db := newTestDB(t)
+
+	ch := CharacterModel{db}
+	s := SpellModel{db}
+
+	spells, err := s.GetAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	testHelpers.Equal(t, len(spells), 2)
+	testHelpers.Equal(t, spells[0].Name, "Dominieren")
+
+	elderSign, err := s.Get(2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testHelpers.Equal(t, elderSign.MPCost, 10)
+	testHelpers.Equal(t, elderSign.SANCost, "1d10")
+
+	_, err = s.Get(99)
+	testHelpers.Equal(t, errors.Is(err, ErrNoRecord), true)
+
+	// 10 magic points, 50 sanity
+	characterId, err := ch.Insert(core.Character{
+		Info:       core.CharacterInfo{Name: "Otto Hightower"},
+		Attributes: core.CharacterAttributes{MA: 50},
+	}, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = s.Cast(core.SpellCasting{CharacterID: characterId, Spell: elderSign})
+	testHelpers.Equal(t, errors.Is(err, ErrNoRecord), true)
+
+	for _, spell := range spells {
+		err = s.Learn(characterId, spell.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	err = s.Learn(characterId, elderSign.ID)
+	testHelpers.Equal(t, errors.Is(err, ErrAlreadyKnown), true)
+
+	character, err := ch.Get(characterId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testHelpers.Equal(t, len(character.Spells), 2)
+	_, ok := character.KnownSpell(elderSign.ID)
+	testHelpers.Equal(t, ok, true)
+
+	err = s.Cast(core.SpellCasting{CharacterID: characterId, Spell: elderSign, Sanity: core.SanityCost{Lost: 6, Temporary: true}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = s.Cast(core.SpellCasting{CharacterID: characterId, Spell: elderSign, Sanity: core.SanityCost{Lost: 6}})
+	testHelpers.Equal(t, errors.Is(err, ErrNotEnoughMagic), true)
+
+	character, err = ch.Get(characterId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testHelpers.Equal(t, character.Stats.MP, 0)
+	testHelpers.Equal(t, character.Stats.STA, 44)
+	testHelpers.Equal(t, character.Stats.SanityLossToday, 6)
+	testHelpers.Equal(t, character.Stats.TemporaryInsanity, true)
+}
@@ -0,0 +1,167 @@
+package models
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/winik100/NoPenNoPaper/internal/core"
+)
+
+type TomeModelInterface interface {
+	Get(tomeId int) (core.Tome, error)
+	GetAll() ([]core.Tome, error)
+	Acquire(characterId, tomeId int) error
+	Study(study core.TomeStudy) (int, error)
+}
+
+const tomeColumns = "t.id, t.name, t.language, t.mythos_gain, t.san_loss, t.study_weeks"
+
+func scanTome(row rowScanner) (core.Tome, error) {
+	var tome core.Tome
+	err := row.Scan(&tome.ID, &tome.Name, &tome.Language, &tome.MythosGain, &tome.SANLoss, &tome.StudyWeeks)
+	if err != nil {
+		return core.Tome{}, err
+	}
+	return tome, nil
+}
+
+// the tomes owned by a character, ordered by name
+func getOwnedTomes(db *sql.DB, characterId int) ([]core.Tome, error) {
+	stmt := "SELECT " + tomeColumns + `, ct.studied FROM character_tomes AS ct JOIN tomes AS t ON ct.tome_id = t.id
+	WHERE ct.character_id=? ORDER BY t.name;`
+	rows, err := db.Query(stmt, characterId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tomes []core.Tome
+	for rows.Next() {
+		var tome core.Tome
+		err = rows.Scan(&tome.ID, &tome.Name, &tome.Language, &tome.MythosGain, &tome.SANLoss, &tome.StudyWeeks, &tome.Studied)
+		if err != nil {
+			return nil, err
+		}
+		tomes = append(tomes, tome)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return tomes, nil
+}
+
+type TomeModel struct {
+	DB *sql.DB
+}
+
+func (t *TomeModel) Get(tomeId int) (core.Tome, error) {
+	stmt := "SELECT " + tomeColumns + " FROM tomes AS t WHERE t.id=?;"
+	tome, err := scanTome(t.DB.QueryRow(stmt, tomeId))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return core.Tome{}, ErrNoRecord
+		}
+		return core.Tome{}, err
+	}
+	return tome, nil
+}
+
+// the whole catalog, ordered by name
+func (t *TomeModel) GetAll() ([]core.Tome, error) {
+	stmt := "SELECT " + tomeColumns + " FROM tomes AS t ORDER BY t.name;"
+	rows, err := t.DB.Query(stmt)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tomes []core.Tome
+	for rows.Next() {
+		tome, err := scanTome(rows)
+		if err != nil {
+			return nil, err
+		}
+		tomes = append(tomes, tome)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return tomes, nil
+}
+
+func (t *TomeModel) Acquire(characterId, tomeId int) error {
+	var exists bool
+	stmt := "SELECT EXISTS(SELECT true FROM character_tomes WHERE character_id=? AND tome_id=?);"
+	err := t.DB.QueryRow(stmt, characterId, tomeId).Scan(&exists)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return ErrAlreadyOwned
+	}
+
+	stmt = "INSERT INTO character_tomes (character_id, tome_id) VALUES (?,?);"
+	_, err = t.DB.Exec(stmt, characterId, tomeId)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// marks the owned tome as studied, raises the character's Cthulhu-Mythos by its gain and deducts the
+// sanity lost, lowering maximum sanity to match the new Cthulhu-Mythos. returns the new Cthulhu-Mythos.
+func (t *TomeModel) Study(study core.TomeStudy) (int, error) {
+	tx, err := t.DB.Begin()
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	var studied bool
+	stmt := "SELECT studied FROM character_tomes WHERE character_id=? AND tome_id=? FOR UPDATE;"
+	err = tx.QueryRow(stmt, study.CharacterID, study.Tome.ID).Scan(&studied)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, ErrNoRecord
+		}
+		return 0, err
+	}
+	if studied {
+		return 0, ErrAlreadyStudied
+	}
+
+	stmt = "UPDATE character_tomes SET studied=true WHERE character_id=? AND tome_id=?;"
+	_, err = tx.Exec(stmt, study.CharacterID, study.Tome.ID)
+	if err != nil {
+		return 0, err
+	}
+
+	stmt = `INSERT INTO character_skills (character_id, skill_name, value) VALUES (?, ?, ?)
+	ON DUPLICATE KEY UPDATE value=LEAST(value+VALUES(value), ?);`
+	_, err = tx.Exec(stmt, study.CharacterID, core.CthulhuMythos, study.Tome.MythosGain, core.SanityLimit)
+	if err != nil {
+		return 0, err
+	}
+
+	var mythos int
+	stmt = "SELECT value FROM character_skills WHERE character_id=? AND skill_name=?;"
+	err = tx.QueryRow(stmt, study.CharacterID, core.CthulhuMythos).Scan(&mythos)
+	if err != nil {
+		return 0, err
+	}
+
+	maxSanity := max(core.SanityLimit-mythos, 0)
+	stmt = `UPDATE character_stats SET sta=LEAST(GREATEST(sta-?, 0), ?), maxsta=LEAST(maxsta, ?), san_loss_today=san_loss_today+?,
+		temp_insane=(temp_insane OR ?), indef_insane=(indef_insane OR ?) WHERE character_id=?;`
+	_, err = tx.Exec(stmt, study.Sanity.Lost, maxSanity, maxSanity, study.Sanity.Lost,
+		study.Sanity.Temporary, study.Sanity.Indefinite, study.CharacterID)
+	if err != nil {
+		return 0, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return 0, err
+	}
+	return mythos, nil
+}
@@ -0,0 +1,98 @@
+package models
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/winik100/NoPenNoPaper/internal/core"
+	"github.com/winik100/NoPenNoPaper/internal/testHelpers"
+)
+
+func TestTomes(t *testing.T) {
+	db := newTestDB(t)
+
+	ch := CharacterModel{db}
+	tm := TomeModel{db}
+
+	tomes, err := tm.GetAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	testHelpers.Equal(t, len(tomes), 2)
+	testHelpers.Equal(t, tomes[0].Name, "Necronomicon (lateinisch)")
+
+	necronomicon, err := tm.Get(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testHelpers.Equal(t, necronomicon.MythosGain, 12)
+	testHelpers.Equal(t, necronomicon.StudyWeeks, 66)
+
+	_, err = tm.Get(99)
+	testHelpers.Equal(t, errors.Is(err, ErrNoRecord), true)
+
+	// 95 power, but 5 Cthulhu-Mythos already limit sanity to 94
+	characterId, err := ch.Insert(core.Character{
+		Info:       core.CharacterInfo{Name: "Otto Hightower"},
+		Attributes: core.CharacterAttributes{MA: 95},
+		Skills:     core.Skills{Name: []string{core.CthulhuMythos}, Value: []int{5}},
+	}, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// no Cthulhu-Mythos at all yet
+	otherId, err := ch.Insert(core.Character{
+		Info:       core.CharacterInfo{Name: "Viserys Targaryen"},
+		Attributes: core.CharacterAttributes{MA: 60},
+	}, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = tm.Study(core.TomeStudy{CharacterID: characterId, Tome: necronomicon})
+	testHelpers.Equal(t, errors.Is(err, ErrNoRecord), true)
+
+	for _, id := range []int{characterId, otherId} {
+		err = tm.Acquire(id, necronomicon.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	err = tm.Acquire(characterId, necronomicon.ID)
+	testHelpers.Equal(t, errors.Is(err, ErrAlreadyOwned), true)
+
+	mythos, err := tm.Study(core.TomeStudy{CharacterID: characterId, Tome: necronomicon, Sanity: core.SanityCost{Lost: 10}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	testHelpers.Equal(t, mythos, 17)
+	_, err = tm.Study(core.TomeStudy{CharacterID: characterId, Tome: necronomicon})
+	testHelpers.Equal(t, errors.Is(err, ErrAlreadyStudied), true)
+
+	character, err := ch.Get(characterId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tome, ok := character.OwnedTome(necronomicon.ID)
+	testHelpers.Equal(t, ok, true)
+	testHelpers.Equal(t, tome.Studied, true)
+	testHelpers.Equal(t, character.MaxSanity(), 82)
+	testHelpers.Equal(t, character.Stats.MaxSTA, 82)
+	testHelpers.Equal(t, character.Stats.STA, 82)
+	testHelpers.Equal(t, character.Stats.SanityLossToday, 10)
+
+	mythos, err = tm.Study(core.TomeStudy{CharacterID: otherId, Tome: necronomicon, Sanity: core.SanityCost{Lost: 3}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	testHelpers.Equal(t, mythos, 12)
+
+	other, err := ch.Get(otherId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	value, _ := other.RollTarget(core.CthulhuMythos)
+	testHelpers.Equal(t, value, 12)
+	testHelpers.Equal(t, other.Stats.MaxSTA, 60)
+	testHelpers.Equal(t, other.Stats.STA, 57)
+}
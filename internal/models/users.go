@@ -61,7 +61,7 @@ func (u *UserModel) Get(name string) (core.User, error) {
 
 	// stmt = `SELECT u.id, u.hashed_password, u.role, m.title, m.file_name FROM users AS u LEFT JOIN materials AS m ON u.id = m.uploaded_by WHERE u.name = ?;`
 
-	stmt = "SELECT title, file_name FROM materials WHERE uploaded_by=?;"
+	stmt = "SELECT id, title, file_name FROM materials WHERE uploaded_by=?;"
 	rows, err := u.DB.Query(stmt, user.ID)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -70,19 +70,23 @@ func (u *UserModel) Get(name string) (core.User, error) {
 		return core.User{}, err
 	}
 
+	var ids []int
 	var titles, fileNames []string
 	for rows.Next() {
+		var id int
 		var title string
 		var fileName string
-		err = rows.Scan(&title, &fileName)
+		err = rows.Scan(&id, &title, &fileName)
 		if err != nil {
 			return core.User{}, err
 		}
+		ids = append(ids, id)
 		titles = append(titles, title)
 		fileNames = append(fileNames, fileName)
 	}
 
 	user.Materials = core.Materials{
+		ID:       ids,
 		Title:    titles,
 		FileName: fileNames,
 	}
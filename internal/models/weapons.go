@@ -0,0 +1,117 @@
+package models
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/winik100/NoPenNoPaper/internal/core"
+)
+
+type WeaponModelInterface interface {
+	Get(weaponId int) (core.Weapon, error)
+	GetAll() ([]core.Weapon, error)
+	Carry(characterId, weaponId int) error
+	Drop(characterId, weaponId int) error
+}
+
+const weaponColumns = "w.id, w.name, w.skill, w.damage, w.weapon_range, w.attacks, w.ammo, w.malfunction"
+
+func scanWeapon(row rowScanner) (core.Weapon, error) {
+	var weapon core.Weapon
+	err := row.Scan(&weapon.ID, &weapon.Name, &weapon.Skill, &weapon.Damage, &weapon.Range, &weapon.Attacks, &weapon.Ammo, &weapon.Malfunction)
+	if err != nil {
+		return core.Weapon{}, err
+	}
+	return weapon, nil
+}
+
+// the weapons carried by a character, ordered by name
+func getCarriedWeapons(db *sql.DB, characterId int) ([]core.Weapon, error) {
+	stmt := "SELECT " + weaponColumns + ` FROM character_weapons AS cw JOIN weapons AS w ON cw.weapon_id = w.id
+	WHERE cw.character_id=? ORDER BY w.name;`
+	rows, err := db.Query(stmt, characterId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var weapons []core.Weapon
+	for rows.Next() {
+		weapon, err := scanWeapon(rows)
+		if err != nil {
+			return nil, err
+		}
+		weapons = append(weapons, weapon)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return weapons, nil
+}
+
+type WeaponModel struct {
+	DB *sql.DB
+}
+
+func (w *WeaponModel) Get(weaponId int) (core.Weapon, error) {
+	stmt := "SELECT " + weaponColumns + " FROM weapons AS w WHERE w.id=?;"
+	weapon, err := scanWeapon(w.DB.QueryRow(stmt, weaponId))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return core.Weapon{}, ErrNoRecord
+		}
+		return core.Weapon{}, err
+	}
+	return weapon, nil
+}
+
+// the whole catalog, ordered by skill and name
+func (w *WeaponModel) GetAll() ([]core.Weapon, error) {
+	stmt := "SELECT " + weaponColumns + " FROM weapons AS w ORDER BY w.skill, w.name;"
+	rows, err := w.DB.Query(stmt)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var weapons []core.Weapon
+	for rows.Next() {
+		weapon, err := scanWeapon(rows)
+		if err != nil {
+			return nil, err
+		}
+		weapons = append(weapons, weapon)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return weapons, nil
+}
+
+func (w *WeaponModel) Carry(characterId, weaponId int) error {
+	var exists bool
+	stmt := "SELECT EXISTS(SELECT true FROM character_weapons WHERE character_id=? AND weapon_id=?);"
+	err := w.DB.QueryRow(stmt, characterId, weaponId).Scan(&exists)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return ErrAlreadyCarried
+	}
+
+	stmt = "INSERT INTO character_weapons (character_id, weapon_id) VALUES (?,?);"
+	_, err = w.DB.Exec(stmt, characterId, weaponId)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (w *WeaponModel) Drop(characterId, weaponId int) error {
+	stmt := "DELETE FROM character_weapons WHERE character_id=? AND weapon_id=?;"
+	_, err := w.DB.Exec(stmt, characterId, weaponId)
+	if err != nil {
+		return err
+	}
+	return nil
+}
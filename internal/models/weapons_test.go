@@ -0,0 +1,73 @@
+package models
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/winik100/NoPenNoPaper/internal/core"
+	"github.com/winik100/NoPenNoPaper/internal/testHelpers"
+)
+
+func TestWeapons(t *testing.T) {
+	db := newTestDB(t)
+
+	ch := CharacterModel{db}
+	w := WeaponModel{db}
+
+	weapons, err := w.GetAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	testHelpers.Equal(t, len(weapons), 2)
+	testHelpers.Equal(t, weapons[0].Name, "Messer")
+
+	revolver, err := w.Get(2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testHelpers.Equal(t, revolver.Skill, "Schusswaffen (Faustfeuerwaffen)")
+	testHelpers.Equal(t, revolver.IsFirearm(), true)
+	testHelpers.Equal(t, revolver.Malfunctions(100), true)
+	testHelpers.Equal(t, revolver.Malfunctions(99), false)
+
+	_, err = w.Get(99)
+	testHelpers.Equal(t, errors.Is(err, ErrNoRecord), true)
+
+	characterId, err := ch.Insert(core.Character{
+		Info:       core.CharacterInfo{Name: "Otto Hightower"},
+		Attributes: core.CharacterAttributes{ST: 80, GR: 80},
+	}, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, weapon := range weapons {
+		err = w.Carry(characterId, weapon.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	err = w.Carry(characterId, revolver.ID)
+	testHelpers.Equal(t, errors.Is(err, ErrAlreadyCarried), true)
+
+	err = w.Drop(characterId, revolver.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	character, err := ch.Get(characterId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testHelpers.Equal(t, len(character.Weapons), 1)
+	knife, ok := character.CarriedWeapon(weapons[0].ID)
+	testHelpers.Equal(t, ok, true)
+
+	// 1d4 plus the damage bonus of 1d4 for ST+GR 160
+	damage, err := knife.RollDamage(character.Attributes.DB)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testHelpers.Equal(t, len(damage.Dice), 2)
+	testHelpers.Equal(t, damage.Total, damage.Dice[0]+damage.Dice[1])
+}
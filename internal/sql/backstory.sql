@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS backstory (
+	id INTEGER NOT NULL PRIMARY KEY AUTO_INCREMENT,
+	character_id INTEGER NOT NULL,
+	section VARCHAR(20) NOT NULL,
+	text VARCHAR(500) NOT NULL,
+	key_connection BOOLEAN NOT NULL DEFAULT FALSE,
+	CONSTRAINT fk_character_backstory FOREIGN KEY (character_id) REFERENCES characters(id) ON DELETE CASCADE
+);
@@ -0,0 +1,42 @@
+CREATE TABLE IF NOT EXISTS campaigns (
+	id INTEGER NOT NULL PRIMARY KEY AUTO_INCREMENT,
+	title VARCHAR(50) NOT NULL,
+	created_by INTEGER NOT NULL,
+	era VARCHAR(20) NOT NULL DEFAULT 'classic',
+	FOREIGN KEY (created_by) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS campaign_members (
+	campaign_id INTEGER NOT NULL,
+	user_id INTEGER NOT NULL,
+	CONSTRAINT fk_campaign_cm FOREIGN KEY (campaign_id) REFERENCES campaigns(id) ON DELETE CASCADE,
+	CONSTRAINT fk_user_cm FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+	CONSTRAINT pk_campaign_members PRIMARY KEY (campaign_id, user_id)
+);
+
+CREATE TABLE IF NOT EXISTS campaign_invites (
+	code CHAR(22) NOT NULL PRIMARY KEY,
+	campaign_id INTEGER NOT NULL,
+	expires DATETIME,
+	single_use BOOLEAN NOT NULL,
+	used BOOLEAN NOT NULL DEFAULT FALSE,
+	CONSTRAINT fk_campaign_ci FOREIGN KEY (campaign_id) REFERENCES campaigns(id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS handouts (
+	id INTEGER NOT NULL PRIMARY KEY AUTO_INCREMENT,
+	material_id INTEGER NOT NULL,
+	campaign_id INTEGER NOT NULL,
+	revealed_to INTEGER,
+	CONSTRAINT fk_material_handouts FOREIGN KEY (material_id) REFERENCES materials(id) ON DELETE CASCADE,
+	CONSTRAINT fk_campaign_handouts FOREIGN KEY (campaign_id) REFERENCES campaigns(id) ON DELETE CASCADE,
+	CONSTRAINT fk_user_handouts FOREIGN KEY (revealed_to) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS campaign_table (
+	campaign_id INTEGER NOT NULL,
+	character_id INTEGER NOT NULL,
+	CONSTRAINT fk_campaign_ct FOREIGN KEY (campaign_id) REFERENCES campaigns(id) ON DELETE CASCADE,
+	CONSTRAINT fk_character_ct FOREIGN KEY (character_id) REFERENCES characters(id) ON DELETE CASCADE,
+	CONSTRAINT pk_campaign_table PRIMARY KEY (campaign_id, character_id)
+);
@@ -1,7 +1,12 @@
 CREATE TABLE IF NOT EXISTS characters (
 	id INTEGER NOT NULL PRIMARY KEY AUTO_INCREMENT,
 	created_by INTEGER NOT NULL,
-	FOREIGN KEY (created_by) REFERENCES users(id) ON DELETE CASCADE
+	campaign_id INTEGER,
+	kind VARCHAR(12) NOT NULL DEFAULT 'investigator',
+	track_tp BOOLEAN NOT NULL DEFAULT TRUE,
+	track_sta BOOLEAN NOT NULL DEFAULT TRUE,
+	FOREIGN KEY (created_by) REFERENCES users(id) ON DELETE CASCADE,
+	FOREIGN KEY (campaign_id) REFERENCES campaigns(id) ON DELETE SET NULL
 );
 
 CREATE TABLE IF NOT EXISTS character_info (
@@ -26,6 +31,8 @@ CREATE TABLE IF NOT EXISTS character_attributes (
 	gr INTEGER NOT NULL,
 	i INTEGER NOT NULL,
 	bw INTEGER NOT NULL,
+	db VARCHAR(10) NOT NULL DEFAULT '0',
+	build INTEGER NOT NULL DEFAULT 0,
 	FOREIGN KEY (character_id) REFERENCES characters(id) ON DELETE CASCADE
 );
 
@@ -39,6 +46,13 @@ CREATE TABLE IF NOT EXISTS character_stats (
 	mp INTEGER NOT NULL,
 	maxluck INTEGER NOT NULL,
 	luck INTEGER NOT NULL,
+	san_loss_today INTEGER NOT NULL DEFAULT 0,
+	temp_insane BOOLEAN NOT NULL DEFAULT FALSE,
+	indef_insane BOOLEAN NOT NULL DEFAULT FALSE,
+	major_wound BOOLEAN NOT NULL DEFAULT FALSE,
+	unconscious BOOLEAN NOT NULL DEFAULT FALSE,
+	dying BOOLEAN NOT NULL DEFAULT FALSE,
+	dead BOOLEAN NOT NULL DEFAULT FALSE,
 	FOREIGN KEY (character_id) REFERENCES characters(id) ON DELETE CASCADE
 ); 
 
@@ -51,6 +65,7 @@ CREATE TABLE IF NOT EXISTS character_skills (
 	character_id INTEGER NOT NULL,
 	skill_name VARCHAR(50) NOT NULL,
 	value INTEGER NOT NULL,
+	used BOOLEAN NOT NULL DEFAULT FALSE,
 	CONSTRAINT fk_character_cs FOREIGN KEY (character_id) REFERENCES characters(id) ON DELETE CASCADE,
 	CONSTRAINT fk_skill_cs FOREIGN KEY (skill_name) REFERENCES skills(name),
 	CONSTRAINT pk_character_skills PRIMARY KEY (character_id, skill_name)
@@ -67,6 +82,7 @@ CREATE TABLE IF NOT EXISTS character_custom_skills (
 	character_id INTEGER NOT NULL,
 	custom_skill_name VARCHAR(50) NOT NULL,
 	value INTEGER NOT NULL,
+	used BOOLEAN NOT NULL DEFAULT FALSE,
 	CONSTRAINT fk_character_ccs FOREIGN KEY (character_id) REFERENCES characters(id) ON DELETE CASCADE,
 	CONSTRAINT fk_custom_skill_ccs FOREIGN KEY (custom_skill_name) REFERENCES custom_skills(name),
 	CONSTRAINT pk_character_custom_skills PRIMARY KEY (character_id, custom_skill_name)
@@ -87,4 +103,4 @@ CREATE TABLE IF NOT EXISTS notes (
 	character_id INTEGER NOT NULL,
 	text VARCHAR(255) NOT NULL,
 	FOREIGN KEY (character_id) REFERENCES characters(id) ON DELETE CASCADE
-);
\ No newline at end of file
+);
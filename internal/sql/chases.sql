@@ -0,0 +1,27 @@
+CREATE TABLE IF NOT EXISTS chases (
+	campaign_id INTEGER NOT NULL PRIMARY KEY,
+	round INTEGER NOT NULL DEFAULT 1,
+	CONSTRAINT fk_campaign_chase FOREIGN KEY (campaign_id) REFERENCES campaigns(id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS chase_participants (
+	campaign_id INTEGER NOT NULL,
+	character_id INTEGER NOT NULL,
+	role VARCHAR(12) NOT NULL,
+	mov INTEGER NOT NULL,
+	position INTEGER NOT NULL DEFAULT 0,
+	actions INTEGER NOT NULL DEFAULT 0,
+	CONSTRAINT fk_chase_chp FOREIGN KEY (campaign_id) REFERENCES chases(campaign_id) ON DELETE CASCADE,
+	CONSTRAINT fk_character_chp FOREIGN KEY (character_id) REFERENCES characters(id) ON DELETE CASCADE,
+	CONSTRAINT pk_chase_participants PRIMARY KEY (campaign_id, character_id)
+);
+
+CREATE TABLE IF NOT EXISTS chase_locations (
+	campaign_id INTEGER NOT NULL,
+	position INTEGER NOT NULL,
+	name VARCHAR(255) NOT NULL DEFAULT '',
+	obstacle VARCHAR(12) NOT NULL DEFAULT '',
+	skill VARCHAR(255) NOT NULL DEFAULT '',
+	CONSTRAINT fk_chase_chl FOREIGN KEY (campaign_id) REFERENCES chases(campaign_id) ON DELETE CASCADE,
+	CONSTRAINT pk_chase_locations PRIMARY KEY (campaign_id, position)
+);
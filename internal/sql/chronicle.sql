@@ -0,0 +1,32 @@
+CREATE TABLE IF NOT EXISTS chronicle_entries (
+	id INTEGER NOT NULL PRIMARY KEY AUTO_INCREMENT,
+	campaign_id INTEGER NOT NULL,
+	played_on DATE NOT NULL,
+	title VARCHAR(100) NOT NULL,
+	body TEXT NOT NULL,
+	CONSTRAINT fk_campaign_ce FOREIGN KEY (campaign_id) REFERENCES campaigns(id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS chronicle_characters (
+	entry_id INTEGER NOT NULL,
+	character_id INTEGER NOT NULL,
+	CONSTRAINT fk_entry_cc FOREIGN KEY (entry_id) REFERENCES chronicle_entries(id) ON DELETE CASCADE,
+	CONSTRAINT fk_character_cc FOREIGN KEY (character_id) REFERENCES characters(id) ON DELETE CASCADE,
+	CONSTRAINT pk_chronicle_characters PRIMARY KEY (entry_id, character_id)
+);
+
+CREATE TABLE IF NOT EXISTS chronicle_handouts (
+	entry_id INTEGER NOT NULL,
+	handout_id INTEGER NOT NULL,
+	CONSTRAINT fk_entry_ch FOREIGN KEY (entry_id) REFERENCES chronicle_entries(id) ON DELETE CASCADE,
+	CONSTRAINT fk_handout_ch FOREIGN KEY (handout_id) REFERENCES handouts(id) ON DELETE CASCADE,
+	CONSTRAINT pk_chronicle_handouts PRIMARY KEY (entry_id, handout_id)
+);
+
+CREATE TABLE IF NOT EXISTS chronicle_notes (
+	entry_id INTEGER NOT NULL,
+	note_id INTEGER NOT NULL,
+	CONSTRAINT fk_entry_cn FOREIGN KEY (entry_id) REFERENCES chronicle_entries(id) ON DELETE CASCADE,
+	CONSTRAINT fk_note_cn FOREIGN KEY (note_id) REFERENCES notes(note_id) ON DELETE CASCADE,
+	CONSTRAINT pk_chronicle_notes PRIMARY KEY (entry_id, note_id)
+);
@@ -0,0 +1,21 @@
+CREATE TABLE IF NOT EXISTS combats (
+	campaign_id INTEGER NOT NULL PRIMARY KEY,
+	round INTEGER NOT NULL DEFAULT 1,
+	current_id INTEGER,
+	target_id INTEGER,
+	CONSTRAINT fk_campaign_co FOREIGN KEY (campaign_id) REFERENCES campaigns(id) ON DELETE CASCADE,
+	CONSTRAINT fk_current_co FOREIGN KEY (current_id) REFERENCES characters(id) ON DELETE SET NULL,
+	CONSTRAINT fk_target_co FOREIGN KEY (target_id) REFERENCES characters(id) ON DELETE SET NULL
+);
+
+CREATE TABLE IF NOT EXISTS combat_participants (
+	campaign_id INTEGER NOT NULL,
+	character_id INTEGER NOT NULL,
+	readied_firearm BOOLEAN NOT NULL DEFAULT FALSE,
+	readied_next_round BOOLEAN NOT NULL DEFAULT FALSE,
+	tie_break INTEGER NOT NULL,
+	defended_round INTEGER NOT NULL DEFAULT 0,
+	CONSTRAINT fk_combat_cp FOREIGN KEY (campaign_id) REFERENCES combats(campaign_id) ON DELETE CASCADE,
+	CONSTRAINT fk_character_cp FOREIGN KEY (character_id) REFERENCES characters(id) ON DELETE CASCADE,
+	CONSTRAINT pk_combat_participants PRIMARY KEY (campaign_id, character_id)
+);
@@ -33,11 +33,52 @@ CREATE TABLE IF NOT EXISTS materials (
     CONSTRAINT unique_filename_user UNIQUE (file_name, uploaded_by)
 );
 
+-- campaigns.sql
+CREATE TABLE IF NOT EXISTS campaigns (
+	id INTEGER NOT NULL PRIMARY KEY AUTO_INCREMENT,
+	title VARCHAR(50) NOT NULL,
+	created_by INTEGER NOT NULL,
+	era VARCHAR(20) NOT NULL DEFAULT 'classic',
+	FOREIGN KEY (created_by) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS campaign_members (
+	campaign_id INTEGER NOT NULL,
+	user_id INTEGER NOT NULL,
+	CONSTRAINT fk_campaign_cm FOREIGN KEY (campaign_id) REFERENCES campaigns(id) ON DELETE CASCADE,
+	CONSTRAINT fk_user_cm FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+	CONSTRAINT pk_campaign_members PRIMARY KEY (campaign_id, user_id)
+);
+
+CREATE TABLE IF NOT EXISTS campaign_invites (
+	code CHAR(22) NOT NULL PRIMARY KEY,
+	campaign_id INTEGER NOT NULL,
+	expires DATETIME,
+	single_use BOOLEAN NOT NULL,
+	used BOOLEAN NOT NULL DEFAULT FALSE,
+	CONSTRAINT fk_campaign_ci FOREIGN KEY (campaign_id) REFERENCES campaigns(id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS handouts (
+	id INTEGER NOT NULL PRIMARY KEY AUTO_INCREMENT,
+	material_id INTEGER NOT NULL,
+	campaign_id INTEGER NOT NULL,
+	revealed_to INTEGER,
+	CONSTRAINT fk_material_handouts FOREIGN KEY (material_id) REFERENCES materials(id) ON DELETE CASCADE,
+	CONSTRAINT fk_campaign_handouts FOREIGN KEY (campaign_id) REFERENCES campaigns(id) ON DELETE CASCADE,
+	CONSTRAINT fk_user_handouts FOREIGN KEY (revealed_to) REFERENCES users(id) ON DELETE CASCADE
+);
+
 -- characters.sql
 CREATE TABLE IF NOT EXISTS characters (
 	id INTEGER NOT NULL PRIMARY KEY AUTO_INCREMENT,
 	created_by INTEGER NOT NULL,
-	FOREIGN KEY (created_by) REFERENCES users(id) ON DELETE CASCADE
+	campaign_id INTEGER,
+	kind VARCHAR(12) NOT NULL DEFAULT 'investigator',
+	track_tp BOOLEAN NOT NULL DEFAULT TRUE,
+	track_sta BOOLEAN NOT NULL DEFAULT TRUE,
+	FOREIGN KEY (created_by) REFERENCES users(id) ON DELETE CASCADE,
+	FOREIGN KEY (campaign_id) REFERENCES campaigns(id) ON DELETE SET NULL
 );
 
 CREATE TABLE IF NOT EXISTS character_info (
@@ -62,6 +103,8 @@ CREATE TABLE IF NOT EXISTS character_attributes (
 	gr INTEGER NOT NULL,
 	i INTEGER NOT NULL,
 	bw INTEGER NOT NULL,
+	db VARCHAR(10) NOT NULL DEFAULT '0',
+	build INTEGER NOT NULL DEFAULT 0,
 	FOREIGN KEY (character_id) REFERENCES characters(id) ON DELETE CASCADE
 );
 
@@ -75,6 +118,13 @@ CREATE TABLE IF NOT EXISTS character_stats (
 	mp INTEGER NOT NULL,
 	maxluck INTEGER NOT NULL,
 	luck INTEGER NOT NULL,
+	san_loss_today INTEGER NOT NULL DEFAULT 0,
+	temp_insane BOOLEAN NOT NULL DEFAULT FALSE,
+	indef_insane BOOLEAN NOT NULL DEFAULT FALSE,
+	major_wound BOOLEAN NOT NULL DEFAULT FALSE,
+	unconscious BOOLEAN NOT NULL DEFAULT FALSE,
+	dying BOOLEAN NOT NULL DEFAULT FALSE,
+	dead BOOLEAN NOT NULL DEFAULT FALSE,
 	FOREIGN KEY (character_id) REFERENCES characters(id) ON DELETE CASCADE
 ); 
 
@@ -87,6 +137,7 @@ CREATE TABLE IF NOT EXISTS character_skills (
 	character_id INTEGER NOT NULL,
 	skill_name VARCHAR(50) NOT NULL,
 	value INTEGER NOT NULL,
+	used BOOLEAN NOT NULL DEFAULT FALSE,
 	CONSTRAINT fk_character_cs FOREIGN KEY (character_id) REFERENCES characters(id) ON DELETE CASCADE,
 	CONSTRAINT fk_skill_cs FOREIGN KEY (skill_name) REFERENCES skills(name),
 	CONSTRAINT pk_character_skills PRIMARY KEY (character_id, skill_name)
@@ -102,6 +153,7 @@ CREATE TABLE IF NOT EXISTS character_custom_skills (
 	character_id INTEGER NOT NULL,
 	custom_skill_name VARCHAR(50) NOT NULL,
 	value INTEGER NOT NULL,
+	used BOOLEAN NOT NULL DEFAULT FALSE,
 	CONSTRAINT fk_character_ccs FOREIGN KEY (character_id) REFERENCES characters(id) ON DELETE CASCADE,
 	CONSTRAINT fk_custom_skill_ccs FOREIGN KEY (custom_skill_name) REFERENCES custom_skills(name),
 	CONSTRAINT pk_character_custom_skills PRIMARY KEY (character_id, custom_skill_name)
@@ -124,6 +176,237 @@ CREATE TABLE IF NOT EXISTS notes (
 	FOREIGN KEY (character_id) REFERENCES characters(id) ON DELETE CASCADE
 );
 
+-- campaigns.sql
+CREATE TABLE IF NOT EXISTS campaign_table (
+	campaign_id INTEGER NOT NULL,
+	character_id INTEGER NOT NULL,
+	CONSTRAINT fk_campaign_ct FOREIGN KEY (campaign_id) REFERENCES campaigns(id) ON DELETE CASCADE,
+	CONSTRAINT fk_character_ct FOREIGN KEY (character_id) REFERENCES characters(id) ON DELETE CASCADE,
+	CONSTRAINT pk_campaign_table PRIMARY KEY (campaign_id, character_id)
+);
+
+-- chronicle.sql
+CREATE TABLE IF NOT EXISTS chronicle_entries (
+	id INTEGER NOT NULL PRIMARY KEY AUTO_INCREMENT,
+	campaign_id INTEGER NOT NULL,
+	played_on DATE NOT NULL,
+	title VARCHAR(100) NOT NULL,
+	body TEXT NOT NULL,
+	CONSTRAINT fk_campaign_ce FOREIGN KEY (campaign_id) REFERENCES campaigns(id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS chronicle_characters (
+	entry_id INTEGER NOT NULL,
+	character_id INTEGER NOT NULL,
+	CONSTRAINT fk_entry_cc FOREIGN KEY (entry_id) REFERENCES chronicle_entries(id) ON DELETE CASCADE,
+	CONSTRAINT fk_character_cc FOREIGN KEY (character_id) REFERENCES characters(id) ON DELETE CASCADE,
+	CONSTRAINT pk_chronicle_characters PRIMARY KEY (entry_id, character_id)
+);
+
+CREATE TABLE IF NOT EXISTS chronicle_handouts (
+	entry_id INTEGER NOT NULL,
+	handout_id INTEGER NOT NULL,
+	CONSTRAINT fk_entry_ch FOREIGN KEY (entry_id) REFERENCES chronicle_entries(id) ON DELETE CASCADE,
+	CONSTRAINT fk_handout_ch FOREIGN KEY (handout_id) REFERENCES handouts(id) ON DELETE CASCADE,
+	CONSTRAINT pk_chronicle_handouts PRIMARY KEY (entry_id, handout_id)
+);
+
+CREATE TABLE IF NOT EXISTS chronicle_notes (
+	entry_id INTEGER NOT NULL,
+	note_id INTEGER NOT NULL,
+	CONSTRAINT fk_entry_cn FOREIGN KEY (entry_id) REFERENCES chronicle_entries(id) ON DELETE CASCADE,
+	CONSTRAINT fk_note_cn FOREIGN KEY (note_id) REFERENCES notes(note_id) ON DELETE CASCADE,
+	CONSTRAINT pk_chronicle_notes PRIMARY KEY (entry_id, note_id)
+);
+
+-- combat.sql
+CREATE TABLE IF NOT EXISTS combats (
+	campaign_id INTEGER NOT NULL PRIMARY KEY,
+	round INTEGER NOT NULL DEFAULT 1,
+	current_id INTEGER,
+	target_id INTEGER,
+	CONSTRAINT fk_campaign_co FOREIGN KEY (campaign_id) REFERENCES campaigns(id) ON DELETE CASCADE,
+	CONSTRAINT fk_current_co FOREIGN KEY (current_id) REFERENCES characters(id) ON DELETE SET NULL,
+	CONSTRAINT fk_target_co FOREIGN KEY (target_id) REFERENCES characters(id) ON DELETE SET NULL
+);
+
+CREATE TABLE IF NOT EXISTS combat_participants (
+	campaign_id INTEGER NOT NULL,
+	character_id INTEGER NOT NULL,
+	readied_firearm BOOLEAN NOT NULL DEFAULT FALSE,
+	readied_next_round BOOLEAN NOT NULL DEFAULT FALSE,
+	tie_break INTEGER NOT NULL,
+	defended_round INTEGER NOT NULL DEFAULT 0,
+	CONSTRAINT fk_combat_cp FOREIGN KEY (campaign_id) REFERENCES combats(campaign_id) ON DELETE CASCADE,
+	CONSTRAINT fk_character_cp FOREIGN KEY (character_id) REFERENCES characters(id) ON DELETE CASCADE,
+	CONSTRAINT pk_combat_participants PRIMARY KEY (campaign_id, character_id)
+);
+
+-- rolls.sql
+CREATE TABLE IF NOT EXISTS rolls (
+	id INTEGER NOT NULL PRIMARY KEY AUTO_INCREMENT,
+	character_id INTEGER NOT NULL,
+	name VARCHAR(50) NOT NULL,
+	value INTEGER NOT NULL,
+	bonus INTEGER NOT NULL DEFAULT 0,
+	tens VARCHAR(20) NOT NULL DEFAULT '',
+	result INTEGER NOT NULL,
+	level INTEGER NOT NULL,
+	luck_spent INTEGER NOT NULL DEFAULT 0,
+	rolled_at DATETIME NOT NULL,
+	source VARCHAR(20) NOT NULL DEFAULT '',
+	pushed_from INTEGER,
+	justification VARCHAR(500) NOT NULL DEFAULT '',
+	consequence VARCHAR(1000) NOT NULL DEFAULT '',
+	CONSTRAINT fk_character_rolls FOREIGN KEY (character_id) REFERENCES characters(id) ON DELETE CASCADE,
+	CONSTRAINT fk_pushed_rolls FOREIGN KEY (pushed_from) REFERENCES rolls(id) ON DELETE CASCADE,
+	CONSTRAINT unique_pushed_from UNIQUE (pushed_from)
+);
+
+-- sanity.sql
+CREATE TABLE IF NOT EXISTS sanity_checks (
+	id INTEGER NOT NULL PRIMARY KEY AUTO_INCREMENT,
+	character_id INTEGER NOT NULL,
+	source VARCHAR(255) NOT NULL DEFAULT '',
+	loss VARCHAR(50) NOT NULL,
+	value INTEGER NOT NULL,
+	result INTEGER NOT NULL,
+	level INTEGER NOT NULL,
+	lost INTEGER NOT NULL,
+	temporary BOOLEAN NOT NULL DEFAULT FALSE,
+	indefinite BOOLEAN NOT NULL DEFAULT FALSE,
+	bout VARCHAR(255) NOT NULL DEFAULT '',
+	checked_at DATETIME NOT NULL,
+	CONSTRAINT fk_character_sanity_checks FOREIGN KEY (character_id) REFERENCES characters(id) ON DELETE CASCADE
+);
+
+-- occupations.sql
+CREATE TABLE IF NOT EXISTS occupations (
+	id INTEGER NOT NULL PRIMARY KEY AUTO_INCREMENT,
+	name VARCHAR(50) NOT NULL UNIQUE,
+	skill_points VARCHAR(50) NOT NULL,
+	credit_min INTEGER NOT NULL,
+	credit_max INTEGER NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS occupation_skills (
+	occupation_id INTEGER NOT NULL,
+	skill VARCHAR(50) NOT NULL,
+	CONSTRAINT fk_occupation_os FOREIGN KEY (occupation_id) REFERENCES occupations(id) ON DELETE CASCADE,
+	CONSTRAINT pk_occupation_skills PRIMARY KEY (occupation_id, skill)
+);
+
+-- weapons.sql
+CREATE TABLE IF NOT EXISTS weapons (
+	id INTEGER NOT NULL PRIMARY KEY AUTO_INCREMENT,
+	name VARCHAR(50) NOT NULL UNIQUE,
+	skill VARCHAR(50) NOT NULL,
+	damage VARCHAR(50) NOT NULL,
+	weapon_range VARCHAR(50) NOT NULL,
+	attacks VARCHAR(50) NOT NULL,
+	ammo INTEGER NOT NULL DEFAULT 0,
+	malfunction INTEGER NOT NULL DEFAULT 0,
+	CONSTRAINT fk_skill_weapons FOREIGN KEY (skill) REFERENCES skills(name)
+);
+
+CREATE TABLE IF NOT EXISTS character_weapons (
+	character_id INTEGER NOT NULL,
+	weapon_id INTEGER NOT NULL,
+	CONSTRAINT fk_character_cw FOREIGN KEY (character_id) REFERENCES characters(id) ON DELETE CASCADE,
+	CONSTRAINT fk_weapon_cw FOREIGN KEY (weapon_id) REFERENCES weapons(id) ON DELETE CASCADE,
+	CONSTRAINT pk_character_weapons PRIMARY KEY (character_id, weapon_id)
+);
+
+-- chases.sql
+CREATE TABLE IF NOT EXISTS chases (
+	campaign_id INTEGER NOT NULL PRIMARY KEY,
+	round INTEGER NOT NULL DEFAULT 1,
+	CONSTRAINT fk_campaign_chase FOREIGN KEY (campaign_id) REFERENCES campaigns(id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS chase_participants (
+	campaign_id INTEGER NOT NULL,
+	character_id INTEGER NOT NULL,
+	role VARCHAR(12) NOT NULL,
+	mov INTEGER NOT NULL,
+	position INTEGER NOT NULL DEFAULT 0,
+	actions INTEGER NOT NULL DEFAULT 0,
+	CONSTRAINT fk_chase_chp FOREIGN KEY (campaign_id) REFERENCES chases(campaign_id) ON DELETE CASCADE,
+	CONSTRAINT fk_character_chp FOREIGN KEY (character_id) REFERENCES characters(id) ON DELETE CASCADE,
+	CONSTRAINT pk_chase_participants PRIMARY KEY (campaign_id, character_id)
+);
+
+CREATE TABLE IF NOT EXISTS chase_locations (
+	campaign_id INTEGER NOT NULL,
+	position INTEGER NOT NULL,
+	name VARCHAR(255) NOT NULL DEFAULT '',
+	obstacle VARCHAR(12) NOT NULL DEFAULT '',
+	skill VARCHAR(255) NOT NULL DEFAULT '',
+	CONSTRAINT fk_chase_chl FOREIGN KEY (campaign_id) REFERENCES chases(campaign_id) ON DELETE CASCADE,
+	CONSTRAINT pk_chase_locations PRIMARY KEY (campaign_id, position)
+);
+
+-- mythos.sql
+CREATE TABLE IF NOT EXISTS spells (
+	id INTEGER NOT NULL PRIMARY KEY AUTO_INCREMENT,
+	name VARCHAR(100) NOT NULL UNIQUE,
+	mp_cost INTEGER NOT NULL,
+	san_cost VARCHAR(20) NOT NULL,
+	casting_time VARCHAR(50) NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS character_spells (
+	character_id INTEGER NOT NULL,
+	spell_id INTEGER NOT NULL,
+	CONSTRAINT fk_character_csp FOREIGN KEY (character_id) REFERENCES characters(id) ON DELETE CASCADE,
+	CONSTRAINT fk_spell_csp FOREIGN KEY (spell_id) REFERENCES spells(id) ON DELETE CASCADE,
+	CONSTRAINT pk_character_spells PRIMARY KEY (character_id, spell_id)
+);
+
+CREATE TABLE IF NOT EXISTS tomes (
+	id INTEGER NOT NULL PRIMARY KEY AUTO_INCREMENT,
+	name VARCHAR(100) NOT NULL UNIQUE,
+	language VARCHAR(50) NOT NULL,
+	mythos_gain INTEGER NOT NULL,
+	san_loss VARCHAR(20) NOT NULL,
+	study_weeks INTEGER NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS character_tomes (
+	character_id INTEGER NOT NULL,
+	tome_id INTEGER NOT NULL,
+	studied BOOLEAN NOT NULL DEFAULT FALSE,
+	CONSTRAINT fk_character_cto FOREIGN KEY (character_id) REFERENCES characters(id) ON DELETE CASCADE,
+	CONSTRAINT fk_tome_cto FOREIGN KEY (tome_id) REFERENCES tomes(id) ON DELETE CASCADE,
+	CONSTRAINT pk_character_tomes PRIMARY KEY (character_id, tome_id)
+);
+
+-- ledger.sql
+CREATE TABLE IF NOT EXISTS cash_ledger (
+	id INTEGER NOT NULL PRIMARY KEY AUTO_INCREMENT,
+	character_id INTEGER NOT NULL,
+	description VARCHAR(255) NOT NULL,
+	amount BIGINT NOT NULL,
+	booked_at DATETIME NOT NULL,
+	CONSTRAINT fk_character_ledger FOREIGN KEY (character_id) REFERENCES characters(id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS cash_openings (
+	character_id INTEGER NOT NULL PRIMARY KEY,
+	amount BIGINT NOT NULL,
+	CONSTRAINT fk_character_opening FOREIGN KEY (character_id) REFERENCES characters(id) ON DELETE CASCADE
+);
+
+-- backstory.sql
+CREATE TABLE IF NOT EXISTS backstory (
+	id INTEGER NOT NULL PRIMARY KEY AUTO_INCREMENT,
+	character_id INTEGER NOT NULL,
+	section VARCHAR(20) NOT NULL,
+	text VARCHAR(500) NOT NULL,
+	key_connection BOOLEAN NOT NULL DEFAULT FALSE,
+	CONSTRAINT fk_character_backstory FOREIGN KEY (character_id) REFERENCES characters(id) ON DELETE CASCADE
+);
+
 -- populate.sql
 INSERT INTO skills (name, default_value) VALUES ('Anthropologie', 1),
 			('Archäologie', 1),
@@ -142,6 +425,7 @@ INSERT INTO skills (name, default_value) VALUES ('Anthropologie', 1),
 			('Klettern', 20),
 			('Mechanische Reparaturen', 10),
 			('Medizin', 1),
+			('Nahkampf (Handgemenge)', 25),
 			('Naturkunde', 10),
 			('Okkultismus', 5),
 			('Orientierung', 10),
@@ -150,6 +434,8 @@ INSERT INTO skills (name, default_value) VALUES ('Anthropologie', 1),
 			('Rechtswesen', 5),
 			('Reiten', 5),
 			('Schließtechnik', 1),
+			('Schusswaffen (Faustfeuerwaffen)', 20),
+			('Schusswaffen (Gewehr/Schrotflinte)', 25),
 			('Schweres Gerät', 1),
 			('Schwimmen', 20),
 			('Springen', 20),
@@ -160,4 +446,56 @@ INSERT INTO skills (name, default_value) VALUES ('Anthropologie', 1),
 			('Verborgenes erkennen', 25),
 			('Verkleiden', 5),
 			('Werfen', 20),
-			('Werte schätzen', 5);
\ No newline at end of file
+			('Werte schätzen', 5);
+
+INSERT INTO occupations (id, name, skill_points, credit_min, credit_max) VALUES (1, 'Antiquar', 'BI×4', 30, 70),
+			(2, 'Archäologe', 'BI×4', 10, 40),
+			(3, 'Arzt', 'BI×4', 30, 80),
+			(4, 'Autor', 'BI×4', 9, 30),
+			(5, 'Bibliothekar', 'BI×4', 9, 35),
+			(6, 'Dilettant', 'BI×2+ER×2', 50, 99),
+			(7, 'Journalist', 'BI×4', 9, 30),
+			(8, 'Polizist', 'BI×2+(GE|ST)×2', 9, 30),
+			(9, 'Privatdetektiv', 'BI×2+(GE|ST)×2', 9, 30),
+			(10, 'Professor', 'BI×4', 20, 70);
+
+INSERT INTO occupation_skills (occupation_id, skill) VALUES (1, 'Werte schätzen'), (1, 'Handwerk'), (1, 'Geschichte'), (1, 'Bibliotheksnutzung'),
+			(1, 'Fremdsprache'), (1, 'Verborgenes erkennen'), (1, 'Charme'), (1, 'Finanzkraft'),
+			(2, 'Werte schätzen'), (2, 'Archäologie'), (2, 'Geschichte'), (2, 'Fremdsprache'), (2, 'Bibliotheksnutzung'),
+			(2, 'Verborgenes erkennen'), (2, 'Mechanische Reparaturen'), (2, 'Orientierung'), (2, 'Finanzkraft'),
+			(3, 'Erste Hilfe'), (3, 'Fremdsprache'), (3, 'Medizin'), (3, 'Psychologie'), (3, 'Naturwissenschaft'),
+			(3, 'Psychoanalyse'), (3, 'Finanzkraft'),
+			(4, 'Handwerk'), (4, 'Geschichte'), (4, 'Bibliotheksnutzung'), (4, 'Naturkunde'), (4, 'Okkultismus'),
+			(4, 'Fremdsprache'), (4, 'Muttersprache'), (4, 'Psychologie'), (4, 'Finanzkraft'),
+			(5, 'Buchführung'), (5, 'Bibliotheksnutzung'), (5, 'Fremdsprache'), (5, 'Muttersprache'), (5, 'Finanzkraft'),
+			(6, 'Handwerk'), (6, 'Fremdsprache'), (6, 'Reiten'), (6, 'Charme'), (6, 'Geschichte'), (6, 'Finanzkraft'),
+			(7, 'Handwerk'), (7, 'Geschichte'), (7, 'Bibliotheksnutzung'), (7, 'Muttersprache'), (7, 'Psychologie'),
+			(7, 'Charme'), (7, 'Überreden'), (7, 'Finanzkraft'),
+			(8, 'Erste Hilfe'), (8, 'Rechtswesen'), (8, 'Psychologie'), (8, 'Verborgenes erkennen'), (8, 'Einschüchtern'),
+			(8, 'Autofahren'), (8, 'Nahkampf (Handgemenge)'), (8, 'Schusswaffen (Faustfeuerwaffen)'), (8, 'Finanzkraft'),
+			(9, 'Handwerk'), (9, 'Verkleiden'), (9, 'Rechtswesen'), (9, 'Bibliotheksnutzung'), (9, 'Psychologie'),
+			(9, 'Verborgenes erkennen'), (9, 'Schließtechnik'), (9, 'Schusswaffen (Faustfeuerwaffen)'), (9, 'Überzeugen'), (9, 'Finanzkraft'),
+			(10, 'Bibliotheksnutzung'), (10, 'Fremdsprache'), (10, 'Muttersprache'), (10, 'Psychologie'), (10, 'Überzeugen'),
+			(10, 'Naturwissenschaft'), (10, 'Geschichte'), (10, 'Finanzkraft');
+
+INSERT INTO weapons (id, name, skill, damage, weapon_range, attacks, ammo, malfunction) VALUES (1, 'Faust', 'Nahkampf (Handgemenge)', '1d3+DB', 'Berührung', '1', 0, 0),
+			(2, 'Messer', 'Nahkampf (Handgemenge)', '1d4+DB', 'Berührung', '1', 0, 0),
+			(3, 'Knüppel', 'Nahkampf (Handgemenge)', '1d6+DB', 'Berührung', '1', 0, 0),
+			(4, 'Wurfmesser', 'Werfen', '1d4+½DB', 'ST/5 m', '1', 0, 0),
+			(5, 'Revolver .38', 'Schusswaffen (Faustfeuerwaffen)', '1d10', '15 m', '1 (3)', 6, 100),
+			(6, 'Pistole .45', 'Schusswaffen (Faustfeuerwaffen)', '1d10+2', '15 m', '1 (3)', 7, 100),
+			(7, 'Jagdgewehr .30-06', 'Schusswaffen (Gewehr/Schrotflinte)', '2d6+4', '110 m', '1', 5, 100),
+			(8, 'Schrotflinte Kal. 12', 'Schusswaffen (Gewehr/Schrotflinte)', '4d6', '10 m', '1 oder 2', 2, 100),
+			(9, 'Thompson-MP', 'Schusswaffen (Gewehr/Schrotflinte)', '1d10+2', '20 m', '1 oder Feuerstoß', 20, 96);
+
+INSERT INTO spells (id, name, mp_cost, san_cost, casting_time) VALUES (1, 'Dominieren', 1, '1d4', 'sofort'),
+			(2, 'Glied verdorren lassen', 8, '1d6', '1 Runde'),
+			(3, 'Kontakt zu Ghulen', 8, '1d6', '1 Stunde'),
+			(4, 'Älteres Zeichen erschaffen', 10, '1d10', '1 Tag'),
+			(5, 'Sternenvampir herbeirufen', 10, '1d10', '5 Runden');
+
+INSERT INTO tomes (id, name, language, mythos_gain, san_loss, study_weeks) VALUES (1, 'Necronomicon (lateinisch)', 'Latein', 12, '2d10', 66),
+			(2, 'Unaussprechliche Kulte', 'Deutsch', 9, '2d8', 52),
+			(3, 'De Vermis Mysteriis', 'Latein', 10, '2d6', 48),
+			(4, 'Pnakotische Manuskripte', 'Englisch', 8, '1d10', 45),
+			(5, 'Cthaat Aquadingen', 'Latein', 9, '2d8', 46);
@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS cash_ledger (
+	id INTEGER NOT NULL PRIMARY KEY AUTO_INCREMENT,
+	character_id INTEGER NOT NULL,
+	description VARCHAR(255) NOT NULL,
+	amount BIGINT NOT NULL,
+	booked_at DATETIME NOT NULL,
+	CONSTRAINT fk_character_ledger FOREIGN KEY (character_id) REFERENCES characters(id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS cash_openings (
+	character_id INTEGER NOT NULL PRIMARY KEY,
+	amount BIGINT NOT NULL,
+	CONSTRAINT fk_character_opening FOREIGN KEY (character_id) REFERENCES characters(id) ON DELETE CASCADE
+);
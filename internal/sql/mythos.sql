@@ -0,0 +1,33 @@
+CREATE TABLE IF NOT EXISTS spells (
+	id INTEGER NOT NULL PRIMARY KEY AUTO_INCREMENT,
+	name VARCHAR(100) NOT NULL UNIQUE,
+	mp_cost INTEGER NOT NULL,
+	san_cost VARCHAR(20) NOT NULL,
+	casting_time VARCHAR(50) NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS character_spells (
+	character_id INTEGER NOT NULL,
+	spell_id INTEGER NOT NULL,
+	CONSTRAINT fk_character_csp FOREIGN KEY (character_id) REFERENCES characters(id) ON DELETE CASCADE,
+	CONSTRAINT fk_spell_csp FOREIGN KEY (spell_id) REFERENCES spells(id) ON DELETE CASCADE,
+	CONSTRAINT pk_character_spells PRIMARY KEY (character_id, spell_id)
+);
+
+CREATE TABLE IF NOT EXISTS tomes (
+	id INTEGER NOT NULL PRIMARY KEY AUTO_INCREMENT,
+	name VARCHAR(100) NOT NULL UNIQUE,
+	language VARCHAR(50) NOT NULL,
+	mythos_gain INTEGER NOT NULL,
+	san_loss VARCHAR(20) NOT NULL,
+	study_weeks INTEGER NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS character_tomes (
+	character_id INTEGER NOT NULL,
+	tome_id INTEGER NOT NULL,
+	studied BOOLEAN NOT NULL DEFAULT FALSE,
+	CONSTRAINT fk_character_cto FOREIGN KEY (character_id) REFERENCES characters(id) ON DELETE CASCADE,
+	CONSTRAINT fk_tome_cto FOREIGN KEY (tome_id) REFERENCES tomes(id) ON DELETE CASCADE,
+	CONSTRAINT pk_character_tomes PRIMARY KEY (character_id, tome_id)
+);
@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS occupations (
+	id INTEGER NOT NULL PRIMARY KEY AUTO_INCREMENT,
+	name VARCHAR(50) NOT NULL UNIQUE,
+	skill_points VARCHAR(50) NOT NULL,
+	credit_min INTEGER NOT NULL,
+	credit_max INTEGER NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS occupation_skills (
+	occupation_id INTEGER NOT NULL,
+	skill VARCHAR(50) NOT NULL,
+	CONSTRAINT fk_occupation_os FOREIGN KEY (occupation_id) REFERENCES occupations(id) ON DELETE CASCADE,
+	CONSTRAINT pk_occupation_skills PRIMARY KEY (occupation_id, skill)
+);
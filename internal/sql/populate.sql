@@ -15,6 +15,7 @@ INSERT INTO skills (name, default_value) VALUES ('Anthropologie', 1),
 			('Klettern', 20),
 			('Mechanische Reparaturen', 10),
 			('Medizin', 1),
+			('Nahkampf (Handgemenge)', 25),
 			('Naturkunde', 10),
 			('Okkultismus', 5),
 			('Orientierung', 10),
@@ -23,6 +24,8 @@ INSERT INTO skills (name, default_value) VALUES ('Anthropologie', 1),
 			('Rechtswesen', 5),
 			('Reiten', 5),
 			('Schließtechnik', 1),
+			('Schusswaffen (Faustfeuerwaffen)', 20),
+			('Schusswaffen (Gewehr/Schrotflinte)', 25),
 			('Schweres Gerät', 1),
 			('Schwimmen', 20),
 			('Springen', 20),
@@ -33,4 +36,56 @@ INSERT INTO skills (name, default_value) VALUES ('Anthropologie', 1),
 			('Verborgenes erkennen', 25),
 			('Verkleiden', 5),
 			('Werfen', 20),
-			('Werte schätzen', 5);
\ No newline at end of file
+			('Werte schätzen', 5);
+
+INSERT INTO occupations (id, name, skill_points, credit_min, credit_max) VALUES (1, 'Antiquar', 'BI×4', 30, 70),
+			(2, 'Archäologe', 'BI×4', 10, 40),
+			(3, 'Arzt', 'BI×4', 30, 80),
+			(4, 'Autor', 'BI×4', 9, 30),
+			(5, 'Bibliothekar', 'BI×4', 9, 35),
+			(6, 'Dilettant', 'BI×2+ER×2', 50, 99),
+			(7, 'Journalist', 'BI×4', 9, 30),
+			(8, 'Polizist', 'BI×2+(GE|ST)×2', 9, 30),
+			(9, 'Privatdetektiv', 'BI×2+(GE|ST)×2', 9, 30),
+			(10, 'Professor', 'BI×4', 20, 70);
+
+INSERT INTO occupation_skills (occupation_id, skill) VALUES (1, 'Werte schätzen'), (1, 'Handwerk'), (1, 'Geschichte'), (1, 'Bibliotheksnutzung'),
+			(1, 'Fremdsprache'), (1, 'Verborgenes erkennen'), (1, 'Charme'), (1, 'Finanzkraft'),
+			(2, 'Werte schätzen'), (2, 'Archäologie'), (2, 'Geschichte'), (2, 'Fremdsprache'), (2, 'Bibliotheksnutzung'),
+			(2, 'Verborgenes erkennen'), (2, 'Mechanische Reparaturen'), (2, 'Orientierung'), (2, 'Finanzkraft'),
+			(3, 'Erste Hilfe'), (3, 'Fremdsprache'), (3, 'Medizin'), (3, 'Psychologie'), (3, 'Naturwissenschaft'),
+			(3, 'Psychoanalyse'), (3, 'Finanzkraft'),
+			(4, 'Handwerk'), (4, 'Geschichte'), (4, 'Bibliotheksnutzung'), (4, 'Naturkunde'), (4, 'Okkultismus'),
+			(4, 'Fremdsprache'), (4, 'Muttersprache'), (4, 'Psychologie'), (4, 'Finanzkraft'),
+			(5, 'Buchführung'), (5, 'Bibliotheksnutzung'), (5, 'Fremdsprache'), (5, 'Muttersprache'), (5, 'Finanzkraft'),
+			(6, 'Handwerk'), (6, 'Fremdsprache'), (6, 'Reiten'), (6, 'Charme'), (6, 'Geschichte'), (6, 'Finanzkraft'),
+			(7, 'Handwerk'), (7, 'Geschichte'), (7, 'Bibliotheksnutzung'), (7, 'Muttersprache'), (7, 'Psychologie'),
+			(7, 'Charme'), (7, 'Überreden'), (7, 'Finanzkraft'),
+			(8, 'Erste Hilfe'), (8, 'Rechtswesen'), (8, 'Psychologie'), (8, 'Verborgenes erkennen'), (8, 'Einschüchtern'),
+			(8, 'Autofahren'), (8, 'Nahkampf (Handgemenge)'), (8, 'Schusswaffen (Faustfeuerwaffen)'), (8, 'Finanzkraft'),
+			(9, 'Handwerk'), (9, 'Verkleiden'), (9, 'Rechtswesen'), (9, 'Bibliotheksnutzung'), (9, 'Psychologie'),
+			(9, 'Verborgenes erkennen'), (9, 'Schließtechnik'), (9, 'Schusswaffen (Faustfeuerwaffen)'), (9, 'Überzeugen'), (9, 'Finanzkraft'),
+			(10, 'Bibliotheksnutzung'), (10, 'Fremdsprache'), (10, 'Muttersprache'), (10, 'Psychologie'), (10, 'Überzeugen'),
+			(10, 'Naturwissenschaft'), (10, 'Geschichte'), (10, 'Finanzkraft');
+
+INSERT INTO weapons (id, name, skill, damage, weapon_range, attacks, ammo, malfunction) VALUES (1, 'Faust', 'Nahkampf (Handgemenge)', '1d3+DB', 'Berührung', '1', 0, 0),
+			(2, 'Messer', 'Nahkampf (Handgemenge)', '1d4+DB', 'Berührung', '1', 0, 0),
+			(3, 'Knüppel', 'Nahkampf (Handgemenge)', '1d6+DB', 'Berührung', '1', 0, 0),
+			(4, 'Wurfmesser', 'Werfen', '1d4+½DB', 'ST/5 m', '1', 0, 0),
+			(5, 'Revolver .38', 'Schusswaffen (Faustfeuerwaffen)', '1d10', '15 m', '1 (3)', 6, 100),
+			(6, 'Pistole .45', 'Schusswaffen (Faustfeuerwaffen)', '1d10+2', '15 m', '1 (3)', 7, 100),
+			(7, 'Jagdgewehr .30-06', 'Schusswaffen (Gewehr/Schrotflinte)', '2d6+4', '110 m', '1', 5, 100),
+			(8, 'Schrotflinte Kal. 12', 'Schusswaffen (Gewehr/Schrotflinte)', '4d6', '10 m', '1 oder 2', 2, 100),
+			(9, 'Thompson-MP', 'Schusswaffen (Gewehr/Schrotflinte)', '1d10+2', '20 m', '1 oder Feuerstoß', 20, 96);
+
+INSERT INTO spells (id, name, mp_cost, san_cost, casting_time) VALUES (1, 'Dominieren', 1, '1d4', 'sofort'),
+			(2, 'Glied verdorren lassen', 8, '1d6', '1 Runde'),
+			(3, 'Kontakt zu Ghulen', 8, '1d6', '1 Stunde'),
+			(4, 'Älteres Zeichen erschaffen', 10, '1d10', '1 Tag'),
+			(5, 'Sternenvampir herbeirufen', 10, '1d10', '5 Runden');
+
+INSERT INTO tomes (id, name, language, mythos_gain, san_loss, study_weeks) VALUES (1, 'Necronomicon (lateinisch)', 'Latein', 12, '2d10', 66),
+			(2, 'Unaussprechliche Kulte', 'Deutsch', 9, '2d8', 52),
+			(3, 'De Vermis Mysteriis', 'Latein', 10, '2d6', 48),
+			(4, 'Pnakotische Manuskripte', 'Englisch', 8, '1d10', 45),
+			(5, 'Cthaat Aquadingen', 'Latein', 9, '2d8', 46);
@@ -0,0 +1,19 @@
+CREATE TABLE IF NOT EXISTS rolls (
+	id INTEGER NOT NULL PRIMARY KEY AUTO_INCREMENT,
+	character_id INTEGER NOT NULL,
+	name VARCHAR(50) NOT NULL,
+	value INTEGER NOT NULL,
+	bonus INTEGER NOT NULL DEFAULT 0,
+	tens VARCHAR(20) NOT NULL DEFAULT '',
+	result INTEGER NOT NULL,
+	level INTEGER NOT NULL,
+	luck_spent INTEGER NOT NULL DEFAULT 0,
+	rolled_at DATETIME NOT NULL,
+	source VARCHAR(20) NOT NULL DEFAULT '',
+	pushed_from INTEGER,
+	justification VARCHAR(500) NOT NULL DEFAULT '',
+	consequence VARCHAR(1000) NOT NULL DEFAULT '',
+	CONSTRAINT fk_character_rolls FOREIGN KEY (character_id) REFERENCES characters(id) ON DELETE CASCADE,
+	CONSTRAINT fk_pushed_rolls FOREIGN KEY (pushed_from) REFERENCES rolls(id) ON DELETE CASCADE,
+	CONSTRAINT unique_pushed_from UNIQUE (pushed_from)
+);
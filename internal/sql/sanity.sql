@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS sanity_checks (
+	id INTEGER NOT NULL PRIMARY KEY AUTO_INCREMENT,
+	character_id INTEGER NOT NULL,
+	source VARCHAR(255) NOT NULL DEFAULT '',
+	loss VARCHAR(50) NOT NULL,
+	value INTEGER NOT NULL,
+	result INTEGER NOT NULL,
+	level INTEGER NOT NULL,
+	lost INTEGER NOT NULL,
+	temporary BOOLEAN NOT NULL DEFAULT FALSE,
+	indefinite BOOLEAN NOT NULL DEFAULT FALSE,
+	bout VARCHAR(255) NOT NULL DEFAULT '',
+	checked_at DATETIME NOT NULL,
+	CONSTRAINT fk_character_sanity_checks FOREIGN KEY (character_id) REFERENCES characters(id) ON DELETE CASCADE
+);
@@ -16,12 +16,52 @@ CREATE TABLE IF NOT EXISTS materials (
     CONSTRAINT unique_filename_user UNIQUE (file_name, uploaded_by)
 );
 
-CREATE TABLE IF NOT EXISTS characters (
+CREATE TABLE IF NOT EXISTS campaigns (
 	id INTEGER NOT NULL PRIMARY KEY AUTO_INCREMENT,
+	title VARCHAR(50) NOT NULL,
 	created_by INTEGER NOT NULL,
+	era VARCHAR(20) NOT NULL DEFAULT 'classic',
 	FOREIGN KEY (created_by) REFERENCES users(id) ON DELETE CASCADE
 );
 
+CREATE TABLE IF NOT EXISTS campaign_members (
+	campaign_id INTEGER NOT NULL,
+	user_id INTEGER NOT NULL,
+	CONSTRAINT fk_campaign_cm FOREIGN KEY (campaign_id) REFERENCES campaigns(id) ON DELETE CASCADE,
+	CONSTRAINT fk_user_cm FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+	CONSTRAINT pk_campaign_members PRIMARY KEY (campaign_id, user_id)
+);
+
+CREATE TABLE IF NOT EXISTS campaign_invites (
+	code CHAR(22) NOT NULL PRIMARY KEY,
+	campaign_id INTEGER NOT NULL,
+	expires DATETIME,
+	single_use BOOLEAN NOT NULL,
+	used BOOLEAN NOT NULL DEFAULT FALSE,
+	CONSTRAINT fk_campaign_ci FOREIGN KEY (campaign_id) REFERENCES campaigns(id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS handouts (
+	id INTEGER NOT NULL PRIMARY KEY AUTO_INCREMENT,
+	material_id INTEGER NOT NULL,
+	campaign_id INTEGER NOT NULL,
+	revealed_to INTEGER,
+	CONSTRAINT fk_material_handouts FOREIGN KEY (material_id) REFERENCES materials(id) ON DELETE CASCADE,
+	CONSTRAINT fk_campaign_handouts FOREIGN KEY (campaign_id) REFERENCES campaigns(id) ON DELETE CASCADE,
+	CONSTRAINT fk_user_handouts FOREIGN KEY (revealed_to) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS characters (
+	id INTEGER NOT NULL PRIMARY KEY AUTO_INCREMENT,
+	created_by INTEGER NOT NULL,
+	campaign_id INTEGER,
+	kind VARCHAR(12) NOT NULL DEFAULT 'investigator',
+	track_tp BOOLEAN NOT NULL DEFAULT TRUE,
+	track_sta BOOLEAN NOT NULL DEFAULT TRUE,
+	FOREIGN KEY (created_by) REFERENCES users(id) ON DELETE CASCADE,
+	FOREIGN KEY (campaign_id) REFERENCES campaigns(id) ON DELETE SET NULL
+);
+
 CREATE TABLE IF NOT EXISTS character_info (
 	character_id INTEGER NOT NULL PRIMARY KEY,
 	name VARCHAR(50) NOT NULL,
@@ -44,6 +84,8 @@ CREATE TABLE IF NOT EXISTS character_attributes (
 	gr INTEGER NOT NULL,
 	i INTEGER NOT NULL,
 	bw INTEGER NOT NULL,
+	db VARCHAR(10) NOT NULL DEFAULT '0',
+	build INTEGER NOT NULL DEFAULT 0,
 	FOREIGN KEY (character_id) REFERENCES characters(id) ON DELETE CASCADE
 );
 
@@ -57,6 +99,13 @@ CREATE TABLE IF NOT EXISTS character_stats (
 	mp INTEGER NOT NULL,
 	maxluck INTEGER NOT NULL,
 	luck INTEGER NOT NULL,
+	san_loss_today INTEGER NOT NULL DEFAULT 0,
+	temp_insane BOOLEAN NOT NULL DEFAULT FALSE,
+	indef_insane BOOLEAN NOT NULL DEFAULT FALSE,
+	major_wound BOOLEAN NOT NULL DEFAULT FALSE,
+	unconscious BOOLEAN NOT NULL DEFAULT FALSE,
+	dying BOOLEAN NOT NULL DEFAULT FALSE,
+	dead BOOLEAN NOT NULL DEFAULT FALSE,
 	FOREIGN KEY (character_id) REFERENCES characters(id) ON DELETE CASCADE
 ); 
 
@@ -69,6 +118,7 @@ CREATE TABLE IF NOT EXISTS character_skills (
 	character_id INTEGER NOT NULL,
 	skill_name VARCHAR(50) NOT NULL,
 	value INTEGER NOT NULL,
+	used BOOLEAN NOT NULL DEFAULT FALSE,
 	CONSTRAINT fk_character_cs FOREIGN KEY (character_id) REFERENCES characters(id) ON DELETE CASCADE,
 	CONSTRAINT fk_skill_cs FOREIGN KEY (skill_name) REFERENCES skills(name),
 	CONSTRAINT pk_character_skills PRIMARY KEY (character_id, skill_name)
@@ -85,6 +135,7 @@ CREATE TABLE IF NOT EXISTS character_custom_skills (
 	character_id INTEGER NOT NULL,
 	custom_skill_name VARCHAR(50) NOT NULL,
 	value INTEGER NOT NULL,
+	used BOOLEAN NOT NULL DEFAULT FALSE,
 	CONSTRAINT fk_character_ccs FOREIGN KEY (character_id) REFERENCES characters(id) ON DELETE CASCADE,
 	CONSTRAINT fk_custom_skill_ccs FOREIGN KEY (custom_skill_name) REFERENCES custom_skills(name),
 	CONSTRAINT pk_character_custom_skills PRIMARY KEY (character_id, custom_skill_name)
@@ -107,6 +158,226 @@ CREATE TABLE IF NOT EXISTS notes (
 	FOREIGN KEY (character_id) REFERENCES characters(id) ON DELETE CASCADE
 );
 
+CREATE TABLE IF NOT EXISTS campaign_table (
+	campaign_id INTEGER NOT NULL,
+	character_id INTEGER NOT NULL,
+	CONSTRAINT fk_campaign_ct FOREIGN KEY (campaign_id) REFERENCES campaigns(id) ON DELETE CASCADE,
+	CONSTRAINT fk_character_ct FOREIGN KEY (character_id) REFERENCES characters(id) ON DELETE CASCADE,
+	CONSTRAINT pk_campaign_table PRIMARY KEY (campaign_id, character_id)
+);
+
+CREATE TABLE IF NOT EXISTS chronicle_entries (
+	id INTEGER NOT NULL PRIMARY KEY AUTO_INCREMENT,
+	campaign_id INTEGER NOT NULL,
+	played_on DATE NOT NULL,
+	title VARCHAR(100) NOT NULL,
+	body TEXT NOT NULL,
+	CONSTRAINT fk_campaign_ce FOREIGN KEY (campaign_id) REFERENCES campaigns(id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS chronicle_characters (
+	entry_id INTEGER NOT NULL,
+	character_id INTEGER NOT NULL,
+	CONSTRAINT fk_entry_cc FOREIGN KEY (entry_id) REFERENCES chronicle_entries(id) ON DELETE CASCADE,
+	CONSTRAINT fk_character_cc FOREIGN KEY (character_id) REFERENCES characters(id) ON DELETE CASCADE,
+	CONSTRAINT pk_chronicle_characters PRIMARY KEY (entry_id, character_id)
+);
+
+CREATE TABLE IF NOT EXISTS chronicle_handouts (
+	entry_id INTEGER NOT NULL,
+	handout_id INTEGER NOT NULL,
+	CONSTRAINT fk_entry_ch FOREIGN KEY (entry_id) REFERENCES chronicle_entries(id) ON DELETE CASCADE,
+	CONSTRAINT fk_handout_ch FOREIGN KEY (handout_id) REFERENCES handouts(id) ON DELETE CASCADE,
+	CONSTRAINT pk_chronicle_handouts PRIMARY KEY (entry_id, handout_id)
+);
+
+CREATE TABLE IF NOT EXISTS chronicle_notes (
+	entry_id INTEGER NOT NULL,
+	note_id INTEGER NOT NULL,
+	CONSTRAINT fk_entry_cn FOREIGN KEY (entry_id) REFERENCES chronicle_entries(id) ON DELETE CASCADE,
+	CONSTRAINT fk_note_cn FOREIGN KEY (note_id) REFERENCES notes(note_id) ON DELETE CASCADE,
+	CONSTRAINT pk_chronicle_notes PRIMARY KEY (entry_id, note_id)
+);
+
+CREATE TABLE IF NOT EXISTS combats (
+	campaign_id INTEGER NOT NULL PRIMARY KEY,
+	round INTEGER NOT NULL DEFAULT 1,
+	current_id INTEGER,
+	target_id INTEGER,
+	CONSTRAINT fk_campaign_co FOREIGN KEY (campaign_id) REFERENCES campaigns(id) ON DELETE CASCADE,
+	CONSTRAINT fk_current_co FOREIGN KEY (current_id) REFERENCES characters(id) ON DELETE SET NULL,
+	CONSTRAINT fk_target_co FOREIGN KEY (target_id) REFERENCES characters(id) ON DELETE SET NULL
+);
+
+CREATE TABLE IF NOT EXISTS combat_participants (
+	campaign_id INTEGER NOT NULL,
+	character_id INTEGER NOT NULL,
+	readied_firearm BOOLEAN NOT NULL DEFAULT FALSE,
+	readied_next_round BOOLEAN NOT NULL DEFAULT FALSE,
+	tie_break INTEGER NOT NULL,
+	defended_round INTEGER NOT NULL DEFAULT 0,
+	CONSTRAINT fk_combat_cp FOREIGN KEY (campaign_id) REFERENCES combats(campaign_id) ON DELETE CASCADE,
+	CONSTRAINT fk_character_cp FOREIGN KEY (character_id) REFERENCES characters(id) ON DELETE CASCADE,
+	CONSTRAINT pk_combat_participants PRIMARY KEY (campaign_id, character_id)
+);
+
+CREATE TABLE IF NOT EXISTS rolls (
+	id INTEGER NOT NULL PRIMARY KEY AUTO_INCREMENT,
+	character_id INTEGER NOT NULL,
+	name VARCHAR(50) NOT NULL,
+	value INTEGER NOT NULL,
+	bonus INTEGER NOT NULL DEFAULT 0,
+	tens VARCHAR(20) NOT NULL DEFAULT '',
+	result INTEGER NOT NULL,
+	level INTEGER NOT NULL,
+	luck_spent INTEGER NOT NULL DEFAULT 0,
+	rolled_at DATETIME NOT NULL,
+	source VARCHAR(20) NOT NULL DEFAULT '',
+	pushed_from INTEGER,
+	justification VARCHAR(500) NOT NULL DEFAULT '',
+	consequence VARCHAR(1000) NOT NULL DEFAULT '',
+	CONSTRAINT fk_character_rolls FOREIGN KEY (character_id) REFERENCES characters(id) ON DELETE CASCADE,
+	CONSTRAINT fk_pushed_rolls FOREIGN KEY (pushed_from) REFERENCES rolls(id) ON DELETE CASCADE,
+	CONSTRAINT unique_pushed_from UNIQUE (pushed_from)
+);
+
+CREATE TABLE IF NOT EXISTS sanity_checks (
+	id INTEGER NOT NULL PRIMARY KEY AUTO_INCREMENT,
+	character_id INTEGER NOT NULL,
+	source VARCHAR(255) NOT NULL DEFAULT '',
+	loss VARCHAR(50) NOT NULL,
+	value INTEGER NOT NULL,
+	result INTEGER NOT NULL,
+	level INTEGER NOT NULL,
+	lost INTEGER NOT NULL,
+	temporary BOOLEAN NOT NULL DEFAULT FALSE,
+	indefinite BOOLEAN NOT NULL DEFAULT FALSE,
+	bout VARCHAR(255) NOT NULL DEFAULT '',
+	checked_at DATETIME NOT NULL,
+	CONSTRAINT fk_character_sanity_checks FOREIGN KEY (character_id) REFERENCES characters(id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS occupations (
+	id INTEGER NOT NULL PRIMARY KEY AUTO_INCREMENT,
+	name VARCHAR(50) NOT NULL UNIQUE,
+	skill_points VARCHAR(50) NOT NULL,
+	credit_min INTEGER NOT NULL,
+	credit_max INTEGER NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS occupation_skills (
+	occupation_id INTEGER NOT NULL,
+	skill VARCHAR(50) NOT NULL,
+	CONSTRAINT fk_occupation_os FOREIGN KEY (occupation_id) REFERENCES occupations(id) ON DELETE CASCADE,
+	CONSTRAINT pk_occupation_skills PRIMARY KEY (occupation_id, skill)
+);
+
+CREATE TABLE IF NOT EXISTS weapons (
+	id INTEGER NOT NULL PRIMARY KEY AUTO_INCREMENT,
+	name VARCHAR(50) NOT NULL UNIQUE,
+	skill VARCHAR(50) NOT NULL,
+	damage VARCHAR(50) NOT NULL,
+	weapon_range VARCHAR(50) NOT NULL,
+	attacks VARCHAR(50) NOT NULL,
+	ammo INTEGER NOT NULL DEFAULT 0,
+	malfunction INTEGER NOT NULL DEFAULT 0,
+	CONSTRAINT fk_skill_weapons FOREIGN KEY (skill) REFERENCES skills(name)
+);
+
+CREATE TABLE IF NOT EXISTS character_weapons (
+	character_id INTEGER NOT NULL,
+	weapon_id INTEGER NOT NULL,
+	CONSTRAINT fk_character_cw FOREIGN KEY (character_id) REFERENCES characters(id) ON DELETE CASCADE,
+	CONSTRAINT fk_weapon_cw FOREIGN KEY (weapon_id) REFERENCES weapons(id) ON DELETE CASCADE,
+	CONSTRAINT pk_character_weapons PRIMARY KEY (character_id, weapon_id)
+);
+
+CREATE TABLE IF NOT EXISTS chases (
+	campaign_id INTEGER NOT NULL PRIMARY KEY,
+	round INTEGER NOT NULL DEFAULT 1,
+	CONSTRAINT fk_campaign_chase FOREIGN KEY (campaign_id) REFERENCES campaigns(id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS chase_participants (
+	campaign_id INTEGER NOT NULL,
+	character_id INTEGER NOT NULL,
+	role VARCHAR(12) NOT NULL,
+	mov INTEGER NOT NULL,
+	position INTEGER NOT NULL DEFAULT 0,
+	actions INTEGER NOT NULL DEFAULT 0,
+	CONSTRAINT fk_chase_chp FOREIGN KEY (campaign_id) REFERENCES chases(campaign_id) ON DELETE CASCADE,
+	CONSTRAINT fk_character_chp FOREIGN KEY (character_id) REFERENCES characters(id) ON DELETE CASCADE,
+	CONSTRAINT pk_chase_participants PRIMARY KEY (campaign_id, character_id)
+);
+
+CREATE TABLE IF NOT EXISTS chase_locations (
+	campaign_id INTEGER NOT NULL,
+	position INTEGER NOT NULL,
+	name VARCHAR(255) NOT NULL DEFAULT '',
+	obstacle VARCHAR(12) NOT NULL DEFAULT '',
+	skill VARCHAR(255) NOT NULL DEFAULT '',
+	CONSTRAINT fk_chase_chl FOREIGN KEY (campaign_id) REFERENCES chases(campaign_id) ON DELETE CASCADE,
+	CONSTRAINT pk_chase_locations PRIMARY KEY (campaign_id, position)
+);
+
+CREATE TABLE IF NOT EXISTS spells (
+	id INTEGER NOT NULL PRIMARY KEY AUTO_INCREMENT,
+	name VARCHAR(100) NOT NULL UNIQUE,
+	mp_cost INTEGER NOT NULL,
+	san_cost VARCHAR(20) NOT NULL,
+	casting_time VARCHAR(50) NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS character_spells (
+	character_id INTEGER NOT NULL,
+	spell_id INTEGER NOT NULL,
+	CONSTRAINT fk_character_csp FOREIGN KEY (character_id) REFERENCES characters(id) ON DELETE CASCADE,
+	CONSTRAINT fk_spell_csp FOREIGN KEY (spell_id) REFERENCES spells(id) ON DELETE CASCADE,
+	CONSTRAINT pk_character_spells PRIMARY KEY (character_id, spell_id)
+);
+
+CREATE TABLE IF NOT EXISTS tomes (
+	id INTEGER NOT NULL PRIMARY KEY AUTO_INCREMENT,
+	name VARCHAR(100) NOT NULL UNIQUE,
+	language VARCHAR(50) NOT NULL,
+	mythos_gain INTEGER NOT NULL,
+	san_loss VARCHAR(20) NOT NULL,
+	study_weeks INTEGER NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS character_tomes (
+	character_id INTEGER NOT NULL,
+	tome_id INTEGER NOT NULL,
+	studied BOOLEAN NOT NULL DEFAULT FALSE,
+	CONSTRAINT fk_character_cto FOREIGN KEY (character_id) REFERENCES characters(id) ON DELETE CASCADE,
+	CONSTRAINT fk_tome_cto FOREIGN KEY (tome_id) REFERENCES tomes(id) ON DELETE CASCADE,
+	CONSTRAINT pk_character_tomes PRIMARY KEY (character_id, tome_id)
+);
+
+CREATE TABLE IF NOT EXISTS cash_ledger (
+	id INTEGER NOT NULL PRIMARY KEY AUTO_INCREMENT,
+	character_id INTEGER NOT NULL,
+	description VARCHAR(255) NOT NULL,
+	amount BIGINT NOT NULL,
+	booked_at DATETIME NOT NULL,
+	CONSTRAINT fk_character_ledger FOREIGN KEY (character_id) REFERENCES characters(id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS cash_openings (
+	character_id INTEGER NOT NULL PRIMARY KEY,
+	amount BIGINT NOT NULL,
+	CONSTRAINT fk_character_opening FOREIGN KEY (character_id) REFERENCES characters(id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS backstory (
+	id INTEGER NOT NULL PRIMARY KEY AUTO_INCREMENT,
+	character_id INTEGER NOT NULL,
+	section VARCHAR(20) NOT NULL,
+	text VARCHAR(500) NOT NULL,
+	key_connection BOOLEAN NOT NULL DEFAULT FALSE,
+	CONSTRAINT fk_character_backstory FOREIGN KEY (character_id) REFERENCES characters(id) ON DELETE CASCADE
+);
+
 INSERT INTO skills (name, default_value) VALUES ('Anthropologie', 1),
 			('Archäologie', 1),
 			('Autofahren', 20),
@@ -124,6 +395,7 @@ INSERT INTO skills (name, default_value) VALUES ('Anthropologie', 1),
 			('Klettern', 20),
 			('Mechanische Reparaturen', 10),
 			('Medizin', 1),
+			('Nahkampf (Handgemenge)', 25),
 			('Naturkunde', 10),
 			('Okkultismus', 5),
 			('Orientierung', 10),
@@ -132,6 +404,8 @@ INSERT INTO skills (name, default_value) VALUES ('Anthropologie', 1),
 			('Rechtswesen', 5),
 			('Reiten', 5),
 			('Schließtechnik', 1),
+			('Schusswaffen (Faustfeuerwaffen)', 20),
+			('Schusswaffen (Gewehr/Schrotflinte)', 25),
 			('Schweres Gerät', 1),
 			('Schwimmen', 20),
 			('Springen', 20),
@@ -142,4 +416,21 @@ INSERT INTO skills (name, default_value) VALUES ('Anthropologie', 1),
 			('Verborgenes erkennen', 25),
 			('Verkleiden', 5),
 			('Werfen', 20),
-			('Werte schätzen', 5);
\ No newline at end of file
+			('Werte schätzen', 5);
+
+INSERT INTO occupations (name, skill_points, credit_min, credit_max) VALUES ('Antiquar', 'BI×4', 30, 70),
+			('Polizist', 'BI×2+(GE|ST)×2', 9, 30);
+
+INSERT INTO occupation_skills (occupation_id, skill) VALUES (1, 'Werte schätzen'), (1, 'Handwerk'), (1, 'Geschichte'), (1, 'Bibliotheksnutzung'),
+			(1, 'Fremdsprache'), (1, 'Verborgenes erkennen'), (1, 'Charme'), (1, 'Finanzkraft'),
+			(2, 'Erste Hilfe'), (2, 'Rechtswesen'), (2, 'Psychologie'), (2, 'Verborgenes erkennen'), (2, 'Einschüchtern'),
+			(2, 'Autofahren'), (2, 'Finanzkraft');
+
+INSERT INTO weapons (name, skill, damage, weapon_range, attacks, ammo, malfunction) VALUES ('Messer', 'Nahkampf (Handgemenge)', '1d4+DB', 'Berührung', '1', 0, 0),
+			('Revolver .38', 'Schusswaffen (Faustfeuerwaffen)', '1d10', '15 m', '1 (3)', 6, 100);
+
+INSERT INTO spells (name, mp_cost, san_cost, casting_time) VALUES ('Dominieren', 1, '1d4', 'sofort'),
+			('Älteres Zeichen erschaffen', 10, '1d10', '1 Tag');
+
+INSERT INTO tomes (name, language, mythos_gain, san_loss, study_weeks) VALUES ('Necronomicon (lateinisch)', 'Latein', 12, '2d10', 66),
+			('Pnakotische Manuskripte', 'Englisch', 8, '1d10', 45);
@@ -1,5 +1,27 @@
 USE test_nopennopaper;
 
+DROP TABLE backstory;
+DROP TABLE cash_openings;
+DROP TABLE cash_ledger;
+DROP TABLE character_tomes;
+DROP TABLE tomes;
+DROP TABLE character_spells;
+DROP TABLE spells;
+DROP TABLE chase_locations;
+DROP TABLE chase_participants;
+DROP TABLE chases;
+DROP TABLE character_weapons;
+DROP TABLE weapons;
+DROP TABLE occupation_skills;
+DROP TABLE occupations;
+DROP TABLE sanity_checks;
+DROP TABLE rolls;
+DROP TABLE combat_participants;
+DROP TABLE combats;
+DROP TABLE chronicle_characters;
+DROP TABLE chronicle_handouts;
+DROP TABLE chronicle_notes;
+DROP TABLE chronicle_entries;
 DROP TABLE character_info;
 DROP TABLE character_attributes;
 DROP TABLE character_stats;
@@ -7,8 +29,13 @@ DROP TABLE character_skills;
 DROP TABLE character_custom_skills;
 DROP TABLE items;
 DROP TABLE notes;
+DROP TABLE campaign_table;
 DROP TABLE skills;
 DROP TABLE custom_skills;
 DROP TABLE characters;
+DROP TABLE campaign_members;
+DROP TABLE campaign_invites;
+DROP TABLE handouts;
+DROP TABLE campaigns;
 DROP TABLE materials;
 DROP TABLE users;
\ No newline at end of file
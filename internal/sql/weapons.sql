@@ -0,0 +1,19 @@
+CREATE TABLE IF NOT EXISTS weapons (
+	id INTEGER NOT NULL PRIMARY KEY AUTO_INCREMENT,
+	name VARCHAR(50) NOT NULL UNIQUE,
+	skill VARCHAR(50) NOT NULL,
+	damage VARCHAR(50) NOT NULL,
+	weapon_range VARCHAR(50) NOT NULL,
+	attacks VARCHAR(50) NOT NULL,
+	ammo INTEGER NOT NULL DEFAULT 0,
+	malfunction INTEGER NOT NULL DEFAULT 0,
+	CONSTRAINT fk_skill_weapons FOREIGN KEY (skill) REFERENCES skills(name)
+);
+
+CREATE TABLE IF NOT EXISTS character_weapons (
+	character_id INTEGER NOT NULL,
+	weapon_id INTEGER NOT NULL,
+	CONSTRAINT fk_character_cw FOREIGN KEY (character_id) REFERENCES characters(id) ON DELETE CASCADE,
+	CONSTRAINT fk_weapon_cw FOREIGN KEY (weapon_id) REFERENCES weapons(id) ON DELETE CASCADE,
+	CONSTRAINT pk_character_weapons PRIMARY KEY (character_id, weapon_id)
+);
@@ -1,6 +1,7 @@
 const checkbox_ids = ['AnthropologieEdit', 'ArchäologieEdit', 'AutofahrenEdit', 'BibliotheksnutzungEdit', 'BuchführungEdit', 'CharmeEdit', 'EinschüchternEdit', 
     'Elektrische ReparaturenEdit', 'Erste HilfeEdit', 'FinanzkraftEdit', 'GeschichteEdit', 'HorchenEdit', 'KaschierenEdit', 'KletternEdit', 'Mechanische ReparaturenEdit', 'MedizinEdit',
-    'NaturkundeEdit', 'OkkultismusEdit', 'OrientierungEdit', 'PsychoanalyseEdit', 'PsychologieEdit', 'RechtswesenEdit', 'ReitenEdit', 'SchließtechnikEdit', 'Schweres GerätEdit', 
+    'Nahkampf (Handgemenge)Edit', 'NaturkundeEdit', 'OkkultismusEdit', 'OrientierungEdit', 'PsychoanalyseEdit', 'PsychologieEdit', 'RechtswesenEdit', 'ReitenEdit', 'SchließtechnikEdit', 'Schusswaffen (Faustfeuerwaffen)Edit',
+    'Schusswaffen (Gewehr/Schrotflinte)Edit', 'Schweres GerätEdit', 
     'SchwimmenEdit', 'SpringenEdit', 'SpurensucheEdit', 'ÜberredenEdit', 'ÜberzeugenEdit', 'Verborgen bleibenEdit', 'Verborgenes erkennenEdit', 'VerkleidenEdit', 'WerfenEdit', 'Werte schätzenEdit'];
 
 for (const id of checkbox_ids){